@@ -1,130 +1,456 @@
 package hnapi
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
+	"net/url"
 	"path"
+	"time"
 )
 
 // GetItem retrieves a single Hacker News item by its ID.
 // It returns the item or an error if the request fails or the context is canceled.
-func (c *Client) GetItem(ctx context.Context, id int) (*Item, error) {
+// Items that do not exist yield an error wrapping ErrNotFound. With tombstone errors
+// enabled, deleted and dead items yield an error wrapping ErrDeleted or ErrDead,
+// returned together with the item. Call options override client defaults for this call.
+func (c *Client) GetItem(ctx context.Context, id int, opts ...CallOption) (*Item, error) {
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
 	// Construct the URL for the item endpoint
 	endpoint := path.Join("item", fmt.Sprintf("%d.json", id))
 
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetItem", Endpoint: endpoint, ItemID: id})
+
 	// Make the request
 	var item Item
-	if err := c.makeRequest(ctx, endpoint, &item); err != nil {
+	err := c.makeRequest(ctx, endpoint, &item, o)
+	end(err)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get item %d: %w", id, err)
 	}
+	c.metrics.itemsFetched.Add(1)
+
+	if c.Config.UnescapeText {
+		item.Title = html.UnescapeString(item.Title)
+		item.Text = html.UnescapeString(item.Text)
+	}
+
+	if c.Config.TombstoneErrors {
+		if err := tombstoneError(&item); err != nil {
+			return &item, fmt.Errorf("failed to get item %d: %w", id, err)
+		}
+	}
 
 	return &item, nil
 }
 
 // GetUser retrieves a Hacker News user by username.
 // It returns the user or an error if the request fails or the context is canceled.
-func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+// Call options override client defaults for this call.
+func (c *Client) GetUser(ctx context.Context, username string, opts ...CallOption) (*User, error) {
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
 	// Construct the URL for the user endpoint
 	endpoint := path.Join("user", fmt.Sprintf("%s.json", username))
 
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetUser", Endpoint: endpoint, Username: username})
+
 	// Make the request
 	var user User
-	if err := c.makeRequest(ctx, endpoint, &user); err != nil {
+	err := c.makeRequest(ctx, endpoint, &user, o)
+	end(err)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get user %s: %w", username, err)
 	}
 
+	if c.Config.UnescapeText {
+		user.About = html.UnescapeString(user.About)
+	}
+
 	return &user, nil
 }
 
+// GetMaxItem retrieves the current largest item ID from Hacker News.
+// New items are created with increasing IDs, so this can be used to discover new items.
+func (c *Client) GetMaxItem(ctx context.Context) (int, error) {
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetMaxItem", Endpoint: "maxitem.json"})
+
+	var maxID int
+	err := c.makeRequest(ctx, "maxitem.json", &maxID, callOptions{})
+	end(err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get max item: %w", err)
+	}
+
+	return maxID, nil
+}
+
 // GetTopStories retrieves the current top stories from Hacker News.
 // It returns a slice of story IDs or an error if the request fails or the context is canceled.
 func (c *Client) GetTopStories(ctx context.Context) ([]int, error) {
-	return c.getStories(ctx, "topstories.json")
+	return c.getStories(ctx, "topstories.json", callOptions{})
 }
 
 // GetNewStories retrieves the newest stories from Hacker News.
 // It returns a slice of story IDs or an error if the request fails or the context is canceled.
 func (c *Client) GetNewStories(ctx context.Context) ([]int, error) {
-	return c.getStories(ctx, "newstories.json")
+	return c.getStories(ctx, "newstories.json", callOptions{})
 }
 
 // GetBestStories retrieves the best stories from Hacker News.
 // It returns a slice of story IDs or an error if the request fails or the context is canceled.
 func (c *Client) GetBestStories(ctx context.Context) ([]int, error) {
-	return c.getStories(ctx, "beststories.json")
+	return c.getStories(ctx, "beststories.json", callOptions{})
 }
 
 // GetAskStories retrieves the Ask HN stories from Hacker News.
 // It returns a slice of story IDs or an error if the request fails or the context is canceled.
 func (c *Client) GetAskStories(ctx context.Context) ([]int, error) {
-	return c.getStories(ctx, "askstories.json")
+	return c.getStories(ctx, "askstories.json", callOptions{})
 }
 
 // GetShowStories retrieves the Show HN stories from Hacker News.
 // It returns a slice of story IDs or an error if the request fails or the context is canceled.
 func (c *Client) GetShowStories(ctx context.Context) ([]int, error) {
-	return c.getStories(ctx, "showstories.json")
+	return c.getStories(ctx, "showstories.json", callOptions{})
 }
 
 // GetJobStories retrieves the job stories from Hacker News.
 // It returns a slice of story IDs or an error if the request fails or the context is canceled.
 func (c *Client) GetJobStories(ctx context.Context) ([]int, error) {
-	return c.getStories(ctx, "jobstories.json")
+	return c.getStories(ctx, "jobstories.json", callOptions{})
 }
 
 // getStories is a helper function that retrieves story IDs from a specific endpoint.
-// It is used by GetTopStories, GetNewStories, etc.
-func (c *Client) getStories(ctx context.Context, endpoint string) ([]int, error) {
-	var storyIDs []int
-	if err := c.makeRequest(ctx, endpoint, &storyIDs); err != nil {
+// It is used by GetTopStories, GetNewStories, etc. Lists are served from the list
+// cache when one is configured and the call neither bypasses it nor sets a query.
+func (c *Client) getStories(ctx context.Context, endpoint string, o callOptions) ([]int, error) {
+	if c.lists != nil && !o.noCache && o.query == nil {
+		return c.cachedStories(ctx, endpoint, o)
+	}
+	return c.fetchStories(ctx, endpoint, o)
+}
+
+// fetchStories requests the story IDs at endpoint from the API.
+func (c *Client) fetchStories(ctx context.Context, endpoint string, o callOptions) ([]int, error) {
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetStories", Endpoint: endpoint})
+
+	var storyIDs storyList
+	err := c.makeRequest(ctx, endpoint, &storyIDs, o)
+	end(err)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get stories from %s: %w", endpoint, err)
 	}
 
+	// Mirrors and offline stores may ignore the query, so apply its limits here too
+	if o.query != nil {
+		storyIDs = o.query.limit(storyIDs)
+	}
+
 	return storyIDs, nil
 }
 
 // makeRequest performs an HTTP GET request to the specified endpoint and unmarshals the response into the target.
-// It uses the client's configuration for the base URL and timeout.
-func (c *Client) makeRequest(ctx context.Context, endpoint string, target interface{}) error {
-	// Create a new HTTP request
-	fullURL := c.Config.BaseURL + endpoint
+// Each attempt is bounded by RequestTimeout and reported to the configured hooks. Network
+// errors, 429, and 5xx responses are retried up to MaxRetries times, paced by the
+// BackoffStrategy or BackoffInterval, unless the call disables retries or the retry
+// budget is spent. In offline mode, the request is served from the offline store instead.
+// Endpoints covered by the CachePolicy are served from the response cache while fresh,
+// and concurrent misses for the same endpoint share one request.
+func (c *Client) makeRequest(ctx context.Context, endpoint string, target interface{}, o callOptions) error {
+	if c.Config.Offline != nil {
+		return c.offlineRequest(ctx, endpoint, target)
+	}
+
+	ttl, cacheable := c.responses.ttl(endpoint)
+	if !cacheable || o.noCache || o.query != nil || o.conditional != nil {
+		return c.retryRequest(ctx, endpoint, target, o)
+	}
+
+	data, call, leader := c.responses.acquire(endpoint, c.clock().Now())
+	if call == nil {
+		c.metrics.cacheHits.Add(1)
+		return c.decodeBody(bytes.NewReader(data), target)
+	}
+	c.metrics.cacheMisses.Add(1)
+
+	if !leader {
+		// Another caller is already fetching the endpoint; share its response
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		switch {
+		case call.err == nil:
+			return c.decodeBody(bytes.NewReader(call.data), target)
+		case errors.Is(call.err, context.Canceled), errors.Is(call.err, context.DeadlineExceeded):
+			// The fetch was cut short by its caller's context, not ours
+			return c.retryRequest(ctx, endpoint, target, o)
+		default:
+			return call.err
+		}
+	}
 
+	o.capture = new(bytes.Buffer)
+	err := c.retryRequest(ctx, endpoint, target, o)
+	c.responses.finish(endpoint, call, o.capture.Bytes(), err, ttl, c.clock().Now())
+	return err
+}
+
+// retryRequest performs the attempts of makeRequest until one succeeds or a failure
+// is not worth retrying.
+func (c *Client) retryRequest(ctx context.Context, endpoint string, target interface{}, o callOptions) error {
+	maxRetries := c.Config.MaxRetries
+	if o.noRetry {
+		maxRetries = 0
+	}
+
+	c.retryBudget.request(c.clock().Now())
+
+	for attempt := 1; ; attempt++ {
+		if o.attempts != nil {
+			*o.attempts = attempt
+		}
+		statusCode, err := c.attemptRequest(ctx, endpoint, target, o, attempt)
+		if err == nil || attempt > maxRetries || ctx.Err() != nil || !isRetryable(statusCode, err) {
+			return err
+		}
+
+		if !c.retryBudget.withdraw(c.clock().Now()) {
+			c.logger().Debug("retry budget exhausted", "endpoint", endpoint, "attempt", attempt, "error", err)
+			return &RetryBudgetError{Endpoint: endpoint, Attempts: attempt, Err: err}
+		}
+
+		c.metrics.retries.Add(1)
+		c.logger().Debug("retrying request", "endpoint", endpoint, "attempt", attempt, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-c.clock().After(c.backoff(attempt)):
+		}
+	}
+}
+
+// attemptRequest performs one attempt of makeRequest, recording metrics and calling hooks.
+// With MaxInFlight set, the attempt first waits for a transport slot, and then for
+// the Limiter, if any, so requests are paced in priority order.
+func (c *Client) attemptRequest(ctx context.Context, endpoint string, target interface{}, o callOptions, attempt int) (int, error) {
+	if err := c.scheduler.acquire(ctx, o.priority); err != nil {
+		return 0, err
+	}
+	defer c.scheduler.release()
+
+	if c.Config.Limiter != nil {
+		if err := c.Config.Limiter.Wait(ctx); err != nil {
+			return 0, fmt.Errorf("failed to wait for rate limiter: %w", err)
+		}
+	}
+
+	c.requestStart(ctx, RequestStartInfo{Endpoint: endpoint, Attempt: attempt})
+	c.metrics.inFlight.Add(1)
+	start := time.Now()
+
+	attemptCtx := ctx
+	if c.Config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, c.Config.RequestTimeout)
+		defer cancel()
+	}
+
+	base := c.failover.current(c.Config)
+	var snippet *bodySnippet
+	if c.Config.Debug {
+		snippet = &bodySnippet{}
+	}
+	statusCode, err := c.doRequest(attemptCtx, base, endpoint, target, o, snippet)
+
+	// Requests abandoned by the caller say nothing about the server's health
+	if ctx.Err() == nil {
+		c.failover.report(base, err == nil || !isRetryable(statusCode, err), c.logger())
+	}
+
+	duration := time.Since(start)
+	c.metrics.inFlight.Add(-1)
+	c.metrics.requests.Add(1)
+	c.metrics.latency.Add(int64(duration))
+	if err != nil {
+		c.metrics.failures.Add(1)
+	}
+
+	if c.Config.Debug {
+		url := base + endpoint
+		if o.query != nil {
+			url += "?" + o.query.encode()
+		}
+		c.logRequest(ctx, debugRequest{
+			url:        url,
+			attempt:    attempt,
+			statusCode: statusCode,
+			duration:   duration,
+			body:       snippet,
+			err:        err,
+		})
+	}
+
+	c.requestEnd(ctx, RequestEndInfo{
+		Endpoint:   endpoint,
+		Attempt:    attempt,
+		Duration:   duration,
+		StatusCode: statusCode,
+		Err:        err,
+	})
+
+	return statusCode, err
+}
+
+// isRetryable reports whether a failed attempt may succeed when repeated: the request
+// never got a response, or the server was overloaded or failing.
+func isRetryable(statusCode int, err error) bool {
+	switch {
+	case statusCode == http.StatusTooManyRequests, statusCode >= 500:
+		return true
+	case statusCode != 0:
+		return false
+	default:
+		// Transport errors are *url.Error; malformed URLs fail before sending
+		var urlErr *url.Error
+		return errors.As(err, &urlErr) && urlErr.Op != "parse"
+	}
+}
+
+// doRequest performs a single attempt of makeRequest and returns the HTTP status code,
+// or 0 if no response was received. Query parameters of the call are added to the URL.
+// A conditional call succeeds on a 304 response without touching target. The body
+// of a successful response is copied to the call's capture buffer, if any. If snippet
+// is not nil, it receives the start of the response body for debug logging.
+func (c *Client) doRequest(ctx context.Context, base, endpoint string, target interface{}, o callOptions, snippet *bodySnippet) (int, error) {
 	// Create a new request with the provided context
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	req, err := c.newRequest(ctx, base, endpoint)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if o.query != nil {
+		req.URL.RawQuery = o.query.encode()
+	}
+
+	cond := o.conditional
+	if cond != nil {
+		cond.prepare(req)
 	}
 
 	// Execute the request
-	resp, err := c.Config.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return 0, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if cond != nil && cond.record(resp) {
+		c.metrics.notModified.Add(1)
+		return resp.StatusCode, nil
+	}
+
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		if snippet != nil {
+			_, _ = io.Copy(snippet, io.LimitReader(resp.Body, debugBodyLimit+1))
+		}
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Decode the response body as it streams in
+	src := snippet.capture(resp.Body)
+	if o.capture != nil {
+		o.capture.Reset()
+		src = io.TeeReader(src, o.capture)
 	}
+	body := newLimitedBody(src, c.Config.MaxResponseSize)
+	if err := c.decodeBody(body, target); err != nil {
+		return resp.StatusCode, err
+	}
+
+	return resp.StatusCode, nil
+}
 
-	// Read and parse the response body
-	body, err := io.ReadAll(resp.Body)
+// decodeResponse decodes a JSON response body into target as it streams in,
+// through a pooled buffered reader so decoding many responses reuses the same read
+// buffers. An empty body or a JSON null yields ErrNotFound. In strict mode, fields
+// that target does not define are an error.
+func decodeResponse(body io.Reader, target interface{}, strict bool) error {
+	r := getReader(body)
+	defer putReader(r)
+
+	first, err := skipSpace(r)
+	if err == io.EOF {
+		return ErrNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// If we got an empty response or "null", return an error
-	if len(body) == 0 || string(body) == "null" {
-		return fmt.Errorf("item not found or null response")
+	decoder := json.NewDecoder(r)
+	if strict {
+		decoder.DisallowUnknownFields()
 	}
 
-	// Unmarshal the JSON response into the target
-	if err := json.Unmarshal(body, target); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+	// Only null starts with 'n'; decode it separately so target is left untouched
+	if first == 'n' {
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return ErrNotFound
 	}
 
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
 	return nil
 }
+
+// skipSpace discards leading JSON whitespace and returns the next byte without
+// consuming it.
+func skipSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		return b, r.UnreadByte()
+	}
+}
+
+// newRequest creates an HTTP GET request for the specified endpoint relative to base.
+// The configured User-Agent and default headers are applied.
+func (c *Client) newRequest(ctx context.Context, base, endpoint string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Config.UserAgent != "" {
+		req.Header.Set("User-Agent", c.Config.UserAgent)
+	}
+	for key, values := range c.Config.DefaultHeaders {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	return req, nil
+}
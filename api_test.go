@@ -124,7 +124,7 @@ func TestGetItem(t *testing.T) {
 			defer server.Close()
 
 			// Create client with the test server URL
-			client := NewClient(WithBaseURL(server.URL + "/"))
+			client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
 
 			// Call GetItem
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -226,7 +226,7 @@ func TestGetUser(t *testing.T) {
 			defer server.Close()
 
 			// Create client with the test server URL
-			client := NewClient(WithBaseURL(server.URL + "/"))
+			client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
 
 			// Call GetUser
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -308,6 +308,7 @@ func TestHTTPClientError(t *testing.T) {
 	client := NewClient(
 		WithBaseURL("https://example.com/"),
 		WithHTTPClient(errorClient),
+		WithMaxRetries(0),
 	)
 
 	// Try to get an item
@@ -362,3 +363,221 @@ func TestResponseBodyReadError(t *testing.T) {
 		t.Errorf("Expected error from response body read, got nil")
 	}
 }
+
+func TestRequestHeaders(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantUserAgent string
+		wantHeader    string
+	}{
+		{
+			name:          "default user agent",
+			wantUserAgent: DefaultUserAgent,
+		},
+		{
+			name:          "custom user agent",
+			opts:          []Option{WithUserAgent("myapp/1.0")},
+			wantUserAgent: "myapp/1.0",
+		},
+		{
+			name: "default headers",
+			opts: []Option{
+				WithUserAgent("myapp/1.0"),
+				WithDefaultHeaders(http.Header{"x-app-id": {"42"}}),
+			},
+			wantUserAgent: "myapp/1.0",
+			wantHeader:    "42",
+		},
+		{
+			name: "default headers override user agent",
+			opts: []Option{
+				WithDefaultHeaders(http.Header{"User-Agent": {"override/2.0"}}),
+			},
+			wantUserAgent: "override/2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`100`))
+				if err != nil {
+					t.Fatalf("Failed to write mock response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(append([]Option{WithBaseURL(server.URL + "/")}, tt.opts...)...)
+			if _, err := client.GetMaxItem(context.Background()); err != nil {
+				t.Fatalf("GetMaxItem() error = %v", err)
+			}
+
+			if ua := got.Get("User-Agent"); ua != tt.wantUserAgent {
+				t.Errorf("Expected User-Agent %q, got %q", tt.wantUserAgent, ua)
+			}
+			if h := got.Get("X-App-Id"); h != tt.wantHeader {
+				t.Errorf("Expected X-App-Id %q, got %q", tt.wantHeader, h)
+			}
+		})
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+		wantID  int
+	}{
+		{name: "object", body: `{"id": 8863}`, wantID: 8863},
+		{name: "leading whitespace", body: " \n\t{\"id\": 1}", wantID: 1},
+		{name: "empty body", body: "", wantErr: ErrNotFound},
+		{name: "whitespace only", body: " \n", wantErr: ErrNotFound},
+		{name: "null", body: "null", wantErr: ErrNotFound},
+		{name: "null with newline", body: "null\n", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item Item
+			err := decodeResponse(strings.NewReader(tt.body), &item, false)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("decodeResponse() error = %v, want %v", err, tt.wantErr)
+			}
+			if item.ID != tt.wantID {
+				t.Errorf("Expected ID %d, got %d", tt.wantID, item.ID)
+			}
+		})
+	}
+
+	for _, body := range []string{`nul`, `{"id": `, `not json`} {
+		var item Item
+		if err := decodeResponse(strings.NewReader(body), &item, false); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("decodeResponse(%q) error = %v, want a decoding error", body, err)
+		}
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	body := `{"id": 8863, "type": "story", "title": "My YC app: Dropbox"}`
+
+	tests := []struct {
+		name    string
+		limit   int64
+		wantErr error
+	}{
+		{name: "below limit", limit: int64(len(body)) + 10},
+		{name: "exactly at limit", limit: int64(len(body))},
+		{name: "over limit", limit: int64(len(body)) - 1, wantErr: ErrResponseTooLarge},
+		{name: "disabled", limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(body))
+				if err != nil {
+					t.Fatalf("Failed to write mock response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL+"/"), WithMaxResponseSize(tt.limit))
+
+			item, err := client.GetItem(context.Background(), 8863)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetItem() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && item.ID != 8863 {
+				t.Errorf("Expected item 8863, got %d", item.ID)
+			}
+		})
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	body := `{"id": 8863, "type": "story", "flagged": true}`
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "lenient by default"},
+		{name: "strict rejects unknown fields", opts: []Option{WithStrictDecoding()}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(body))
+				if err != nil {
+					t.Fatalf("Failed to write mock response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(append([]Option{WithBaseURL(server.URL + "/")}, tt.opts...)...)
+
+			_, err := client.GetItem(context.Background(), 8863)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "flagged") {
+				t.Errorf("Expected error to name the unknown field, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithUnescapedText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		body := `{"id": 1, "type": "comment", "title": "Rock &amp; Roll", "text": "It&#x27;s <i>fine</i>"}`
+		if strings.HasPrefix(r.URL.Path, "/user/") {
+			body = `{"id": "pg", "about": "Bug &amp; fix"}`
+		}
+		_, err := w.Write([]byte(body))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	raw := NewClient(WithBaseURL(server.URL + "/"))
+	item, err := raw.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Text != "It&#x27;s <i>fine</i>" {
+		t.Errorf("Expected text to stay escaped by default, got %q", item.Text)
+	}
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithUnescapedText())
+
+	item, err = client.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Title != "Rock & Roll" || item.Text != "It's <i>fine</i>" {
+		t.Errorf("Expected unescaped item, got title %q and text %q", item.Title, item.Text)
+	}
+
+	user, err := client.GetUser(ctx, "pg")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.About != "Bug & fix" {
+		t.Errorf("Expected unescaped about, got %q", user.About)
+	}
+}
@@ -0,0 +1,94 @@
+package hnapi
+
+import (
+	"math"
+	"time"
+)
+
+// BackoffStrategy decides how long the client waits before retrying a failed
+// request. attempt is the number of the attempt that failed, starting at 1.
+type BackoffStrategy interface {
+	NextDelay(attempt int) time.Duration
+}
+
+// BackoffFunc adapts a function to the BackoffStrategy interface.
+type BackoffFunc func(attempt int) time.Duration
+
+// NextDelay calls f(attempt).
+func (f BackoffFunc) NextDelay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// ConstantBackoff waits the same Interval before every retry, like configuring
+// only BackoffInterval.
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// NextDelay returns the interval.
+func (b ConstantBackoff) NextDelay(attempt int) time.Duration {
+	return b.Interval
+}
+
+// ExponentialBackoff multiplies the delay by Multiplier after every attempt,
+// starting at Initial and never exceeding Max.
+type ExponentialBackoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+
+	// Max caps the delay. Zero means no cap.
+	Max time.Duration
+
+	// Multiplier is the factor between consecutive delays. Values below 1 use 2.
+	Multiplier float64
+}
+
+// NextDelay returns Initial * Multiplier^(attempt-1), capped at Max.
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(max(attempt, 1)-1))
+	return capDelay(delay, b.Max)
+}
+
+// FibonacciBackoff grows the delay along the Fibonacci sequence, Initial times 1,
+// 1, 2, 3, 5, and so on, never exceeding Max. It backs off more gently than
+// doubling.
+type FibonacciBackoff struct {
+	// Initial is the delay before the first and second retries.
+	Initial time.Duration
+
+	// Max caps the delay. Zero means no cap.
+	Max time.Duration
+}
+
+// NextDelay returns Initial times the attempt-th Fibonacci number, capped at Max.
+func (b FibonacciBackoff) NextDelay(attempt int) time.Duration {
+	prev, cur := 0.0, 1.0
+	for i := 1; i < attempt && cur < math.MaxInt64; i++ {
+		prev, cur = cur, prev+cur
+	}
+	return capDelay(float64(b.Initial)*cur, b.Max)
+}
+
+// capDelay converts delay to a duration no longer than limit, or than the longest
+// representable duration if limit is zero.
+func capDelay(delay float64, limit time.Duration) time.Duration {
+	if limit > 0 && delay > float64(limit) {
+		return limit
+	}
+	if delay >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+// backoff returns how long to wait before retrying the given failed attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	if c.Config.BackoffStrategy != nil {
+		return c.Config.BackoffStrategy.NextDelay(attempt)
+	}
+	return c.Config.BackoffInterval
+}
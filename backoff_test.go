@@ -0,0 +1,91 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackoffStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy BackoffStrategy
+		want     []time.Duration
+	}{
+		{
+			name:     "constant",
+			strategy: ConstantBackoff{Interval: time.Second},
+			want:     []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:     "exponential",
+			strategy: ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second},
+			want:     []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second},
+		},
+		{
+			name:     "exponential multiplier",
+			strategy: ExponentialBackoff{Initial: time.Second, Multiplier: 3},
+			want:     []time.Duration{time.Second, 3 * time.Second, 9 * time.Second},
+		},
+		{
+			name:     "fibonacci",
+			strategy: FibonacciBackoff{Initial: time.Second, Max: 6 * time.Second},
+			want:     []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 6 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				if got := tt.strategy.NextDelay(i + 1); got != want {
+					t.Errorf("NextDelay(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffOverflow(t *testing.T) {
+	for _, strategy := range []BackoffStrategy{
+		ExponentialBackoff{Initial: time.Hour},
+		FibonacciBackoff{Initial: time.Hour},
+	} {
+		if got := strategy.NextDelay(1000); got <= 0 {
+			t.Errorf("%T.NextDelay(1000) = %v, want a positive delay", strategy, got)
+		}
+	}
+}
+
+func TestWithBackoffStrategy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithMaxRetries(3),
+		WithBackoffInterval(time.Hour),
+		WithBackoffStrategy(BackoffFunc(func(attempt int) time.Duration {
+			attempts = append(attempts, attempt)
+			return time.Millisecond
+		})),
+	)
+
+	if _, err := client.GetItem(context.Background(), 1); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	want := []int{1, 2, 3}
+	if len(attempts) != len(want) {
+		t.Fatalf("Expected delays for attempts %v, got %v", want, attempts)
+	}
+	for i := range want {
+		if attempts[i] != want[i] {
+			t.Fatalf("Expected delays for attempts %v, got %v", want, attempts)
+		}
+	}
+}
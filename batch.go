@@ -2,88 +2,352 @@ package hnapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
 )
 
+// BatchMode decides what a batch call does when an item fails.
+type BatchMode int
+
+const (
+	// BatchBestEffort attempts every ID and reports the failures together with
+	// the items that loaded. It is the default.
+	BatchBestEffort BatchMode = iota
+
+	// BatchFailFast stops starting new fetches on the first failure, once its
+	// retries are spent, and fails the whole call with it. Deleted and dead items
+	// dropped by SkipDeadAndDeleted are not failures.
+	BatchFailFast
+)
+
+// String returns the name of the batch mode.
+func (m BatchMode) String() string {
+	switch m {
+	case BatchBestEffort:
+		return "best-effort"
+	case BatchFailFast:
+		return "fail-fast"
+	default:
+		return fmt.Sprintf("BatchMode(%d)", int(m))
+	}
+}
+
+// batchChunkSize is the number of IDs GetItemsBatch fetches at a time. Each chunk's
+// results are collected before the next one starts, so very large batches do not
+// hold per-item bookkeeping for every ID at once.
+const batchChunkSize = 1000
+
 // GetItemsBatch retrieves multiple items concurrently by their IDs.
 // It respects the client's Concurrency configuration to limit the number of concurrent requests.
-func (c *Client) GetItemsBatch(ctx context.Context, ids []int) ([]*Item, error) {
+// Items are returned in the order of ids; items that fail to load are omitted and the
+// first failure is returned as the error. Pass SkipDeadAndDeleted to drop tombstoned
+// items quietly, and WithBatchMode(BatchFailFast) to give up on the first failure
+// instead, returning no items. Batches of tens of thousands of IDs are fetched in chunks.
+func (c *Client) GetItemsBatch(ctx context.Context, ids []int, opts ...CallOption) ([]*Item, error) {
 	if len(ids) == 0 {
 		return []*Item{}, nil
 	}
-
-	// Create a context that we can cancel if needed
-	ctx, cancel := context.WithCancel(ctx)
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
 	defer cancel()
 
-	// Channel to collect results
-	resultCh := make(chan *itemResult, len(ids))
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetItemsBatch", BatchSize: len(ids)})
+	collector := newItemCollector(min(len(ids), batchChunkSize))
+	if err := c.fetchItemsChunked(ctx, ids, o, opts, collector.add); err != nil {
+		end(err)
+		return nil, err
+	}
+	items, err := collector.result()
+	end(err)
 
-	// Use a semaphore to limit concurrency
-	sem := make(chan struct{}, c.Config.Concurrency)
+	return items, err
+}
 
-	// WaitGroup to wait for all goroutines to finish
-	var wg sync.WaitGroup
+// ItemResult is the outcome of fetching one item of a batch.
+type ItemResult struct {
+	// ID is the requested item ID.
+	ID int
 
-	// Start a goroutine for each item ID
-	for _, id := range ids {
-		// Add to wait group before spawning goroutine
-		wg.Add(1)
+	// Item is the fetched item, or nil if Err is set. With tombstone errors
+	// enabled, deleted and dead items are returned together with Err.
+	Item *Item
 
-		go func(id int) {
-			defer wg.Done()
+	// Err is the reason the item could not be fetched.
+	Err error
 
-			// Acquire a token from the semaphore
-			sem <- struct{}{}
-			defer func() { <-sem }() // Release the token when done
+	// Attempts is the number of requests made for the item, including retries.
+	// It is zero for items served from a cache or an offline store.
+	Attempts int
+}
 
-			// Get the item
-			item, err := c.GetItem(ctx, id)
+// GetItemsBatchResults fetches items like GetItemsBatch but returns one result per
+// ID, in the order of ids, instead of only the first error. Each item is retried on
+// its own according to the retry policy, so a transient failure of one item neither
+// fails the batch nor the item unless its retries run out. Pass SkipDeadAndDeleted
+// to drop the results of tombstoned items. In fail-fast mode, the IDs not attempted
+// after the first failure have results with an error wrapping it.
+func (c *Client) GetItemsBatchResults(ctx context.Context, ids []int, opts ...CallOption) []ItemResult {
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
 
-			// Send the result through the channel
-			resultCh <- &itemResult{
-				Item:  item,
-				ID:    id,
-				Error: err,
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetItemsBatchResults", BatchSize: len(ids)})
+	out := make([]ItemResult, 0, len(ids))
+	failed := 0
+	_ = c.fetchItemsChunked(ctx, ids, o, opts, func(results []itemResult) {
+		for _, result := range results {
+			if result.Error != nil {
+				failed++
 			}
-		}(id)
+			out = append(out, ItemResult{ID: result.ID, Item: result.Item, Err: result.Error, Attempts: result.Attempts})
+		}
+	})
+
+	if failed > 0 {
+		end(fmt.Errorf("failed to get %d of %d items", failed, len(ids)))
+	} else {
+		end(nil)
 	}
+	return out
+}
 
-	// Close the results channel once all goroutines are done
-	go func() {
-		wg.Wait()
-		close(resultCh)
-	}()
+// fetchItemsChunked fetches ids in chunks of batchChunkSize and passes the results
+// of each chunk to flush before starting the next, dropping tombstones if the call
+// skips them. In fail-fast mode it stops after the chunk with the first failure and
+// returns that failure.
+func (c *Client) fetchItemsChunked(ctx context.Context, ids []int, o callOptions, opts []CallOption, flush func([]itemResult)) error {
+	progress := newProgressReporter(o.progress, len(ids))
 
-	// Collect results
-	items := make([]*Item, 0, len(ids))
-	errors := make([]error, 0)
+	if o.batchMode == BatchFailFast {
+		var cancel context.CancelCauseFunc
+		ctx, cancel = context.WithCancelCause(ctx)
+		defer cancel(nil)
+		opts = append(slices.Clip(opts), abortOnFailure(cancel))
+	}
+
+	for start := 0; start < len(ids); start += batchChunkSize {
+		chunk := ids[start:min(start+batchChunkSize, len(ids))]
+		results := c.fetchItemsProgress(ctx, chunk, progress, opts)
+		if o.skipTombstones {
+			results = withoutTombstones(results)
+		}
+		flush(results)
 
-	for result := range resultCh {
+		if o.batchMode == BatchFailFast && ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+	}
+	return nil
+}
+
+// itemCollector accumulates per-item results into the GetItemsBatch return values,
+// keeping only the items and the first error.
+type itemCollector struct {
+	items    []*Item
+	firstErr error
+}
+
+// newItemCollector creates a collector with room for size items.
+func newItemCollector(size int) *itemCollector {
+	return &itemCollector{items: make([]*Item, 0, size)}
+}
+
+// add records the items and the first failure of results.
+func (ic *itemCollector) add(results []itemResult) {
+	for _, result := range results {
 		if result.Error != nil {
-			errors = append(errors, fmt.Errorf("failed to get item %d: %w", result.ID, result.Error))
+			if ic.firstErr == nil {
+				ic.firstErr = fmt.Errorf("failed to get item %d: %w", result.ID, result.Error)
+			}
 		} else if result.Item != nil {
-			items = append(items, result.Item)
+			ic.items = append(ic.items, result.Item)
 		}
 	}
+}
 
+// result returns the collected items and the first failure.
+func (ic *itemCollector) result() ([]*Item, error) {
 	// Return an error if we couldn't get any items
-	if len(items) == 0 && len(errors) > 0 {
-		return nil, fmt.Errorf("failed to get any items: %w", errors[0])
+	if len(ic.items) == 0 && ic.firstErr != nil {
+		return nil, fmt.Errorf("failed to get any items: %w", ic.firstErr)
 	}
 
-	// Return a combined error if some items failed
-	if len(errors) > 0 {
-		return items, errors[0]
+	// Return the first error if some items failed
+	return ic.items, ic.firstErr
+}
+
+// withoutTombstones removes results for deleted and dead items, whether they were
+// returned as items or reported with ErrDeleted or ErrDead.
+func withoutTombstones(results []itemResult) []itemResult {
+	kept := results[:0]
+	for _, result := range results {
+		if isTombstone(result.Error) {
+			continue
+		}
+		if result.Error == nil && result.Item != nil && tombstoneError(result.Item) != nil {
+			continue
+		}
+		kept = append(kept, result)
+	}
+	return kept
+}
+
+// isTombstone reports whether err is a tombstone error of a deleted or dead item.
+func isTombstone(err error) bool {
+	return errors.Is(err, ErrDeleted) || errors.Is(err, ErrDead)
+}
+
+// fetchItems retrieves items concurrently and returns one result per ID, in the order of ids.
+// It respects the client's Concurrency configuration to limit the number of concurrent requests.
+func (c *Client) fetchItems(ctx context.Context, ids []int, opts ...CallOption) []itemResult {
+	return c.fetchItemsProgress(ctx, ids, nil, opts)
+}
+
+// fetchItemsProgress implements fetchItems, reporting a step to progress for every
+// completed item. A pool of at most Concurrency workers fetches the items, so the
+// number of goroutines does not grow with the number of IDs. If the call aborts on
+// failure, the first failure is passed to abort, and once ctx is done the remaining
+// IDs are not attempted.
+func (c *Client) fetchItemsProgress(ctx context.Context, ids []int, progress *progressReporter, opts []CallOption) []itemResult {
+	results := make([]itemResult, len(ids))
+	opts = itemOptions(bulkOptions(opts))
+	o := newCallOptions(opts)
+
+	// Workers claim the next unfetched index until none are left
+	var next atomic.Int64
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(c.Config.Concurrency, len(ids)); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Each worker counts the attempts of its current item
+			var attempts int
+			opts := append(slices.Clip(opts), countAttempts(&attempts))
+
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(ids) {
+					return
+				}
+
+				if o.abort != nil && ctx.Err() != nil {
+					results[i] = itemResult{ID: ids[i], Error: fmt.Errorf("batch aborted: %w", context.Cause(ctx))}
+					progress.step(1)
+					continue
+				}
+
+				// Get the item; each index is claimed by one worker
+				attempts = 0
+				item, err := c.GetItem(ctx, ids[i], opts...)
+				results[i] = itemResult{
+					Item:     item,
+					ID:       ids[i],
+					Error:    err,
+					Attempts: attempts,
+				}
+				progress.step(1)
+
+				if o.abort != nil && err != nil && ctx.Err() == nil && !(o.skipTombstones && isTombstone(err)) {
+					o.abort(fmt.Errorf("failed to get item %d: %w", ids[i], err))
+				}
+			}
+		}()
 	}
 
-	return items, nil
+	wg.Wait()
+
+	return results
 }
 
 // itemResult holds the result of getting a single item, used by GetItemsBatch.
 type itemResult struct {
-	Item  *Item
-	ID    int
-	Error error
+	Item     *Item
+	ID       int
+	Error    error
+	Attempts int
+}
+
+// GetUsersBatch retrieves multiple users concurrently by their usernames.
+// It respects the client's Concurrency configuration to limit the number of concurrent requests.
+// Call options apply to every user's fetch. The users are returned in the order of
+// usernames, leaving out those that failed; the error joins the failure of every
+// user that was left out.
+func (c *Client) GetUsersBatch(ctx context.Context, usernames []string, opts ...CallOption) ([]*User, error) {
+	if len(usernames) == 0 {
+		return []*User{}, nil
+	}
+
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetUsersBatch", BatchSize: len(usernames)})
+	users, err := c.getUsersBatch(ctx, usernames, opts)
+	end(err)
+
+	return users, err
+}
+
+// getUsersBatch implements GetUsersBatch for a non-empty list of usernames. Like
+// fetchItemsProgress, a pool of at most Concurrency workers fetches the users and
+// each result is stored at the index of its username.
+func (c *Client) getUsersBatch(ctx context.Context, usernames []string, opts []CallOption) ([]*User, error) {
+	results := make([]userResult, len(usernames))
+	opts = bulkOptions(opts)
+
+	// Workers claim the next unfetched index until none are left
+	var next atomic.Int64
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(c.Config.Concurrency, len(usernames)); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(usernames) {
+					return
+				}
+
+				user, err := c.GetUser(ctx, usernames[i], opts...)
+				results[i] = userResult{
+					User:     user,
+					Username: usernames[i],
+					Error:    err,
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Collect results in input order
+	users := make([]*User, 0, len(usernames))
+	var errs []error
+
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to get user %s: %w", result.Username, result.Error))
+		} else if result.User != nil {
+			users = append(users, result.User)
+		}
+	}
+
+	// Return an error if we couldn't get any users
+	if len(users) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("failed to get any users: %w", errors.Join(errs...))
+	}
+
+	return users, errors.Join(errs...)
+}
+
+// userResult holds the result of getting a single user, used by GetUsersBatch.
+type userResult struct {
+	User     *User
+	Username string
+	Error    error
 }
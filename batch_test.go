@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -265,3 +267,346 @@ func TestGetItemsBatchWithContext(t *testing.T) {
 		t.Errorf("Expected fewer than 10 requests due to context cancellation, got %d", requestsMade)
 	}
 }
+
+func TestGetUsersBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "user/alice.json"):
+			resp = `{"id": "alice", "karma": 10}`
+		case strings.HasSuffix(r.URL.Path, "user/bob.json"):
+			resp = `{"id": "bob", "karma": 20}`
+		default:
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	users, err := client.GetUsersBatch(ctx, []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("GetUsersBatch() error = %v", err)
+	}
+	if len(users) != 2 {
+		t.Errorf("Expected 2 users, got %d", len(users))
+	}
+
+	users, err = client.GetUsersBatch(ctx, []string{"alice", "missing"})
+	if err == nil {
+		t.Errorf("Expected error for missing user")
+	}
+	if len(users) != 1 || users[0].ID != "alice" {
+		t.Errorf("Expected partial result with alice, got %v", users)
+	}
+
+	users, err = client.GetUsersBatch(ctx, []string{"missing"})
+	if err == nil || users != nil {
+		t.Errorf("Expected nil users and error when all fail, got %v, %v", users, err)
+	}
+
+	users, err = client.GetUsersBatch(ctx, nil)
+	if err != nil || len(users) != 0 {
+		t.Errorf("Expected empty result for empty input, got %v, %v", users, err)
+	}
+}
+
+func TestGetUsersBatchOrder(t *testing.T) {
+	names := []string{"u0", "u1", "u2", "u3", "u4", "u5", "u6", "u7"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSuffix(path.Base(r.URL.Path), ".json")
+
+		// Earlier users answer later, so completion order is the reverse of input order
+		i := slices.Index(names, name)
+		time.Sleep(time.Duration(len(names)-i) * 5 * time.Millisecond)
+
+		if name == "u2" || name == "u5" {
+			_, _ = w.Write([]byte("null"))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id": %q}`, name)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(len(names)))
+
+	users, err := client.GetUsersBatch(context.Background(), names)
+	if err == nil || !strings.Contains(err.Error(), "u2") || !strings.Contains(err.Error(), "u5") {
+		t.Errorf("Expected an error naming u2 and u5, got %v", err)
+	}
+
+	var got []string
+	for _, user := range users {
+		got = append(got, user.ID)
+	}
+	if want := []string{"u0", "u1", "u3", "u4", "u6", "u7"}; !slices.Equal(got, want) {
+		t.Errorf("GetUsersBatch() = %v, want %v", got, want)
+	}
+}
+
+func TestGetItemsBatchSkipDeadAndDeleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch r.URL.Path {
+		case "/item/1.json":
+			resp = `{"id": 1, "type": "comment", "text": "alive"}`
+		case "/item/2.json":
+			resp = `{"id": 2, "type": "comment", "deleted": true}`
+		case "/item/3.json":
+			resp = `{"id": 3, "type": "comment", "dead": true}`
+		default:
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		callOpts  []CallOption
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "tombstones returned by default", wantCount: 3},
+		{name: "tombstones skipped", callOpts: []CallOption{SkipDeadAndDeleted()}, wantCount: 1},
+		{name: "tombstone errors are partial failures", opts: []Option{WithTombstoneErrors()}, wantCount: 1, wantErr: true},
+		{name: "tombstone errors skipped", opts: []Option{WithTombstoneErrors()}, callOpts: []CallOption{SkipDeadAndDeleted()}, wantCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(append([]Option{WithBaseURL(server.URL + "/")}, tt.opts...)...)
+
+			items, err := client.GetItemsBatch(ctx, []int{1, 2, 3}, tt.callOpts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetItemsBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(items) != tt.wantCount || items[0].ID != 1 {
+				t.Errorf("Expected %d items starting with 1, got %v", tt.wantCount, items)
+			}
+		})
+	}
+
+	// Items that do not exist are still failures
+	if _, err := NewClient(WithBaseURL(server.URL+"/")).GetItemsBatch(ctx, []int{1, 4}, SkipDeadAndDeleted()); err == nil {
+		t.Errorf("Expected error for missing item")
+	}
+}
+
+func TestGetItemsBatchChunked(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		var id int
+		if _, err := fmt.Sscanf(r.URL.Path, "/item/%d.json", &id); err != nil {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if id == batchChunkSize+7 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id": %d, "type": "comment"}`, id)
+	}))
+	defer server.Close()
+
+	// More than two chunks, with one failure in the second
+	ids := make([]int, 2*batchChunkSize+10)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	var lastDone, lastTotal int
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(8), WithMaxRetries(0))
+	items, err := client.GetItemsBatch(context.Background(), ids, WithProgress(func(done, total int) {
+		lastDone, lastTotal = done, total
+	}))
+
+	if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("item %d", batchChunkSize+7)) {
+		t.Errorf("Expected the failure of item %d, got %v", batchChunkSize+7, err)
+	}
+	if len(items) != len(ids)-1 {
+		t.Fatalf("Expected %d items, got %d", len(ids)-1, len(items))
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i].ID <= items[i-1].ID {
+			t.Fatalf("Expected items in the order of ids, got %d after %d", items[i].ID, items[i-1].ID)
+		}
+	}
+	if lastDone != len(ids) || lastTotal != len(ids) {
+		t.Errorf("Expected final progress %d/%d, got %d/%d", len(ids), len(ids), lastDone, lastTotal)
+	}
+	if got := maxInFlight.Load(); got > 8 {
+		t.Errorf("Expected at most 8 requests in flight, got %d", got)
+	}
+}
+
+func TestGetItemsBatchResults(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		n := requests[r.URL.Path]
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/item/2.json":
+			// Fails twice, then recovers
+			if n <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/item/3.json":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case "/item/4.json":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var id int
+		_, _ = fmt.Sscanf(r.URL.Path, "/item/%d.json", &id)
+		_, _ = fmt.Fprintf(w, `{"id": %d, "type": "comment"}`, id)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(3), WithBackoffInterval(time.Millisecond))
+	results := client.GetItemsBatchResults(context.Background(), []int{1, 2, 3, 4})
+
+	tests := []struct {
+		id           int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{id: 1, wantAttempts: 1},
+		{id: 2, wantAttempts: 3},
+		{id: 3, wantErr: true, wantAttempts: 4},
+		{id: 4, wantErr: true, wantAttempts: 1},
+	}
+
+	if len(results) != len(tests) {
+		t.Fatalf("Expected %d results, got %d", len(tests), len(results))
+	}
+	for i, tt := range tests {
+		result := results[i]
+		if result.ID != tt.id {
+			t.Errorf("Expected result %d for item %d, got item %d", i, tt.id, result.ID)
+		}
+		if (result.Err != nil) != tt.wantErr {
+			t.Errorf("Item %d: error = %v, wantErr %v", tt.id, result.Err, tt.wantErr)
+		}
+		if !tt.wantErr && (result.Item == nil || result.Item.ID != tt.id) {
+			t.Errorf("Item %d: expected the item, got %v", tt.id, result.Item)
+		}
+		if result.Attempts != tt.wantAttempts {
+			t.Errorf("Item %d: expected %d attempts, got %d", tt.id, tt.wantAttempts, result.Attempts)
+		}
+	}
+}
+
+func TestGetItemsBatchMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		_, _ = fmt.Sscanf(r.URL.Path, "/item/%d.json", &id)
+		switch id {
+		case 2:
+			w.WriteHeader(http.StatusForbidden)
+		case 3:
+			_, _ = w.Write([]byte(`{"id": 3, "type": "comment", "deleted": true}`))
+		default:
+			_, _ = fmt.Fprintf(w, `{"id": %d, "type": "comment"}`, id)
+		}
+	}))
+	defer server.Close()
+
+	ids := make([]int, 50)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	// Concurrency 1 makes the order of fetches follow the IDs
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(1), WithTombstoneErrors())
+	ctx := context.Background()
+
+	t.Run("best-effort", func(t *testing.T) {
+		items, err := client.GetItemsBatch(ctx, ids, WithBatchMode(BatchBestEffort))
+		if err == nil {
+			t.Error("Expected the failure of item 2")
+		}
+		if len(items) != len(ids)-2 {
+			t.Errorf("Expected %d items, got %d", len(ids)-2, len(items))
+		}
+	})
+
+	t.Run("fail-fast", func(t *testing.T) {
+		items, err := client.GetItemsBatch(ctx, ids, WithBatchMode(BatchFailFast))
+		if err == nil || !strings.Contains(err.Error(), "item 2") {
+			t.Errorf("Expected the failure of item 2, got %v", err)
+		}
+		if items != nil {
+			t.Errorf("Expected no items, got %d", len(items))
+		}
+
+		results := client.GetItemsBatchResults(ctx, ids, WithBatchMode(BatchFailFast))
+		for _, result := range results[2:] {
+			if result.Attempts != 0 || result.Err == nil {
+				t.Fatalf("Expected item %d not to be attempted, got %d attempts and error %v", result.ID, result.Attempts, result.Err)
+			}
+		}
+	})
+
+	t.Run("fail-fast skips tombstones", func(t *testing.T) {
+		items, err := client.GetItemsBatch(ctx, append([]int{3}, ids[3:]...), WithBatchMode(BatchFailFast), SkipDeadAndDeleted())
+		if err != nil {
+			t.Fatalf("GetItemsBatch() error = %v", err)
+		}
+		if len(items) != len(ids)-3 {
+			t.Errorf("Expected %d items, got %d", len(ids)-3, len(items))
+		}
+	})
+}
+
+func TestBatchModeString(t *testing.T) {
+	tests := []struct {
+		mode BatchMode
+		want string
+	}{
+		{BatchBestEffort, "best-effort"},
+		{BatchFailFast, "fail-fast"},
+		{BatchMode(7), "BatchMode(7)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("BatchMode(%d).String() = %q, want %q", int(tt.mode), got, tt.want)
+		}
+	}
+}
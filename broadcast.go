@@ -0,0 +1,182 @@
+package hnapi
+
+import (
+	"context"
+	"sync"
+)
+
+// Broadcaster fans the updates of one poll loop out to any number of subscribers,
+// so consumers of the same client do not each poll the updates endpoint. The poll
+// loop starts with the first subscriber and stops when the last one leaves.
+type Broadcaster struct {
+	client *Client
+
+	mu   sync.Mutex
+	subs []*subscriber
+	run  *broadcastRun
+}
+
+// broadcastRun is one generation of the shared poll loop. Its context is canceled
+// when the loop stops, which ends deliveries to its subscribers.
+type broadcastRun struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// subscriber is one subscription of a Broadcaster.
+type subscriber struct {
+	ch     chan Updates
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   func() bool
+
+	// mu serializes delivery with closing the channel
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewBroadcaster creates a broadcaster for the client's updates. It does not poll
+// until the first subscriber arrives.
+func (c *Client) NewBroadcaster() *Broadcaster {
+	return &Broadcaster{client: c}
+}
+
+// Subscribe returns a channel that receives every update polled after the call.
+// Each subscriber gets its own channel, sized and filled according to the
+// UpdatesBufferSize and UpdatesOverflowPolicy configuration; with OverflowBlock, a
+// slow subscriber holds back the others. The subscription ends, and the channel is
+// closed, when the context is canceled, Unsubscribe is called, or the client is
+// closed.
+func (b *Broadcaster) Subscribe(ctx context.Context) (<-chan Updates, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.run == nil {
+		if err := b.startLocked(); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &subscriber{ch: b.client.newUpdatesChannel()}
+	s.ctx, s.cancel = context.WithCancel(b.run.ctx)
+	s.stop = context.AfterFunc(ctx, func() { b.Unsubscribe(s.ch) })
+	b.subs = append(b.subs, s)
+
+	return s.ch, nil
+}
+
+// Unsubscribe ends the subscription that returned ch and closes the channel.
+// Unknown or already closed channels are ignored.
+func (b *Broadcaster) Unsubscribe(ch <-chan Updates) {
+	b.mu.Lock()
+	var found *subscriber
+	for i, s := range b.subs {
+		if s.ch == ch {
+			found = s
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			break
+		}
+	}
+
+	// The last subscriber to leave stops the poll loop
+	if found != nil && len(b.subs) == 0 && b.run != nil {
+		b.run.cancel()
+		b.run = nil
+	}
+	b.mu.Unlock()
+
+	if found != nil {
+		found.close()
+	}
+}
+
+// Subscribers returns the number of active subscriptions.
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subs)
+}
+
+// startLocked starts a generation of the poll loop; b.mu must be held.
+func (b *Broadcaster) startLocked() error {
+	c := b.client
+
+	ctx, cancel, err := c.startBackground(context.Background())
+	if err != nil {
+		return err
+	}
+
+	run := &broadcastRun{ctx: ctx, cancel: cancel}
+	b.run = run
+
+	source := c.newUpdatesChannel()
+	c.goBackground(func() { c.runUpdates(ctx, source, nil) })
+	c.goBackground(func() {
+		for updates := range source {
+			for _, s := range b.subscribers(run) {
+				s.deliver(c, updates)
+			}
+		}
+		b.finish(run)
+	})
+
+	return nil
+}
+
+// subscribers returns the current subscribers if run is still the active poll loop.
+func (b *Broadcaster) subscribers(run *broadcastRun) []*subscriber {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.run != run {
+		return nil
+	}
+	return append([]*subscriber(nil), b.subs...)
+}
+
+// finish ends the subscriptions of run once its poll loop has stopped, which
+// happens on its own only when the client is closed.
+func (b *Broadcaster) finish(run *broadcastRun) {
+	b.mu.Lock()
+	if b.run != run {
+		b.mu.Unlock()
+		return
+	}
+	subs := b.subs
+	b.subs = nil
+	b.run = nil
+	b.mu.Unlock()
+
+	run.cancel()
+	for _, s := range subs {
+		s.close()
+	}
+}
+
+// deliver sends updates to the subscriber according to the overflow policy. The
+// poll loop already counted the update in the client's metrics, so fanning it out
+// does not count it again.
+func (s *subscriber) deliver(c *Client, updates Updates) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		_, _ = offerUpdates(s.ctx, c.Config.UpdatesOverflowPolicy, s.ch, updates, nil)
+	}
+}
+
+// close ends the subscription. Canceling first releases a delivery blocked on a
+// full channel, so the lock can be taken.
+func (s *subscriber) close() {
+	s.stop()
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
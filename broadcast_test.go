@@ -0,0 +1,144 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBroadcaster(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		_, _ = w.Write([]byte(`{"items": [1], "profiles": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Hour))
+	defer client.Close()
+
+	b := client.NewBroadcaster()
+	ctx := context.Background()
+
+	first, err := b.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if got := <-first; got.Items[0] != 1 {
+		t.Fatalf("Expected item 1, got %v", got.Items)
+	}
+
+	// Later subscribers join the running poll loop instead of starting their own
+	second, err := b.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if got := b.Subscribers(); got != 2 {
+		t.Errorf("Expected 2 subscribers, got %d", got)
+	}
+	if got := polls.Load(); got != 1 {
+		t.Errorf("Expected a single poll loop, got %d polls", got)
+	}
+
+	b.Unsubscribe(second)
+	if _, ok := <-second; ok {
+		t.Error("Expected the unsubscribed channel to be closed")
+	}
+
+	// The last subscriber leaving stops polling; the next one starts it again
+	b.Unsubscribe(first)
+	if _, ok := <-first; ok {
+		t.Error("Expected the unsubscribed channel to be closed")
+	}
+
+	third, err := b.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if got := <-third; got.Items[0] != 1 {
+		t.Fatalf("Expected item 1, got %v", got.Items)
+	}
+	if got := polls.Load(); got != 2 {
+		t.Errorf("Expected a restarted poll loop, got %d polls", got)
+	}
+}
+
+func TestBroadcasterFanOut(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		_, _ = w.Write([]byte(`{"items": [1], "profiles": []}`))
+	}))
+	defer server.Close()
+
+	// Updates are delivered to both subscribers before the poller can continue
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(5*time.Millisecond), WithUpdatesBufferSize(0))
+	defer client.Close()
+
+	b := client.NewBroadcaster()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	channels := make([]<-chan Updates, 3)
+	for i := range channels {
+		ch, err := b.Subscribe(ctx)
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+		channels[i] = ch
+	}
+
+	for round := 0; round < 3; round++ {
+		for i, ch := range channels {
+			if got := <-ch; len(got.Items) != 1 {
+				t.Fatalf("Subscriber %d: expected 1 item, got %v", i, got.Items)
+			}
+		}
+	}
+
+	// Three subscribers received three rounds from at most four polls
+	if got := polls.Load(); got > 4 {
+		t.Errorf("Expected one poll per round, got %d polls", got)
+	}
+
+	// Fanning out to subscribers does not count an update again
+	if got := client.Stats().UpdatesEmitted; got > int64(polls.Load()) {
+		t.Errorf("Expected at most one emitted update per poll, got %d for %d polls", got, polls.Load())
+	}
+
+	// Canceling the context ends every subscription
+	cancel()
+	for i, ch := range channels {
+		for range ch {
+		}
+		if i == len(channels)-1 && b.Subscribers() != 0 {
+			t.Errorf("Expected no subscribers, got %d", b.Subscribers())
+		}
+	}
+}
+
+func TestBroadcasterClientClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": [1], "profiles": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Millisecond), WithUpdatesBufferSize(0))
+	b := client.NewBroadcaster()
+
+	// A subscriber that never reads must not keep Close from returning
+	ch, err := b.Subscribe(context.Background())
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	_ = client.Close()
+
+	for range ch {
+	}
+	if _, err := b.Subscribe(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package hnapi
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// readerBufferSize is the size of the pooled read buffers. It is large enough for
+// the decoder to pull an item in a few reads, without holding on to memory sized
+// for the largest response.
+const readerBufferSize = 4 << 10
+
+// readerPool holds the buffered readers response bodies are streamed through.
+// Batches and the firehose decode thousands of small responses, so reusing the
+// read buffers takes a share of the allocation out of decoding.
+var readerPool = sync.Pool{
+	New: func() any { return bufio.NewReaderSize(nil, readerBufferSize) },
+}
+
+// getReader returns a pooled buffered reader reading from r.
+func getReader(r io.Reader) *bufio.Reader {
+	br := readerPool.Get().(*bufio.Reader)
+	br.Reset(r)
+	return br
+}
+
+// putReader returns br to the pool. The caller must not use br afterwards.
+func putReader(br *bufio.Reader) {
+	br.Reset(nil)
+	readerPool.Put(br)
+}
@@ -0,0 +1,41 @@
+package hnapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPutReader(t *testing.T) {
+	r := getReader(strings.NewReader("response"))
+	if _, err := r.Peek(1); err != nil {
+		t.Fatalf("Peek() error = %v", err)
+	}
+	putReader(r)
+	if r.Buffered() != 0 {
+		t.Errorf("Expected a pooled reader to be reset, got %d buffered bytes", r.Buffered())
+	}
+}
+
+func TestDecodeResponseReusesReaders(t *testing.T) {
+	// Decoded strings must not alias the pooled buffer they were read through
+	var first Item
+	if err := decodeResponse(strings.NewReader(`{"id": 1, "title": "first"}`), &first, false); err != nil {
+		t.Fatalf("decodeResponse() error = %v", err)
+	}
+	var second Item
+	if err := decodeResponse(strings.NewReader(`{"id": 2, "title": "other"}`), &second, false); err != nil {
+		t.Fatalf("decodeResponse() error = %v", err)
+	}
+
+	if first.Title != "first" || second.Title != "other" {
+		t.Errorf("Expected titles %q and %q, got %q and %q", "first", "other", first.Title, second.Title)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		var ids []int
+		_ = decodeResponse(strings.NewReader(`[1, 2, 3, 4, 5, 6, 7, 8]`), &ids, false)
+	})
+	if allocs > 15 {
+		t.Errorf("Expected few allocations per decode, got %v", allocs)
+	}
+}
@@ -0,0 +1,206 @@
+package hnapi
+
+import (
+	"bytes"
+	"cmp"
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachePolicy configures response caching separately for each class of endpoint,
+// for example caching items for ten minutes but lists for thirty seconds.
+type CachePolicy struct {
+	// Items covers item/<id>.json, used by GetItem, batches, and comment trees.
+	Items EndpointCache
+
+	// Users covers user/<name>.json, used by GetUser and GetUsersBatch.
+	Users EndpointCache
+
+	// Lists covers the story lists. When enabled it takes precedence over
+	// ListCacheTTL and behaves the same, including background refreshes.
+	Lists EndpointCache
+
+	// Updates covers updates.json. Pollers faster than the TTL receive the same
+	// updates again; combine it with UpdatesDedupWindow to drop them.
+	Updates EndpointCache
+
+	// MaxEntries caps the number of cached item, user, and updates responses. Once
+	// full, the least recently used response is evicted. Zero means
+	// DefaultCacheMaxEntries.
+	MaxEntries int
+}
+
+// EndpointCache is the cache setting for one class of endpoint.
+type EndpointCache struct {
+	// Enabled turns caching on for the class.
+	Enabled bool
+
+	// TTL is how long a response is served from the cache. It must be positive
+	// when the class is enabled.
+	TTL time.Duration
+}
+
+// listCacheTTL returns the TTL of the list cache: the Lists policy if enabled,
+// ListCacheTTL otherwise.
+func (c *Config) listCacheTTL() time.Duration {
+	if c.CachePolicy.Lists.Enabled {
+		return c.CachePolicy.Lists.TTL
+	}
+	return c.ListCacheTTL
+}
+
+// DefaultCacheMaxEntries is the number of responses a CachePolicy keeps when its
+// MaxEntries is zero.
+const DefaultCacheMaxEntries = 10000
+
+// responseCache holds the raw bodies of recent item, user, and updates responses,
+// keyed by endpoint. Bodies are decoded again on every hit, so callers never share
+// the values they get back. Once full, the least recently used response is evicted.
+type responseCache struct {
+	policy     CachePolicy
+	maxEntries int
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List
+	inFlight map[string]*responseCall
+}
+
+// responseEntry is a cached response body.
+type responseEntry struct {
+	endpoint string
+	data     []byte
+	expires  time.Time
+}
+
+// responseCall is a request for an uncached endpoint that concurrent callers for
+// the same endpoint wait on instead of sending their own.
+type responseCall struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// newResponseCache creates a response cache for policy, or returns nil if policy
+// enables none of the classes it covers.
+func newResponseCache(policy CachePolicy) *responseCache {
+	if !policy.Items.Enabled && !policy.Users.Enabled && !policy.Updates.Enabled {
+		return nil
+	}
+	return &responseCache{
+		policy:     policy,
+		maxEntries: cmp.Or(policy.MaxEntries, DefaultCacheMaxEntries),
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		inFlight:   make(map[string]*responseCall),
+	}
+}
+
+// ttl returns how long responses from endpoint are cached, or false if they are
+// not. Story lists are left to the list cache.
+func (rc *responseCache) ttl(endpoint string) (time.Duration, bool) {
+	if rc == nil {
+		return 0, false
+	}
+
+	var class EndpointCache
+	switch {
+	case strings.HasPrefix(endpoint, "item/"):
+		class = rc.policy.Items
+	case strings.HasPrefix(endpoint, "user/"):
+		class = rc.policy.Users
+	case endpoint == "updates.json":
+		class = rc.policy.Updates
+	}
+	return class.TTL, class.Enabled
+}
+
+// lookup returns the cached body for endpoint, if it has not expired.
+func (rc *responseCache) lookup(endpoint string, now time.Time) ([]byte, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.lookupLocked(endpoint, now)
+}
+
+// lookupLocked implements lookup; rc.mu must be held.
+func (rc *responseCache) lookupLocked(endpoint string, now time.Time) ([]byte, bool) {
+	elem, ok := rc.entries[endpoint]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*responseEntry)
+	if !now.Before(entry.expires) {
+		rc.lru.Remove(elem)
+		delete(rc.entries, endpoint)
+		return nil, false
+	}
+
+	rc.lru.MoveToFront(elem)
+	return entry.data, true
+}
+
+// acquire returns the cached body for endpoint if it has not expired. Otherwise it
+// returns the request in flight for endpoint and whether the caller started it, in
+// which case the caller must complete it with finish.
+func (rc *responseCache) acquire(endpoint string, now time.Time) (data []byte, call *responseCall, leader bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if data, ok := rc.lookupLocked(endpoint, now); ok {
+		return data, nil, false
+	}
+
+	if call, ok := rc.inFlight[endpoint]; ok {
+		return nil, call, false
+	}
+
+	call = &responseCall{done: make(chan struct{})}
+	rc.inFlight[endpoint] = call
+	return nil, call, true
+}
+
+// finish completes the request call for endpoint, caching data until now plus ttl
+// if err is nil, and releases the callers waiting on it.
+func (rc *responseCache) finish(endpoint string, call *responseCall, data []byte, err error, ttl time.Duration, now time.Time) {
+	rc.mu.Lock()
+	delete(rc.inFlight, endpoint)
+	if err == nil {
+		call.data = rc.storeLocked(endpoint, data, ttl, now)
+	}
+	call.err = err
+	rc.mu.Unlock()
+
+	close(call.done)
+}
+
+// store caches a copy of data as the body for endpoint until now plus ttl.
+func (rc *responseCache) store(endpoint string, data []byte, ttl time.Duration, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.storeLocked(endpoint, data, ttl, now)
+}
+
+// storeLocked implements store and returns the cached copy; rc.mu must be held.
+// The least recently used entries are evicted to stay within maxEntries.
+func (rc *responseCache) storeLocked(endpoint string, data []byte, ttl time.Duration, now time.Time) []byte {
+	entry := &responseEntry{endpoint: endpoint, data: bytes.Clone(data), expires: now.Add(ttl)}
+
+	if elem, ok := rc.entries[endpoint]; ok {
+		elem.Value = entry
+		rc.lru.MoveToFront(elem)
+		return entry.data
+	}
+
+	rc.entries[endpoint] = rc.lru.PushFront(entry)
+	for len(rc.entries) > rc.maxEntries {
+		oldest := rc.lru.Back()
+		rc.lru.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*responseEntry).endpoint)
+	}
+	return entry.data
+}
@@ -0,0 +1,210 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachePolicy(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/item/"):
+			_, _ = w.Write([]byte(`{"id": 8863, "type": "story", "title": "My YC app"}`))
+		case strings.HasPrefix(r.URL.Path, "/user/"):
+			_, _ = w.Write([]byte(`{"id": "pg", "karma": 155111}`))
+		default:
+			_, _ = w.Write([]byte(`[8863]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithCachePolicy(CachePolicy{
+		Items: EndpointCache{Enabled: true, TTL: time.Minute},
+		Lists: EndpointCache{Enabled: true, TTL: time.Minute},
+	}))
+	defer client.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		item, err := client.GetItem(ctx, 8863)
+		if err != nil {
+			t.Fatalf("GetItem() error = %v", err)
+		}
+		if item.Title != "My YC app" {
+			t.Errorf("Expected title %q, got %q", "My YC app", item.Title)
+		}
+		// Hits are decoded afresh, so this does not reach the cache
+		item.Title = "changed"
+
+		if _, err := client.GetUser(ctx, "pg"); err != nil {
+			t.Fatalf("GetUser() error = %v", err)
+		}
+		if _, err := client.GetTopStories(ctx); err != nil {
+			t.Fatalf("GetTopStories() error = %v", err)
+		}
+	}
+
+	// WithNoCache bypasses the cache
+	if _, err := client.GetItem(ctx, 8863, WithNoCache()); err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[string]int{"/item/8863.json": 2, "/user/pg.json": 2, "/topstories.json": 1}
+	for path, n := range want {
+		if requests[path] != n {
+			t.Errorf("Expected %d requests to %s, got %d", n, path, requests[path])
+		}
+	}
+
+	if !client.Capabilities().Cache {
+		t.Error("Expected Capabilities().Cache to be true")
+	}
+}
+
+func TestResponseCacheExpiry(t *testing.T) {
+	rc := newResponseCache(CachePolicy{Items: EndpointCache{Enabled: true, TTL: time.Minute}})
+	now := time.Unix(1175714200, 0)
+
+	rc.store("item/8863.json", []byte(`{"id": 8863}`), time.Minute, now)
+
+	if _, ok := rc.lookup("item/8863.json", now.Add(59*time.Second)); !ok {
+		t.Error("Expected a hit within the TTL")
+	}
+	if _, ok := rc.lookup("item/8863.json", now.Add(time.Minute)); ok {
+		t.Error("Expected a miss once the TTL has passed")
+	}
+}
+
+func TestResponseCacheEviction(t *testing.T) {
+	rc := newResponseCache(CachePolicy{Items: EndpointCache{Enabled: true, TTL: time.Minute}, MaxEntries: 2})
+	now := time.Unix(1175714200, 0)
+
+	rc.store("item/1.json", nil, time.Minute, now)
+	rc.store("item/2.json", nil, time.Minute, now)
+
+	// Using item 1 makes item 2 the least recently used
+	rc.lookup("item/1.json", now)
+	rc.store("item/3.json", nil, time.Minute, now)
+
+	if len(rc.entries) != 2 {
+		t.Errorf("Expected 2 entries, got %d", len(rc.entries))
+	}
+	if _, ok := rc.lookup("item/2.json", now); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	for _, endpoint := range []string{"item/1.json", "item/3.json"} {
+		if _, ok := rc.lookup(endpoint, now); !ok {
+			t.Errorf("Expected %s to be cached", endpoint)
+		}
+	}
+}
+
+func TestResponseCacheCoalescing(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"id": 8863, "type": "story", "title": "My YC app"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithCachePolicy(CachePolicy{
+		Items: EndpointCache{Enabled: true, TTL: time.Minute},
+	}))
+	defer client.Close()
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, err := client.GetItem(context.Background(), 8863)
+			if err == nil && item.Title != "My YC app" {
+				err = fmt.Errorf("unexpected title %q", item.Title)
+			}
+			errs <- err
+		}()
+	}
+
+	// Let the callers queue up behind the first request before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetItem() error = %v", err)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected concurrent misses to share one request, got %d", n)
+	}
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	rc := newResponseCache(CachePolicy{
+		Items:   EndpointCache{Enabled: true, TTL: time.Minute},
+		Users:   EndpointCache{TTL: time.Hour},
+		Updates: EndpointCache{Enabled: true, TTL: time.Second},
+	})
+
+	tests := []struct {
+		endpoint string
+		want     time.Duration
+		wantOK   bool
+	}{
+		{endpoint: "item/8863.json", want: time.Minute, wantOK: true},
+		{endpoint: "user/pg.json", want: time.Hour, wantOK: false},
+		{endpoint: "updates.json", want: time.Second, wantOK: true},
+		{endpoint: "topstories.json", wantOK: false},
+		{endpoint: "maxitem.json", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, ok := rc.ttl(tt.endpoint)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("Expected %v, %v, got %v, %v", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+
+	if newResponseCache(CachePolicy{Lists: EndpointCache{Enabled: true, TTL: time.Minute}}) != nil {
+		t.Error("Expected no response cache for a lists-only policy")
+	}
+}
+
+func TestCachePolicyValidation(t *testing.T) {
+	config := DefaultConfig()
+	config.CachePolicy.Users = EndpointCache{Enabled: true}
+
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "CachePolicy.Users") {
+		t.Errorf("Expected an error about CachePolicy.Users, got %v", err)
+	}
+
+	config = DefaultConfig()
+	config.CachePolicy.MaxEntries = -1
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "CachePolicy.MaxEntries") {
+		t.Errorf("Expected an error about CachePolicy.MaxEntries, got %v", err)
+	}
+}
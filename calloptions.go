@@ -0,0 +1,172 @@
+package hnapi
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+)
+
+// CallOption customizes a single call without changing the client's configuration.
+type CallOption func(*callOptions)
+
+// callOptions holds the settings of a single call.
+type callOptions struct {
+	// timeout bounds the whole call, including retries; zero means no extra bound
+	timeout time.Duration
+
+	// noCache bypasses the response cache
+	noCache bool
+
+	// noRetry makes failed requests return immediately
+	noRetry bool
+
+	// skipTombstones drops deleted and dead items from batch results
+	skipTombstones bool
+
+	// progress receives the number of completed and total fetches
+	progress func(done, total int)
+
+	// priority orders the call's requests for transport slots
+	priority Priority
+
+	// query holds Firebase query parameters added to the request URL
+	query *Query
+
+	// conditional, if set, revalidates a cached response with its ETag
+	conditional *conditional
+
+	// capture, if set, receives the body of a successful response for caching
+	capture *bytes.Buffer
+
+	// attempts, if set, receives the number of requests the call made
+	attempts *int
+
+	// batchMode decides whether a batch stops on the first failure
+	batchMode BatchMode
+
+	// commentOrder orders the replies of comment trees
+	commentOrder CommentOrder
+
+	// abort, if set, stops a fail-fast batch with the failure passed to it
+	abort func(error)
+}
+
+// newCallOptions applies opts to the default call settings.
+func newCallOptions(opts []CallOption) callOptions {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// context returns ctx bounded by the call timeout, if any.
+func (o callOptions) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// itemOptions returns opts for the per-item fetches of a batch or comment tree. The
+// call timeout already bounds the whole call, so it is dropped rather than applied
+// again to every item.
+func itemOptions(opts []CallOption) []CallOption {
+	return append(slices.Clip(opts), func(o *callOptions) { o.timeout = 0 })
+}
+
+// WithCallTimeout bounds the whole call, including retries, by timeout. For batch
+// calls the timeout covers the entire batch.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithNoCache makes the call bypass any configured response cache and go to the API.
+func WithNoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+// WithNoRetry makes the call fail on the first error instead of retrying up to
+// MaxRetries times.
+func WithNoRetry() CallOption {
+	return func(o *callOptions) {
+		o.noRetry = true
+	}
+}
+
+// SkipDeadAndDeleted makes batch calls silently drop deleted and dead items instead
+// of returning them, or, with WithTombstoneErrors, reporting them as failures.
+func SkipDeadAndDeleted() CallOption {
+	return func(o *callOptions) {
+		o.skipTombstones = true
+	}
+}
+
+// WithProgress makes long-running calls report their progress to fn as items are
+// fetched, to drive progress bars and logging. GetItemsBatch reports one step per
+// item. GetCommentTree estimates the total from the story's comment count, or grows
+// it as replies are discovered, and reports a final call with the exact total.
+// WalkItems and Crawl report the number of IDs processed out of the range. Calls to
+// fn are serialized; fn must not block.
+func WithProgress(fn func(done, total int)) CallOption {
+	return func(o *callOptions) {
+		o.progress = fn
+	}
+}
+
+// WithPriority sets the priority of the call's requests when the client limits
+// requests in flight with MaxInFlight. Batches, walks, and iterators default to
+// PriorityBulk and single calls to PriorityNormal; pass PriorityInteractive for
+// requests a user is waiting on.
+func WithPriority(priority Priority) CallOption {
+	return func(o *callOptions) {
+		o.priority = priority
+	}
+}
+
+// WithQuery adds Firebase REST query parameters to the call's requests, so the
+// server filters the response instead of sending all of it. It is meant for story
+// lists: GetList(ctx, ListTop, WithQuery(Query{LimitToFirst: 10})) downloads only the
+// top ten IDs. Calls with a query bypass the list cache.
+func WithQuery(query Query) CallOption {
+	return func(o *callOptions) {
+		o.query = &query
+	}
+}
+
+// WithBatchMode decides what GetItemsBatch and GetItemsBatchResults do when an item
+// fails: BatchBestEffort, the default, attempts every ID, while BatchFailFast stops
+// on the first failure. Items already in flight when a batch stops are abandoned.
+func WithBatchMode(mode BatchMode) CallOption {
+	return func(o *callOptions) {
+		o.batchMode = mode
+	}
+}
+
+// WithCommentOrder sorts the replies at every level of the tree returned by
+// GetCommentTree: in display order, the default, newest or oldest first, or with
+// the largest subtrees first. FlattenTree keeps the order of the tree it is given.
+func WithCommentOrder(order CommentOrder) CallOption {
+	return func(o *callOptions) {
+		o.commentOrder = order
+	}
+}
+
+// abortOnFailure makes a batch call pass its first failure to abort.
+func abortOnFailure(abort func(error)) CallOption {
+	return func(o *callOptions) {
+		o.abort = abort
+	}
+}
+
+// countAttempts makes the call store the number of requests it made in n.
+func countAttempts(n *int) CallOption {
+	return func(o *callOptions) {
+		o.attempts = n
+	}
+}
@@ -0,0 +1,132 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		status       int
+		callOpts     []CallOption
+		wantErr      bool
+		wantRequests int32
+	}{
+		{name: "recovers from server errors", failures: 2, status: http.StatusServiceUnavailable, wantRequests: 3},
+		{name: "recovers from rate limiting", failures: 1, status: http.StatusTooManyRequests, wantRequests: 2},
+		{name: "gives up after max retries", failures: 10, status: http.StatusInternalServerError, wantErr: true, wantRequests: 4},
+		{name: "client errors are not retried", failures: 10, status: http.StatusNotFound, wantErr: true, wantRequests: 1},
+		{name: "no retry call option", failures: 1, status: http.StatusServiceUnavailable, callOpts: []CallOption{WithNoRetry()}, wantErr: true, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+			}))
+			defer server.Close()
+
+			var attempts []int
+			client := NewClient(
+				WithBaseURL(server.URL+"/"),
+				WithMaxRetries(3),
+				WithBackoffInterval(time.Millisecond),
+				WithHooks(Hooks{OnRequestEnd: func(_ context.Context, info RequestEndInfo) {
+					attempts = append(attempts, info.Attempt)
+				}}),
+			)
+
+			_, err := client.GetItem(context.Background(), 1, tt.callOpts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, got)
+			}
+			if got := client.Stats().Retries; got != int64(tt.wantRequests-1) {
+				t.Errorf("Expected %d retries, got %d", tt.wantRequests-1, got)
+			}
+			for i, attempt := range attempts {
+				if attempt != i+1 {
+					t.Errorf("Expected attempt %d to be reported as %d", i+1, attempt)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutPerAttempt(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// The first attempt hangs past the request timeout
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithRequestTimeout(50*time.Millisecond),
+		WithBackoffInterval(time.Millisecond),
+	)
+
+	item, err := client.GetItem(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.ID != 1 || requests.Load() != 2 {
+		t.Errorf("Expected item 1 after 2 requests, got %v after %d", item, requests.Load())
+	}
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	start := time.Now()
+	_, err := client.GetItem(context.Background(), 1, WithCallTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the call to give up after the call timeout, took %v", elapsed)
+	}
+
+	_, err = client.GetItemsBatch(context.Background(), []int{1, 2}, WithCallTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected batch deadline exceeded, got %v", err)
+	}
+
+	// The items of a batch share the batch's timeout instead of each starting their own
+	if o := newCallOptions(itemOptions([]CallOption{WithCallTimeout(time.Second)})); o.timeout != 0 {
+		t.Errorf("Expected no per-item call timeout, got %v", o.timeout)
+	}
+}
@@ -0,0 +1,148 @@
+package hnapi
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams are query parameters that identify a referral rather than a
+// resource and are removed by CanonicalURL. Parameters starting with "utm_" are
+// removed as well.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref":     true,
+	"ref_src": true,
+	"ref_url": true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+}
+
+// CanonicalURL normalizes a URL so that different spellings of the same address
+// compare equal: the scheme becomes https, the host is lowercased without "www."
+// or a default port, tracking parameters such as utm_source and fbclid are removed,
+// the remaining query parameters are sorted, and the fragment and trailing slashes
+// are dropped. It returns an empty string for URLs without a host.
+func CanonicalURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(normalizeHost(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+
+	canonical := url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     strings.TrimRight(u.EscapedPath(), "/"),
+		RawQuery: encodeSortedQuery(query),
+	}
+	return canonical.String()
+}
+
+// encodeSortedQuery encodes query with keys sorted and each key's values sorted.
+func encodeSortedQuery(query url.Values) string {
+	for _, values := range query {
+		sort.Strings(values)
+	}
+	// url.Values.Encode sorts by key
+	return query.Encode()
+}
+
+// CanonicalURL returns the canonical form of the item's URL, as computed by the
+// package-level CanonicalURL, or an empty string if the item has no URL.
+func (i *Item) CanonicalURL() string {
+	return CanonicalURL(i.URL)
+}
+
+// DuplicateGroup is a set of distinct items that link to the same canonical URL.
+type DuplicateGroup struct {
+	// URL is the canonical URL the items share.
+	URL string
+
+	// Items are the submissions of the URL, in the order they were first seen.
+	Items []*Item
+}
+
+// FindDuplicates groups items that link to the same canonical URL. Only groups with
+// more than one distinct item are returned, in the order their first item appears.
+// The same item appearing more than once, as happens when merging the top, new, and
+// best lists, is not a duplicate. Items without a URL are ignored.
+func FindDuplicates(items []*Item) []DuplicateGroup {
+	var groups []DuplicateGroup
+	index := make(map[string]int)
+	seen := make(map[int]bool)
+
+	for _, item := range items {
+		if item == nil || seen[item.ID] {
+			continue
+		}
+		canonical := item.CanonicalURL()
+		if canonical == "" {
+			continue
+		}
+		seen[item.ID] = true
+
+		i, ok := index[canonical]
+		if !ok {
+			i = len(groups)
+			index[canonical] = i
+			groups = append(groups, DuplicateGroup{URL: canonical})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+
+	duplicates := groups[:0]
+	for _, group := range groups {
+		if len(group.Items) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	return duplicates
+}
+
+// DedupByURL returns items with repeated items and later submissions of an already
+// seen canonical URL removed, keeping the first of each. Items without a URL are
+// only deduplicated by ID. The order of the kept items is preserved.
+func DedupByURL(items []*Item) []*Item {
+	kept := make([]*Item, 0, len(items))
+	seenIDs := make(map[int]bool)
+	seenURLs := make(map[string]bool)
+
+	for _, item := range items {
+		if item == nil || seenIDs[item.ID] {
+			continue
+		}
+		seenIDs[item.ID] = true
+
+		if canonical := item.CanonicalURL(); canonical != "" {
+			if seenURLs[canonical] {
+				continue
+			}
+			seenURLs[canonical] = true
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
@@ -0,0 +1,68 @@
+package hnapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "Plain", url: "https://example.com/post", want: "https://example.com/post"},
+		{name: "SchemeAndHostCase", url: "HTTP://WWW.Example.COM/Post", want: "https://example.com/Post"},
+		{name: "TrailingSlash", url: "https://example.com/post/", want: "https://example.com/post"},
+		{name: "Root", url: "https://example.com/", want: "https://example.com"},
+		{name: "DefaultPort", url: "http://example.com:80/a", want: "https://example.com/a"},
+		{name: "CustomPort", url: "https://example.com:8080/a", want: "https://example.com:8080/a"},
+		{name: "TrackingParams", url: "https://example.com/a?utm_source=hn&id=2&fbclid=x&UTM_Medium=y", want: "https://example.com/a?id=2"},
+		{name: "SortedQuery", url: "https://example.com/a?b=2&a=1", want: "https://example.com/a?a=1&b=2"},
+		{name: "Fragment", url: "https://example.com/a#section", want: "https://example.com/a"},
+		{name: "Empty", url: "", want: ""},
+		{name: "NoHost", url: "/relative/path", want: ""},
+		{name: "OtherScheme", url: "ftp://example.com/file", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalURL(tt.url); got != tt.want {
+				t.Errorf("CanonicalURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	a1 := &Item{ID: 1, URL: "https://example.com/a"}
+	a2 := &Item{ID: 2, URL: "http://www.example.com/a/?utm_source=x"}
+	b := &Item{ID: 3, URL: "https://example.com/b"}
+	ask := &Item{ID: 4, Text: "Ask HN"}
+	a3 := &Item{ID: 5, URL: "https://EXAMPLE.com/a#comments"}
+
+	// Item 1 appears twice, as when merging lists
+	groups := FindDuplicates([]*Item{a1, b, ask, a1, a2, a3})
+
+	want := []DuplicateGroup{{URL: "https://example.com/a", Items: []*Item{a1, a2, a3}}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("FindDuplicates() = %+v, want %+v", groups, want)
+	}
+
+	if groups := FindDuplicates([]*Item{a1, a1, b}); len(groups) != 0 {
+		t.Errorf("Expected no duplicates, got %+v", groups)
+	}
+}
+
+func TestDedupByURL(t *testing.T) {
+	a1 := &Item{ID: 1, URL: "https://example.com/a"}
+	a2 := &Item{ID: 2, URL: "http://example.com/a/"}
+	ask := &Item{ID: 3, Text: "Ask HN"}
+	b := &Item{ID: 4, URL: "https://example.com/b"}
+
+	got := DedupByURL([]*Item{a1, ask, a2, ask, b, a1})
+	want := []*Item{a1, ask, b}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DedupByURL() = %v, want %v", got, want)
+	}
+}
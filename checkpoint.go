@@ -0,0 +1,132 @@
+package hnapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore persists progress markers, such as the last item ID a crawl has
+// processed, so work can resume after a restart. Implementations must be safe for
+// concurrent use.
+type CheckpointStore interface {
+	// LoadCheckpoint returns the ID saved under key, or 0 if there is none.
+	LoadCheckpoint(ctx context.Context, key string) (int, error)
+
+	// SaveCheckpoint saves id under key, replacing any previous value.
+	SaveCheckpoint(ctx context.Context, key string, id int) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in memory.
+// It is useful for tests and for resuming within a single process.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]int
+}
+
+// NewMemoryCheckpointStore creates an empty in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: make(map[string]int)}
+}
+
+// LoadCheckpoint returns the ID saved under key, or 0 if there is none.
+func (s *MemoryCheckpointStore) LoadCheckpoint(_ context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checkpoints[key], nil
+}
+
+// SaveCheckpoint saves id under key.
+func (s *MemoryCheckpointStore) SaveCheckpoint(_ context.Context, key string, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[key] = id
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore that keeps checkpoints in a JSON file.
+// Saves replace the file atomically, so a crash never leaves it half written.
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpointStore creates a checkpoint store backed by the file at path.
+// The file is created on the first save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// LoadCheckpoint returns the ID saved under key, or 0 if there is none.
+func (s *FileCheckpointStore) LoadCheckpoint(_ context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+	return checkpoints[key], nil
+}
+
+// SaveCheckpoint saves id under key.
+func (s *FileCheckpointStore) SaveCheckpoint(_ context.Context, key string, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[key] = id
+
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoints: %w", err)
+	}
+
+	// Write to a temporary file and rename it over the old one
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// read loads all checkpoints from the file. The caller must hold s.mu.
+func (s *FileCheckpointStore) read() (map[string]int, error) {
+	checkpoints := make(map[string]int)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
@@ -0,0 +1,53 @@
+package hnapi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointStores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	stores := map[string]CheckpointStore{
+		"memory": NewMemoryCheckpointStore(),
+		"file":   NewFileCheckpointStore(path),
+	}
+
+	ctx := context.Background()
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			if id, err := store.LoadCheckpoint(ctx, "a"); err != nil || id != 0 {
+				t.Fatalf("LoadCheckpoint() = %d, %v, want 0 before saving", id, err)
+			}
+
+			for _, save := range []struct {
+				key string
+				id  int
+			}{{"a", 10}, {"b", 20}, {"a", 30}} {
+				if err := store.SaveCheckpoint(ctx, save.key, save.id); err != nil {
+					t.Fatalf("SaveCheckpoint() error = %v", err)
+				}
+			}
+
+			for key, want := range map[string]int{"a": 30, "b": 20} {
+				if id, err := store.LoadCheckpoint(ctx, key); err != nil || id != want {
+					t.Errorf("LoadCheckpoint(%q) = %d, %v, want %d", key, id, err, want)
+				}
+			}
+		})
+	}
+
+	// A new store on the same file sees the saved checkpoints
+	if id, err := NewFileCheckpointStore(path).LoadCheckpoint(ctx, "a"); err != nil || id != 30 {
+		t.Errorf("Expected checkpoints to persist in the file, got %d, %v", id, err)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileCheckpointStore(path).LoadCheckpoint(ctx, "a"); err == nil {
+		t.Errorf("Expected an error for a corrupt checkpoint file")
+	}
+}
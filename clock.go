@@ -0,0 +1,67 @@
+package hnapi
+
+import "time"
+
+// Clock is the source of time for the client's pollers, retries, and caches.
+// Tests can replace it with a synthetic clock, such as hnapitest.Clock, to advance
+// time without sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTicker returns a ticker that ticks every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals, like time.Ticker.
+type Ticker interface {
+	// C returns the channel the ticks are delivered on.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+// Now returns time.Now().
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After returns time.After(d).
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTicker returns a ticker backed by time.NewTicker.
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// realTicker adapts time.Ticker to the Ticker interface.
+type realTicker struct {
+	t *time.Ticker
+}
+
+// C returns the ticker's channel.
+func (t realTicker) C() <-chan time.Time {
+	return t.t.C
+}
+
+// Stop stops the ticker.
+func (t realTicker) Stop() {
+	t.t.Stop()
+}
+
+// clock returns the configured Clock, or the real clock if none is configured.
+func (c *Client) clock() Clock {
+	if c.Config.Clock != nil {
+		return c.Config.Clock
+	}
+	return realClock{}
+}
@@ -0,0 +1,32 @@
+package hnapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRealClock(t *testing.T) {
+	client := NewClient()
+	if _, ok := client.clock().(realClock); !ok {
+		t.Fatalf("Expected the system clock by default, got %T", client.clock())
+	}
+
+	clock := client.clock()
+	if d := time.Since(clock.Now()); d < 0 || d > time.Second {
+		t.Errorf("Expected Now() to be the current time, off by %v", d)
+	}
+
+	ticker := clock.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C():
+	case <-time.After(time.Second):
+		t.Fatal("Expected the ticker to tick")
+	}
+
+	select {
+	case <-clock.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Fatal("Expected After to fire")
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// pageSize is the number of stories shown per list page.
+const pageSize = 10
+
+// lists maps the names accepted by the list command to story lists.
+var lists = map[string]hnapi.List{
+	"top":  hnapi.ListTop,
+	"new":  hnapi.ListNew,
+	"best": hnapi.ListBest,
+	"ask":  hnapi.ListAsk,
+	"show": hnapi.ListShow,
+	"job":  hnapi.ListJob,
+}
+
+// comment is a node of the open story's comment thread. Its level of the comment
+// tree is loaded the first time the comment is expanded.
+type comment struct {
+	item     *hnapi.Item
+	depth    int
+	replies  []*comment
+	loaded   bool
+	expanded bool
+}
+
+// app is the reader state: the current list page, or the open story and the
+// visible part of its thread.
+type app struct {
+	client *hnapi.Client
+	out    io.Writer
+	now    func() time.Time
+
+	list  hnapi.List
+	ids   []int
+	page  int
+	items map[int]*hnapi.Item
+
+	story    *hnapi.Item
+	comments []*comment
+	visible  []*comment
+}
+
+// newApp creates a reader showing the given list.
+func newApp(client *hnapi.Client, out io.Writer, list hnapi.List) *app {
+	return &app{
+		client: client,
+		out:    out,
+		now:    time.Now,
+		list:   list,
+		items:  make(map[int]*hnapi.Item),
+	}
+}
+
+// run loads the list and executes commands read from in until quit or end of input.
+func (a *app) run(ctx context.Context, in io.Reader) error {
+	if err := a.loadList(ctx); err != nil {
+		return err
+	}
+	if err := a.render(ctx); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		a.prompt()
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		quit, err := a.execute(ctx, scanner.Text())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Fprintf(a.out, "error: %v\n", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+}
+
+// prompt prints the commands available in the current view.
+func (a *app) prompt() {
+	if a.story == nil {
+		fmt.Fprint(a.out, "\n[#] open  [n]ext  [p]rev  [l]ist top|new|best|ask|show|job  [r]efresh  [q]uit > ")
+		return
+	}
+	fmt.Fprint(a.out, "\n[#] expand/collapse comment  [b]ack  [q]uit > ")
+}
+
+// execute runs one command line and reports whether the reader should exit.
+func (a *app) execute(ctx context.Context, line string) (bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	switch cmd := fields[0]; {
+	case cmd == "q" || cmd == "quit":
+		return true, nil
+
+	case cmd == "b" || cmd == "back":
+		a.story, a.comments, a.visible = nil, nil, nil
+		return false, a.render(ctx)
+
+	case a.story == nil && (cmd == "n" || cmd == "next"):
+		if (a.page+1)*pageSize < len(a.ids) {
+			a.page++
+		}
+		return false, a.render(ctx)
+
+	case a.story == nil && (cmd == "p" || cmd == "prev"):
+		if a.page > 0 {
+			a.page--
+		}
+		return false, a.render(ctx)
+
+	case a.story == nil && (cmd == "l" || cmd == "list"):
+		if len(fields) < 2 {
+			return false, fmt.Errorf("usage: list top|new|best|ask|show|job")
+		}
+		list, ok := lists[fields[1]]
+		if !ok {
+			return false, fmt.Errorf("unknown list %q", fields[1])
+		}
+		a.list = list
+		if err := a.loadList(ctx); err != nil {
+			return false, err
+		}
+		return false, a.render(ctx)
+
+	case a.story == nil && (cmd == "r" || cmd == "refresh"):
+		a.items = make(map[int]*hnapi.Item)
+		if err := a.loadList(ctx); err != nil {
+			return false, err
+		}
+		return false, a.render(ctx)
+	}
+
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return false, fmt.Errorf("unknown command %q", fields[0])
+	}
+	if a.story == nil {
+		return false, a.openStory(ctx, n)
+	}
+	return false, a.toggleComment(ctx, n)
+}
+
+// loadList fetches the story IDs of the current list and returns to its first page.
+func (a *app) loadList(ctx context.Context) error {
+	ids, err := a.client.GetList(ctx, a.list)
+	if err != nil {
+		return err
+	}
+	a.ids = ids
+	a.page = 0
+	return nil
+}
+
+// pageIDs returns the story IDs on the current page.
+func (a *app) pageIDs() []int {
+	start := a.page * pageSize
+	if start > len(a.ids) {
+		start = len(a.ids)
+	}
+	end := start + pageSize
+	if end > len(a.ids) {
+		end = len(a.ids)
+	}
+	return a.ids[start:end]
+}
+
+// fetch returns the items with the given IDs, fetching only those not seen before.
+// Items that fail to load are left out.
+func (a *app) fetch(ctx context.Context, ids []int) ([]*hnapi.Item, error) {
+	var missing []int
+	for _, id := range ids {
+		if _, ok := a.items[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	items, err := a.client.GetItemsBatch(ctx, missing, hnapi.SkipDeadAndDeleted())
+	for _, item := range items {
+		a.items[item.ID] = item
+	}
+
+	found := make([]*hnapi.Item, 0, len(ids))
+	for _, id := range ids {
+		if item, ok := a.items[id]; ok {
+			found = append(found, item)
+		}
+	}
+	return found, err
+}
+
+// render prints the current view.
+func (a *app) render(ctx context.Context) error {
+	if a.story != nil {
+		a.renderStory()
+		return nil
+	}
+
+	items, err := a.fetch(ctx, a.pageIDs())
+	pages := (len(a.ids) + pageSize - 1) / pageSize
+	fmt.Fprintf(a.out, "\n== %s stories (page %d/%d) ==\n", a.list, a.page+1, pages)
+	for i, item := range items {
+		fmt.Fprintf(a.out, "%3d. %s", a.page*pageSize+i+1, item.Title)
+		if domain := item.Domain(); domain != "" {
+			fmt.Fprintf(a.out, " (%s)", domain)
+		}
+		fmt.Fprintf(a.out, "\n     %d points by %s %s ago | %d comments\n",
+			item.Score, item.By, formatAge(item.Age(a.now())), item.Descendants)
+	}
+	return err
+}
+
+// openStory loads the story at position n of the list with the first level of its
+// comment tree and shows it.
+func (a *app) openStory(ctx context.Context, n int) error {
+	index := n - 1
+	if index < 0 || index >= len(a.ids) {
+		return fmt.Errorf("no story %d", n)
+	}
+
+	tree, err := a.client.GetCommentTree(ctx, a.ids[index], 1)
+	if tree == nil {
+		return err
+	}
+	if !tree.Item.IsLive() {
+		return fmt.Errorf("story %d is no longer available", n)
+	}
+
+	a.story = tree.Item
+	a.items[tree.Item.ID] = tree.Item
+	a.comments = threadNodes(tree, 0)
+	a.renderStory()
+	return err
+}
+
+// loadReplies fetches c and its direct replies as one level of comment tree, so
+// the comment is shown as fresh as the replies it gained.
+func (a *app) loadReplies(ctx context.Context, c *comment) error {
+	tree, err := a.client.GetCommentTree(ctx, c.item.ID, 1)
+	if tree == nil {
+		return err
+	}
+
+	c.item = tree.Item
+	c.replies = threadNodes(tree, c.depth+1)
+	return err
+}
+
+// threadNodes converts the loaded replies of tree into thread nodes at depth,
+// leaving out deleted and dead comments.
+func threadNodes(tree *hnapi.CommentNode, depth int) []*comment {
+	replies := make([]*comment, 0, len(tree.Children))
+	for _, child := range tree.Children {
+		if child.Item.IsLive() {
+			replies = append(replies, &comment{item: child.Item, depth: depth})
+		}
+	}
+	return replies
+}
+
+// toggleComment expands or collapses visible comment n, loading its replies the
+// first time it is expanded.
+func (a *app) toggleComment(ctx context.Context, n int) error {
+	index := n - 1
+	if index < 0 || index >= len(a.visible) {
+		return fmt.Errorf("no comment %d", n)
+	}
+
+	c := a.visible[index]
+	var err error
+	if !c.loaded {
+		err = a.loadReplies(ctx, c)
+		c.loaded = true
+	}
+	c.expanded = !c.expanded
+
+	a.renderStory()
+	return err
+}
+
+// renderStory prints the open story and the visible part of its thread, numbering
+// comments so they can be expanded.
+func (a *app) renderStory() {
+	s := a.story
+	fmt.Fprintf(a.out, "\n== %s ==\n", s.Title)
+	if s.URL != "" {
+		fmt.Fprintln(a.out, s.URL)
+	}
+	fmt.Fprintf(a.out, "%d points by %s %s ago | %s\n", s.Score, s.By, formatAge(s.Age(a.now())), s.HNLink())
+	if text := s.PlainText(); text != "" {
+		fmt.Fprintf(a.out, "\n%s\n", text)
+	}
+
+	fmt.Fprintf(a.out, "\n-- %d comments --\n", s.Descendants)
+	a.visible = a.visible[:0]
+	a.renderComments(a.comments)
+}
+
+// renderComments prints comments and the replies of expanded comments.
+func (a *app) renderComments(comments []*comment) {
+	for _, c := range comments {
+		a.visible = append(a.visible, c)
+		indent := strings.Repeat("    ", c.depth)
+
+		marker := "+"
+		switch {
+		case len(c.item.Kids) == 0:
+			marker = " "
+		case c.expanded:
+			marker = "-"
+		}
+
+		fmt.Fprintf(a.out, "%s%s[%d] %s %s ago", indent, marker, len(a.visible), c.item.By, formatAge(c.item.Age(a.now())))
+		if len(c.item.Kids) > 0 {
+			fmt.Fprintf(a.out, " (%d replies)", len(c.item.Kids))
+		}
+		fmt.Fprintln(a.out)
+		for _, line := range strings.Split(c.item.PlainText(), "\n") {
+			fmt.Fprintf(a.out, "%s   %s\n", indent, line)
+		}
+
+		if c.expanded {
+			a.renderComments(c.replies)
+		}
+	}
+}
+
+// formatAge formats a duration in the largest whole unit, as Hacker News does.
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+}
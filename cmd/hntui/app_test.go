@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+func TestApp(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	posted := now.Add(-2 * time.Hour).Unix()
+
+	srv.AddItems(
+		&hnapi.Item{ID: 1, Type: hnapi.TypeStory, By: "alice", Title: "First story", URL: "https://www.example.com/a", Score: 42, Time: posted, Kids: []int{3}, Descendants: 2},
+		&hnapi.Item{ID: 2, Type: hnapi.TypeStory, By: "bob", Title: "Second story", Score: 7, Time: posted},
+		&hnapi.Item{ID: 3, Type: hnapi.TypeComment, By: "carol", Text: "Top <i>comment</i>", Parent: 1, Time: posted, Kids: []int{4}},
+		&hnapi.Item{ID: 4, Type: hnapi.TypeComment, By: "dave", Text: "A reply", Parent: 3, Time: posted},
+	)
+	srv.SetList(hnapi.ListTop, []int{1, 2})
+	srv.SetList(hnapi.ListNew, []int{2})
+
+	var out bytes.Buffer
+	a := newApp(srv.Client(), &out, hnapi.ListTop)
+	a.now = func() time.Time { return now }
+
+	input := strings.Join([]string{"1", "1", "b", "l new", "q"}, "\n")
+	if err := a.run(context.Background(), strings.NewReader(input)); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"== top stories (page 1/1) ==",
+		"  1. First story (example.com)",
+		"42 points by alice 2h ago | 2 comments",
+		"+[1] carol 2h ago (1 replies)",
+		"Top comment",
+		"    [2] dave 2h ago",
+		"== new stories (page 1/1) ==",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	// The reply is loaded only on expansion, and items seen before are not refetched
+	if n := srv.Requests("item/4.json"); n != 1 {
+		t.Errorf("Expected reply to be fetched once, got %d requests", n)
+	}
+	if n := srv.Requests("item/2.json"); n != 1 {
+		t.Errorf("Expected cached story to be fetched once, got %d requests", n)
+	}
+}
+
+func TestAppErrors(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	a := newApp(srv.Client(), &bytes.Buffer{}, hnapi.ListTop)
+	ctx := context.Background()
+
+	for _, line := range []string{"5", "l nope", "x"} {
+		if _, err := a.execute(ctx, line); err == nil {
+			t.Errorf("execute(%q) expected an error", line)
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "0m"},
+		{5 * time.Minute, "5m"},
+		{3 * time.Hour, "3h"},
+		{50 * time.Hour, "2d"},
+	}
+
+	for _, tt := range tests {
+		if got := formatAge(tt.d); got != tt.want {
+			t.Errorf("formatAge(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
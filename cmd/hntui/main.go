@@ -0,0 +1,47 @@
+// Command hntui is an interactive, line-based terminal reader for Hacker News.
+// It prints a view and reads one command per line from standard input, so it also
+// works in plain terminals and pipes; it is not a full-screen interface.
+//
+// It shows a story list page by page and opens stories with the first level of
+// their comment tree, loaded with GetCommentTree. Each further level of the tree
+// is loaded only when a comment is expanded. Stories already seen on list pages
+// are kept in memory, so paging back and forth does not refetch them.
+//
+// Usage:
+//
+//	hntui [-list top|new|best|ask|show|job] [-concurrency n]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/yarlson/hnapi"
+)
+
+func main() {
+	listName := flag.String("list", "top", "story list to open: top, new, best, ask, show, or job")
+	concurrency := flag.Int("concurrency", 10, "maximum number of concurrent requests")
+	flag.Parse()
+
+	list, ok := lists[*listName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "hntui: unknown list %q\n", *listName)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := hnapi.NewClient(hnapi.WithConcurrency(*concurrency))
+	defer client.Close()
+
+	if err := newApp(client, os.Stdout, list).run(ctx, os.Stdin); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "hntui: %v\n", err)
+		os.Exit(1)
+	}
+}
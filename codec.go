@@ -0,0 +1,48 @@
+package hnapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// marshalJSON encodes v with the configured JSON codec.
+func (c *Client) marshalJSON(v interface{}) ([]byte, error) {
+	if c.Config.JSONMarshal != nil {
+		return c.Config.JSONMarshal(v)
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalJSON decodes data into v with the configured JSON codec.
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if c.Config.JSONUnmarshal != nil {
+		return c.Config.JSONUnmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodeBody decodes a response body into target. The standard library decoder
+// streams the body through a pooled reader; a custom codec receives a freshly
+// allocated copy, since it may keep references into the data it decodes.
+func (c *Client) decodeBody(body io.Reader, target interface{}) error {
+	if c.Config.JSONUnmarshal == nil {
+		return decodeResponse(body, target, c.Config.StrictDecoding)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return ErrNotFound
+	}
+
+	if err := c.Config.JSONUnmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package hnapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithJSONCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		body := `{"id": 8863, "type": "story"}`
+		if strings.HasSuffix(r.URL.Path, "/item/1.json") {
+			body = "null\n"
+		}
+		_, err := w.Write([]byte(body))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var calls atomic.Int32
+	unmarshal := func(data []byte, v interface{}) error {
+		calls.Add(1)
+		return json.Unmarshal(data, v)
+	}
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithJSONCodec(json.Marshal, unmarshal),
+	)
+
+	item, err := client.GetItem(context.Background(), 8863)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.ID != 8863 {
+		t.Errorf("Expected item 8863, got %d", item.ID)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected the custom codec to be called once, got %d", calls.Load())
+	}
+
+	// Null responses are detected before the codec is called
+	if _, err := client.GetItem(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected the codec not to be called for null, got %d calls", calls.Load())
+	}
+}
+
+func TestWithJSONCodecError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": 8863}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	codecErr := errors.New("codec failure")
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithJSONCodec(nil, func(data []byte, v interface{}) error { return codecErr }),
+	)
+
+	if _, err := client.GetItem(context.Background(), 8863); !errors.Is(err, codecErr) {
+		t.Errorf("Expected codec error, got %v", err)
+	}
+}
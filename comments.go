@@ -0,0 +1,109 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// CommentNode is an item in a comment tree together with its loaded replies.
+type CommentNode struct {
+	// Item is the story, poll, or comment at this node.
+	Item *Item
+
+	// Children are the loaded replies in ranked display order, unless the tree was
+	// sorted with WithCommentOrder or SortTree. Replies beyond the requested depth
+	// are not loaded; their IDs remain in Item.Kids.
+	Children []*CommentNode
+}
+
+// GetKids retrieves the direct replies of an item in ranked display order. It is
+// the building block for loading comment threads lazily, one level at a time.
+func (c *Client) GetKids(ctx context.Context, item *Item, opts ...CallOption) ([]*Item, error) {
+	return c.GetItemsBatch(ctx, item.Kids, opts...)
+}
+
+// GetCommentTree retrieves an item and its comments down to maxDepth levels of
+// replies, or the whole thread if maxDepth is 0. Each level is fetched as one batch,
+// so the client's Concurrency configuration applies.
+//
+// Comments that fail to load are left out of the tree and the first failure is
+// returned as the error together with the partial tree. Call options apply to every
+// fetch; WithCallTimeout bounds the whole tree, and WithCommentOrder sorts it.
+func (c *Client) GetCommentTree(ctx context.Context, id int, maxDepth int, opts ...CallOption) (*CommentNode, error) {
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetCommentTree", ItemID: id})
+
+	progress := newProgressReporter(o.progress, 1)
+	tree, err := c.getCommentTree(ctx, id, maxDepth, progress, itemOptions(opts))
+	progress.finish()
+	end(err)
+
+	if tree != nil {
+		SortTree(tree, o.commentOrder)
+	}
+
+	return tree, err
+}
+
+// getCommentTree loads the tree breadth first, one batch per level.
+func (c *Client) getCommentTree(ctx context.Context, id int, maxDepth int, progress *progressReporter, opts []CallOption) (*CommentNode, error) {
+	root, err := c.GetItem(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The story's comment count estimates the size of a complete tree
+	discovered := 1
+	if maxDepth <= 0 {
+		progress.grow(1 + root.Descendants)
+	}
+	progress.step(1)
+
+	tree := &CommentNode{Item: root}
+	level := []*CommentNode{tree}
+	var firstErr error
+
+	for depth := 1; len(level) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		var ids []int
+		for _, node := range level {
+			ids = append(ids, node.Item.Kids...)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		discovered += len(ids)
+		progress.grow(discovered)
+		results := c.fetchItemsProgress(ctx, ids, progress, opts)
+		if ctx.Err() != nil {
+			return tree, fmt.Errorf("failed to get comment tree of item %d: %w", id, ctx.Err())
+		}
+
+		// Results are in the order of ids, so they can be handed out parent by parent
+		var next []*CommentNode
+		i := 0
+		for _, node := range level {
+			for range node.Item.Kids {
+				result := results[i]
+				i++
+
+				if result.Error != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to get comment %d: %w", result.ID, result.Error)
+					}
+					continue
+				}
+
+				child := &CommentNode{Item: result.Item}
+				node.Children = append(node.Children, child)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+
+	return tree, firstErr
+}
@@ -0,0 +1,74 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestGetCommentTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var body string
+		switch r.URL.Path {
+		case "/item/1.json":
+			body = `{"id": 1, "type": "story", "kids": [2, 3]}`
+		case "/item/2.json":
+			body = `{"id": 2, "type": "comment", "parent": 1, "kids": [4, 5]}`
+		case "/item/3.json":
+			body = `{"id": 3, "type": "comment", "parent": 1}`
+		case "/item/4.json":
+			body = `{"id": 4, "type": "comment", "parent": 2, "kids": [6]}`
+		case "/item/6.json":
+			body = `{"id": 6, "type": "comment", "parent": 4}`
+		default:
+			body = `null`
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	ctx := context.Background()
+
+	tree, err := client.GetCommentTree(ctx, 1, 0)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetCommentTree() error = %v, want ErrNotFound for missing comment 5", err)
+	}
+	if got := treeIDs(tree); got != "1(2(4(6))3)" {
+		t.Errorf("Expected tree 1(2(4(6))3), got %s", got)
+	}
+
+	tree, err = client.GetCommentTree(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetCommentTree() with depth 1 error = %v", err)
+	}
+	if got := treeIDs(tree); got != "1(23)" {
+		t.Errorf("Expected tree 1(23), got %s", got)
+	}
+
+	kids, err := client.GetKids(ctx, tree.Children[0].Item)
+	if !errors.Is(err, ErrNotFound) || len(kids) != 1 || kids[0].ID != 4 {
+		t.Errorf("Expected GetKids to return comment 4 and ErrNotFound, got %v, %v", kids, err)
+	}
+}
+
+// treeIDs renders a comment tree compactly for comparison.
+func treeIDs(node *CommentNode) string {
+	if node == nil {
+		return ""
+	}
+	s := strconv.Itoa(node.Item.ID)
+	if len(node.Children) > 0 {
+		s += "("
+		for _, child := range node.Children {
+			s += treeIDs(child)
+		}
+		s += ")"
+	}
+	return s
+}
@@ -1,6 +1,7 @@
 package hnapi
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -10,35 +11,170 @@ type Config struct {
 	// BaseURL is the base URL for the Hacker News API.
 	BaseURL string
 
+	// FallbackURLs are mirrors of the API, such as a caching proxy, that requests
+	// fail over to when the BaseURL keeps failing. The client returns to the BaseURL
+	// after a minute.
+	FallbackURLs []string
+
+	// Offline, if set, serves items, users, and lists from a local store instead of
+	// the network. Endpoints the store cannot serve fail with ErrOffline.
+	Offline OfflineStore
+
 	// RequestTimeout is the timeout for HTTP requests.
 	RequestTimeout time.Duration
 
+	// MaxResponseSize is the largest response body, in bytes, the client will read.
+	// Larger responses fail with ErrResponseTooLarge. Zero disables the limit.
+	MaxResponseSize int64
+
+	// StrictDecoding makes decoding fail when a response contains fields the target
+	// type does not define, surfacing upstream schema changes early.
+	StrictDecoding bool
+
+	// UnescapeText unescapes HTML entities in the Title and Text of items and the
+	// About of users returned by GetItem and GetUser. Text and About keep their HTML
+	// tags, so they must no longer be rendered as HTML or passed to HTMLToText.
+	UnescapeText bool
+
+	// TombstoneErrors makes GetItem report deleted and dead items with errors
+	// wrapping ErrDeleted and ErrDead instead of returning them like live items.
+	TombstoneErrors bool
+
+	// JSONMarshal and JSONUnmarshal replace encoding/json, for example with a faster
+	// implementation. JSONUnmarshal decodes API responses; JSONMarshal is used by
+	// features that encode data. Nil functions use encoding/json. StrictDecoding only applies to
+	// encoding/json; a custom codec decides for itself how to treat unknown fields.
+	JSONMarshal   func(v interface{}) ([]byte, error)
+	JSONUnmarshal func(data []byte, v interface{}) error
+
+	// ListCacheTTL caches the story lists returned by GetTopStories, GetList, and
+	// friends for this long, so repeated calls are served without a request. A list
+	// older than the TTL is still served for one more TTL while it is refreshed in
+	// the background. Zero disables the cache.
+	ListCacheTTL time.Duration
+
+	// CachePolicy caches responses per class of endpoint: items, users, lists, and
+	// updates. Its Lists setting, when enabled, overrides ListCacheTTL.
+	CachePolicy CachePolicy
+
 	// MaxRetries is the maximum number of retries for failed requests.
 	MaxRetries int
 
+	// RetryBudget caps the retries of all requests together. A zero Window
+	// disables the budget.
+	RetryBudget RetryBudget
+
 	// BackoffInterval is the time to wait between retries.
 	BackoffInterval time.Duration
 
+	// BackoffStrategy, if set, decides the time to wait between retries instead of
+	// BackoffInterval. A dropped update stream is still reconnected after
+	// BackoffInterval.
+	BackoffStrategy BackoffStrategy
+
 	// PollInterval is the time to wait between polling the updates endpoint.
 	PollInterval time.Duration
 
 	// Concurrency is the maximum number of concurrent requests for batch operations.
 	Concurrency int
 
-	// HTTPClient is the HTTP client used for making requests.
+	// Limiter paces every request the client sends, including retries. Share one
+	// Limiter between clients to keep their combined request rate polite. A nil
+	// Limiter does not pace requests.
+	Limiter Limiter
+
+	// MaxInFlight limits the number of requests in flight across all calls of the
+	// client. Requests beyond it wait for a transport slot, which is handed to the
+	// waiting request with the highest Priority first. Zero disables the limit.
+	MaxInFlight int
+
+	// UpdatesMode selects whether updates are polled or streamed over Server-Sent Events.
+	UpdatesMode UpdatesMode
+
+	// UpdatesBufferSize is the capacity of the channel returned by StartUpdates.
+	UpdatesBufferSize int
+
+	// UpdatesOverflowPolicy determines what the poller does when the updates channel is full.
+	UpdatesOverflowPolicy OverflowPolicy
+
+	// UpdatesDedupWindow suppresses item IDs and usernames already emitted by the
+	// updates poller within this window. Zero disables deduplication.
+	UpdatesDedupWindow time.Duration
+
+	// HTTPClient is the HTTP client used for making requests. If it is nil, NewClient
+	// creates a dedicated client whose connection pool is sized to Concurrency.
 	HTTPClient *http.Client
+
+	// MaxIdleConnsPerHost is the number of idle connections the default HTTP client
+	// keeps per host. Zero sizes the pool to Concurrency. It has no effect when
+	// HTTPClient is set.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long the default HTTP client keeps idle connections
+	// open. Zero uses DefaultIdleConnTimeout. It has no effect when HTTPClient is set.
+	IdleConnTimeout time.Duration
+
+	// UserAgent is sent as the User-Agent header of every request. An empty
+	// UserAgent leaves the header to the HTTP client.
+	UserAgent string
+
+	// DefaultHeaders are added to every request. A User-Agent set here takes
+	// precedence over UserAgent.
+	DefaultHeaders http.Header
+
+	// Middleware wraps every request the client makes. The first middleware is the outermost.
+	Middleware []Middleware
+
+	// Hooks are invoked for every request the client makes.
+	Hooks []Hooks
+
+	// Tracer instruments client operations. A nil Tracer disables tracing.
+	Tracer Tracer
+
+	// ExpvarPrefix is the expvar name under which the client's counters are published.
+	// An empty prefix disables publishing.
+	ExpvarPrefix string
+
+	// Clock is the source of time for polling, retries, and caches. A nil Clock
+	// uses the system clock.
+	Clock Clock
+
+	// Logger receives diagnostic messages from the client, such as polling errors.
+	// A nil Logger discards all messages.
+	Logger *slog.Logger
+
+	// Debug logs every request attempt through Logger at debug level, with its URL,
+	// status, duration, and the start of the response body.
+	Debug bool
+
+	// ErrorHandler is called with errors from background operations, such as the
+	// updates poller, giving applications one hook for metrics and alerting.
+	ErrorHandler func(error)
 }
 
+// DefaultMaxResponseSize is the default limit on response body size. The largest
+// regular responses, such as 500-ID story lists, are well below it.
+const DefaultMaxResponseSize = 10 << 20
+
+// DefaultUserAgent is the User-Agent sent by clients that do not set their own.
+const DefaultUserAgent = "hnapi/" + Version + " (+https://github.com/yarlson/hnapi)"
+
 // DefaultConfig returns a default configuration for the Hacker News API client.
 func DefaultConfig() *Config {
 	return &Config{
 		BaseURL:         "https://hacker-news.firebaseio.com/v0/",
 		RequestTimeout:  10 * time.Second,
+		MaxResponseSize: DefaultMaxResponseSize,
 		MaxRetries:      3,
 		BackoffInterval: 2 * time.Second,
 		PollInterval:    30 * time.Second,
 		Concurrency:     10,
-		HTTPClient:      http.DefaultClient,
+		UserAgent:       DefaultUserAgent,
+		Logger:          newDiscardLogger(),
+
+		UpdatesMode:           UpdatesModePoll,
+		UpdatesBufferSize:     1,
+		UpdatesOverflowPolicy: OverflowBlock,
 	}
 }
 
@@ -52,6 +188,28 @@ func WithBaseURL(url string) Option {
 	}
 }
 
+// WithBaseURLs sets the primary base URL followed by fallback mirrors. After three
+// consecutive failed requests the client switches to the next URL, and it tries the
+// primary again after a minute.
+func WithBaseURLs(urls ...string) Option {
+	return func(c *Config) {
+		if len(urls) == 0 {
+			return
+		}
+		c.BaseURL = urls[0]
+		c.FallbackURLs = append([]string(nil), urls[1:]...)
+	}
+}
+
+// WithOffline serves items, users, and lists from a local store, such as an
+// hnapiarchive.Archive, and never touches the network. Other endpoints, including
+// maxitem and updates, fail with ErrOffline.
+func WithOffline(store OfflineStore) Option {
+	return func(c *Config) {
+		c.Offline = store
+	}
+}
+
 // WithRequestTimeout sets a custom request timeout.
 func WithRequestTimeout(timeout time.Duration) Option {
 	return func(c *Config) {
@@ -59,6 +217,48 @@ func WithRequestTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithMaxResponseSize sets the largest response body, in bytes, the client will read.
+// Zero disables the limit.
+func WithMaxResponseSize(size int64) Option {
+	return func(c *Config) {
+		c.MaxResponseSize = size
+	}
+}
+
+// WithStrictDecoding makes requests fail when a response contains unknown fields
+// instead of silently ignoring them.
+func WithStrictDecoding() Option {
+	return func(c *Config) {
+		c.StrictDecoding = true
+	}
+}
+
+// WithUnescapedText makes the client unescape HTML entities in item titles and
+// texts and in user about fields, so "&#x27;" arrives as "'".
+func WithUnescapedText() Option {
+	return func(c *Config) {
+		c.UnescapeText = true
+	}
+}
+
+// WithTombstoneErrors makes GetItem return an error wrapping ErrDeleted or ErrDead,
+// along with the item, for deleted and dead items. Batch operations then treat such
+// items as failures.
+func WithTombstoneErrors() Option {
+	return func(c *Config) {
+		c.TombstoneErrors = true
+	}
+}
+
+// WithJSONCodec replaces encoding/json with the given functions, which must behave
+// like json.Marshal and json.Unmarshal. Either may be nil to keep encoding/json.
+func WithJSONCodec(marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) Option {
+	return func(c *Config) {
+		c.JSONMarshal = marshal
+		c.JSONUnmarshal = unmarshal
+	}
+}
+
 // WithMaxRetries sets a custom maximum number of retries.
 func WithMaxRetries(retries int) Option {
 	return func(c *Config) {
@@ -66,6 +266,18 @@ func WithMaxRetries(retries int) Option {
 	}
 }
 
+// WithRetryBudget caps retries across all requests of the client, for example to
+// 20% of requests over the last ten seconds:
+//
+//	hnapi.WithRetryBudget(hnapi.RetryBudget{Ratio: 0.2, MinRetries: 10, Window: 10 * time.Second})
+//
+// Requests that fail once the budget is spent return a *RetryBudgetError.
+func WithRetryBudget(budget RetryBudget) Option {
+	return func(c *Config) {
+		c.RetryBudget = budget
+	}
+}
+
 // WithBackoffInterval sets a custom backoff interval between retries.
 func WithBackoffInterval(interval time.Duration) Option {
 	return func(c *Config) {
@@ -73,6 +285,15 @@ func WithBackoffInterval(interval time.Duration) Option {
 	}
 }
 
+// WithBackoffStrategy sets the strategy that decides the time to wait between
+// retries, such as ExponentialBackoff or FibonacciBackoff, overriding
+// WithBackoffInterval.
+func WithBackoffStrategy(strategy BackoffStrategy) Option {
+	return func(c *Config) {
+		c.BackoffStrategy = strategy
+	}
+}
+
 // WithPollInterval sets a custom polling interval for updates.
 func WithPollInterval(interval time.Duration) Option {
 	return func(c *Config) {
@@ -87,9 +308,185 @@ func WithConcurrency(concurrency int) Option {
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client.
+// WithSharedLimiter paces every request of the client with limiter. Passing the
+// same limiter, such as one from NewTokenBucket, to several clients makes them share
+// one request budget.
+func WithSharedLimiter(limiter Limiter) Option {
+	return func(c *Config) {
+		c.Limiter = limiter
+	}
+}
+
+// WithMaxInFlight limits the requests in flight across all calls to slots and
+// schedules waiting requests by priority, so a single GetItem from a UI does not
+// queue behind a crawl. Set priorities per call with WithPriority.
+func WithMaxInFlight(slots int) Option {
+	return func(c *Config) {
+		c.MaxInFlight = slots
+	}
+}
+
+// WithUpdatesMode selects the transport used by the updates subscriptions.
+// With UpdatesModeStream, the HTTPClient must not set a Timeout shorter than the
+// desired lifetime of the stream.
+func WithUpdatesMode(mode UpdatesMode) Option {
+	return func(c *Config) {
+		c.UpdatesMode = mode
+	}
+}
+
+// WithUpdatesBufferSize sets the capacity of the channel returned by StartUpdates.
+func WithUpdatesBufferSize(size int) Option {
+	return func(c *Config) {
+		c.UpdatesBufferSize = size
+	}
+}
+
+// WithUpdatesOverflowPolicy sets the policy applied when the updates channel is full.
+func WithUpdatesOverflowPolicy(policy OverflowPolicy) Option {
+	return func(c *Config) {
+		c.UpdatesOverflowPolicy = policy
+	}
+}
+
+// WithUpdatesDedupWindow enables suppression of item IDs and usernames that the
+// updates poller already emitted within the given window.
+func WithUpdatesDedupWindow(window time.Duration) Option {
+	return func(c *Config) {
+		c.UpdatesDedupWindow = window
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client in place of the tuned default one.
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Config) {
 		c.HTTPClient = client
 	}
 }
+
+// WithMaxIdleConnsPerHost sets how many idle connections the default HTTP client
+// keeps per host. Raise it with Concurrency-heavy workloads that share a client, so
+// connections are reused instead of churned. It is ignored with WithHTTPClient.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Config) {
+		c.MaxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long the default HTTP client keeps idle connections
+// open. It is ignored with WithHTTPClient.
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.IdleConnTimeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithDefaultHeaders adds headers to every request. It can be used multiple times;
+// later values replace earlier ones for the same header.
+func WithDefaultHeaders(headers http.Header) Option {
+	return func(c *Config) {
+		if c.DefaultHeaders == nil {
+			c.DefaultHeaders = make(http.Header, len(headers))
+		}
+		for key, values := range headers {
+			c.DefaultHeaders[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
+}
+
+// WithMiddleware appends middleware that wraps every request the client makes.
+// Middleware runs in the order given, with the first one outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Config) {
+		c.Middleware = append(c.Middleware, middleware...)
+	}
+}
+
+// WithHooks registers request lifecycle callbacks. It can be used multiple times;
+// all registered hooks are called in order.
+func WithHooks(hooks Hooks) Option {
+	return func(c *Config) {
+		c.Hooks = append(c.Hooks, hooks)
+	}
+}
+
+// WithTracer sets the tracer used to instrument client operations.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Config) {
+		c.Tracer = tracer
+	}
+}
+
+// WithExpvar publishes the client's counters (requests, errors, retries, items
+// fetched, and updates received) as an expvar.Map under the given name.
+func WithExpvar(prefix string) Option {
+	return func(c *Config) {
+		c.ExpvarPrefix = prefix
+	}
+}
+
+// WithListCache caches story lists for ttl and refreshes them in the background
+// once they go stale, so UIs can call GetTopStories on every render. Use the
+// WithNoCache call option with GetList to force a fresh list.
+func WithListCache(ttl time.Duration) Option {
+	return func(c *Config) {
+		c.ListCacheTTL = ttl
+	}
+}
+
+// WithCachePolicy caches responses with a separate TTL for each class of endpoint,
+// for example items for ten minutes and lists for thirty seconds:
+//
+//	hnapi.WithCachePolicy(hnapi.CachePolicy{
+//		Items: hnapi.EndpointCache{Enabled: true, TTL: 10 * time.Minute},
+//		Lists: hnapi.EndpointCache{Enabled: true, TTL: 30 * time.Second},
+//	})
+//
+// Cached items and users are decoded afresh on every hit, so callers can modify
+// what they get back. Pass the WithNoCache call option to bypass the cache.
+func WithCachePolicy(policy CachePolicy) Option {
+	return func(c *Config) {
+		c.CachePolicy = policy
+	}
+}
+
+// WithClock replaces the system clock used for polling, retry backoff, and cache
+// expiry, so tests can advance time synthetically instead of sleeping.
+func WithClock(clock Clock) Option {
+	return func(c *Config) {
+		c.Clock = clock
+	}
+}
+
+// WithLogger sets the structured logger used for the client's diagnostic messages.
+// By default the client does not log anything.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithDebug logs every request attempt through the configured logger at debug level:
+// the method, URL with query, attempt number, status code, duration, error, and the
+// first 512 bytes of the response body. It is meant for diagnosing unexpected API
+// behavior; the logger's handler must have debug level enabled for entries to show.
+func WithDebug() Option {
+	return func(c *Config) {
+		c.Debug = true
+	}
+}
+
+// WithErrorHandler sets a callback invoked with errors from background operations.
+// The handler is called synchronously from the background goroutine and must not block.
+func WithErrorHandler(handler func(error)) Option {
+	return func(c *Config) {
+		c.ErrorHandler = handler
+	}
+}
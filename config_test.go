@@ -33,8 +33,8 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Expected Concurrency to be %d, got %d", 10, config.Concurrency)
 	}
 
-	if config.HTTPClient != http.DefaultClient {
-		t.Errorf("Expected HTTPClient to be http.DefaultClient")
+	if config.HTTPClient != nil {
+		t.Errorf("Expected HTTPClient to be nil until NewClient creates the default one")
 	}
 }
 
@@ -129,3 +129,25 @@ func TestPartialOptions(t *testing.T) {
 		t.Errorf("Expected PollInterval to be default %v, got %v", defaultConfig.PollInterval, config.PollInterval)
 	}
 }
+
+func TestUpdatesBufferOptions(t *testing.T) {
+	config := DefaultConfig()
+	if config.UpdatesBufferSize != 1 {
+		t.Errorf("Expected default UpdatesBufferSize to be 1, got %d", config.UpdatesBufferSize)
+	}
+	if config.UpdatesOverflowPolicy != OverflowBlock {
+		t.Errorf("Expected default UpdatesOverflowPolicy to be %v, got %v", OverflowBlock, config.UpdatesOverflowPolicy)
+	}
+
+	client := NewClient(
+		WithUpdatesBufferSize(16),
+		WithUpdatesOverflowPolicy(OverflowDropOldest),
+	)
+
+	if client.Config.UpdatesBufferSize != 16 {
+		t.Errorf("Expected UpdatesBufferSize to be 16, got %d", client.Config.UpdatesBufferSize)
+	}
+	if client.Config.UpdatesOverflowPolicy != OverflowDropOldest {
+		t.Errorf("Expected UpdatesOverflowPolicy to be %v, got %v", OverflowDropOldest, client.Config.UpdatesOverflowPolicy)
+	}
+}
@@ -0,0 +1,220 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Defaults used by Crawl for unset CrawlConfig fields.
+const (
+	DefaultCrawlName               = "crawl"
+	DefaultCrawlCheckpointInterval = 1000
+	DefaultCrawlProgressInterval   = 10 * time.Second
+)
+
+// CrawlConfig configures a crawl started with Crawl.
+type CrawlConfig struct {
+	// Name identifies the crawl in the checkpoint store. It defaults to
+	// DefaultCrawlName.
+	Name string
+
+	// StartID is the first item ID to crawl when there is no checkpoint. It
+	// defaults to 1.
+	StartID int
+
+	// EndID is the last item ID to crawl. If 0, the crawl ends at the maxitem
+	// reported when it starts.
+	EndID int
+
+	// Checkpoints persists the last processed ID so a restarted crawl resumes
+	// after it. If nil, progress is not persisted.
+	Checkpoints CheckpointStore
+
+	// CheckpointInterval is how many IDs are processed between checkpoints. It
+	// defaults to DefaultCrawlCheckpointInterval.
+	CheckpointInterval int
+
+	// OnProgress, if set, is called every ProgressInterval and once when the
+	// crawl ends.
+	OnProgress func(CrawlProgress)
+
+	// ProgressInterval is how often OnProgress is called. It defaults to
+	// DefaultCrawlProgressInterval.
+	ProgressInterval time.Duration
+}
+
+// CrawlProgress reports how far a crawl has come.
+type CrawlProgress struct {
+	// StartID is the first ID crawled in this run, after any checkpoint.
+	StartID int
+
+	// EndID is the last ID of the crawl.
+	EndID int
+
+	// LastID is the highest ID processed so far; every ID up to it is done.
+	LastID int
+
+	// Items is the number of items passed to the callback in this run.
+	Items int64
+
+	// Elapsed is the duration of this run.
+	Elapsed time.Duration
+
+	// Rate is the number of IDs processed per second in this run.
+	Rate float64
+
+	// ETA is the estimated time until the crawl reaches EndID at the current
+	// rate, or 0 if it cannot be estimated yet.
+	ETA time.Duration
+}
+
+// Remaining returns the number of IDs left to crawl.
+func (p CrawlProgress) Remaining() int {
+	if p.LastID >= p.EndID {
+		return 0
+	}
+	return p.EndID - p.LastID
+}
+
+// Crawl walks the item ID range from the configured start toward maxitem, calling
+// fn for each item in ascending ID order as WalkItems does. With a checkpoint
+// store, the last processed ID is saved periodically and when the crawl ends, and
+// a restarted crawl with the same Name resumes after it.
+//
+// The crawl stops at the first fetch error, when fn returns an error, or when the
+// context is canceled; the error is returned unless fn returned ErrStopWalk. Progress
+// up to the last processed item is saved in every case.
+func (c *Client) Crawl(ctx context.Context, config CrawlConfig, fn func(*Item) error, opts ...CallOption) error {
+	config = config.withDefaults()
+
+	startID := config.StartID
+	if config.Checkpoints != nil {
+		lastID, err := config.Checkpoints.LoadCheckpoint(ctx, config.Name)
+		if err != nil {
+			return fmt.Errorf("failed to load crawl checkpoint: %w", err)
+		}
+		if lastID >= startID {
+			startID = lastID + 1
+		}
+	}
+
+	endID := config.EndID
+	if endID <= 0 {
+		maxID, err := c.GetMaxItem(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start crawl: %w", err)
+		}
+		endID = maxID
+	}
+
+	crawl := &crawlState{
+		config:   config,
+		progress: CrawlProgress{StartID: startID, EndID: endID, LastID: startID - 1},
+		saved:    startID - 1,
+		clock:    c.clock(),
+	}
+	crawl.started = crawl.clock.Now()
+	crawl.reported = crawl.started
+
+	stopped := false
+	err := c.WalkItems(ctx, startID, endID, func(item *Item) error {
+		if err := fn(item); err != nil {
+			stopped = errors.Is(err, ErrStopWalk)
+			return err
+		}
+		return crawl.advance(ctx, item.ID)
+	}, opts...)
+
+	// A complete walk covers trailing IDs that do not exist too
+	if err == nil && !stopped && endID >= startID {
+		crawl.progress.LastID = endID
+	}
+
+	// Save even if the crawl was canceled, so a restart does not repeat the work
+	saveErr := crawl.save(context.WithoutCancel(ctx))
+	crawl.report(crawl.clock.Now())
+
+	if err != nil {
+		return err
+	}
+	return saveErr
+}
+
+// withDefaults returns the configuration with unset fields defaulted.
+func (c CrawlConfig) withDefaults() CrawlConfig {
+	if c.Name == "" {
+		c.Name = DefaultCrawlName
+	}
+	if c.StartID < 1 {
+		c.StartID = 1
+	}
+	if c.CheckpointInterval <= 0 {
+		c.CheckpointInterval = DefaultCrawlCheckpointInterval
+	}
+	if c.ProgressInterval <= 0 {
+		c.ProgressInterval = DefaultCrawlProgressInterval
+	}
+	return c
+}
+
+// crawlState tracks the progress of a running crawl.
+type crawlState struct {
+	config   CrawlConfig
+	progress CrawlProgress
+	saved    int
+	clock    Clock
+	started  time.Time
+	reported time.Time
+}
+
+// advance records that id was processed, saving a checkpoint and reporting
+// progress when they are due.
+func (s *crawlState) advance(ctx context.Context, id int) error {
+	s.progress.LastID = id
+	s.progress.Items++
+
+	if s.progress.LastID-s.saved >= s.config.CheckpointInterval {
+		if err := s.save(ctx); err != nil {
+			return err
+		}
+	}
+
+	if now := s.clock.Now(); now.Sub(s.reported) >= s.config.ProgressInterval {
+		s.report(now)
+	}
+	return nil
+}
+
+// save persists the last processed ID if it changed since the previous save.
+func (s *crawlState) save(ctx context.Context) error {
+	if s.config.Checkpoints == nil || s.progress.LastID == s.saved {
+		return nil
+	}
+
+	if err := s.config.Checkpoints.SaveCheckpoint(ctx, s.config.Name, s.progress.LastID); err != nil {
+		return fmt.Errorf("failed to save crawl checkpoint: %w", err)
+	}
+	s.saved = s.progress.LastID
+	return nil
+}
+
+// report calls OnProgress with the rate and ETA as of now.
+func (s *crawlState) report(now time.Time) {
+	s.reported = now
+	if s.config.OnProgress == nil {
+		return
+	}
+
+	p := s.progress
+	p.Elapsed = now.Sub(s.started)
+
+	done := p.LastID - p.StartID + 1
+	if p.Elapsed > 0 && done > 0 {
+		p.Rate = float64(done) / p.Elapsed.Seconds()
+		p.ETA = time.Duration(float64(p.Remaining()) / p.Rate * float64(time.Second))
+	}
+
+	s.config.OnProgress(p)
+}
@@ -0,0 +1,128 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newCrawlServer serves items 1-20 except 5 and reports 20 as maxitem.
+func newCrawlServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/maxitem.json" {
+			_, _ = w.Write([]byte(`20`))
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+		if id == "5" {
+			_, _ = w.Write([]byte(`null`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": ` + id + `}`))
+	}))
+}
+
+func TestCrawlResume(t *testing.T) {
+	server := newCrawlServer()
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(4))
+	store := NewMemoryCheckpointStore()
+	ctx := context.Background()
+	config := CrawlConfig{Name: "test", StartID: 3, EndID: 12, Checkpoints: store, CheckpointInterval: 2}
+
+	// The first run is interrupted by the callback
+	var first []int
+	testErr := errors.New("interrupted")
+	err := client.Crawl(ctx, config, func(item *Item) error {
+		if item.ID == 8 {
+			return testErr
+		}
+		first = append(first, item.ID)
+		return nil
+	})
+	if !errors.Is(err, testErr) {
+		t.Fatalf("Crawl() error = %v, want %v", err, testErr)
+	}
+	if !reflect.DeepEqual(first, []int{3, 4, 6, 7}) {
+		t.Errorf("First run visited %v, want [3 4 6 7]", first)
+	}
+	if id, _ := store.LoadCheckpoint(ctx, "test"); id != 7 {
+		t.Errorf("Expected checkpoint 7 after interruption, got %d", id)
+	}
+
+	// The restarted run resumes after the checkpoint and finishes the range
+	var second []int
+	var progress []CrawlProgress
+	config.OnProgress = func(p CrawlProgress) { progress = append(progress, p) }
+	err = client.Crawl(ctx, config, func(item *Item) error {
+		second = append(second, item.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	if !reflect.DeepEqual(second, []int{8, 9, 10, 11, 12}) {
+		t.Errorf("Second run visited %v, want [8 9 10 11 12]", second)
+	}
+	if id, _ := store.LoadCheckpoint(ctx, "test"); id != 12 {
+		t.Errorf("Expected checkpoint 12 after completion, got %d", id)
+	}
+
+	if len(progress) == 0 {
+		t.Fatal("Expected a final progress report")
+	}
+	final := progress[len(progress)-1]
+	if final.StartID != 8 || final.LastID != 12 || final.Items != 5 || final.Remaining() != 0 || final.ETA != 0 {
+		t.Errorf("Unexpected final progress %+v", final)
+	}
+
+	// A finished crawl has nothing left to do
+	err = client.Crawl(ctx, config, func(item *Item) error {
+		t.Errorf("Unexpected item %d after the crawl finished", item.ID)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Crawl() error = %v", err)
+	}
+}
+
+func TestCrawlToMaxItem(t *testing.T) {
+	server := newCrawlServer()
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	var count int
+	err := client.Crawl(context.Background(), CrawlConfig{StartID: 15}, func(item *Item) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Crawl() error = %v", err)
+	}
+	if count != 6 {
+		t.Errorf("Expected items 15-20 to be crawled, got %d", count)
+	}
+}
+
+func TestCrawlProgressETA(t *testing.T) {
+	p := CrawlProgress{StartID: 1, EndID: 100, LastID: 25}
+	if p.Remaining() != 75 {
+		t.Errorf("Remaining() = %d, want 75", p.Remaining())
+	}
+
+	s := &crawlState{progress: p, started: time.Unix(0, 0)}
+	var got CrawlProgress
+	s.config.OnProgress = func(p CrawlProgress) { got = p }
+	s.report(time.Unix(5, 0))
+
+	if got.Rate != 5 || got.ETA != 15*time.Second {
+		t.Errorf("Expected 5 IDs/s and a 15s ETA, got %v and %v", got.Rate, got.ETA)
+	}
+}
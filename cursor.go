@@ -0,0 +1,169 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultCursorPageSize is the page size NewItemCursor uses when none is given.
+const DefaultCursorPageSize = 30
+
+// ItemCursor pages through a list of item IDs, fetching the pages after the current
+// one in the background so a pagination UI can show the next page without waiting.
+// Use it like bufio.Scanner:
+//
+//	cursor := client.NewItemCursor(ctx, ids, 30, 1)
+//	defer cursor.Close()
+//	for cursor.Next() {
+//		render(cursor.Page())
+//	}
+//	if err := cursor.Err(); err != nil {
+//		log.Print(err)
+//	}
+//
+// Items that do not exist are left out of their page. An ItemCursor is not safe
+// for concurrent use.
+type ItemCursor struct {
+	client    *Client
+	ids       []int
+	pageSize  int
+	lookahead int
+	opts      []CallOption
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	pages   []chan pageResult
+	index   int
+	current []*Item
+	err     error
+}
+
+// pageResult is a fetched page.
+type pageResult struct {
+	items []*Item
+	err   error
+}
+
+// NewItemCursor creates a cursor over ids with pages of pageSize items, or
+// DefaultCursorPageSize if pageSize is 0, that keeps up to lookahead pages fetched
+// ahead of the current one. A lookahead of 0 disables prefetching. Call options
+// apply to every item fetch. The cursor must be closed to stop prefetching when it
+// is abandoned before the last page.
+func (c *Client) NewItemCursor(ctx context.Context, ids []int, pageSize, lookahead int, opts ...CallOption) *ItemCursor {
+	if pageSize <= 0 {
+		pageSize = DefaultCursorPageSize
+	}
+	if lookahead < 0 {
+		lookahead = 0
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	return &ItemCursor{
+		client:    c,
+		ids:       ids,
+		pageSize:  pageSize,
+		lookahead: lookahead,
+		opts:      opts,
+		ctx:       ctx,
+		cancel:    cancel,
+		pages:     make([]chan pageResult, (len(ids)+pageSize-1)/pageSize),
+		index:     -1,
+	}
+}
+
+// Next advances to the next page, waiting for it to load, and starts prefetching
+// the pages after it. It returns false when there are no more pages or a page
+// failed to load; Err reports which.
+func (cur *ItemCursor) Next() bool {
+	if cur.err != nil || cur.index+1 >= len(cur.pages) {
+		cur.current = nil
+		return false
+	}
+	cur.index++
+
+	for i := cur.index; i <= cur.index+cur.lookahead && i < len(cur.pages); i++ {
+		cur.start(i)
+	}
+
+	select {
+	case result := <-cur.pages[cur.index]:
+		cur.current, cur.err = result.items, result.err
+	case <-cur.ctx.Done():
+		cur.current, cur.err = nil, cur.ctx.Err()
+	}
+	return cur.err == nil
+}
+
+// Page returns the items of the current page in the order of their IDs.
+func (cur *ItemCursor) Page() []*Item {
+	return cur.current
+}
+
+// PageIndex returns the 0-based index of the current page.
+func (cur *ItemCursor) PageIndex() int {
+	return cur.index
+}
+
+// Pages returns the total number of pages.
+func (cur *ItemCursor) Pages() int {
+	return len(cur.pages)
+}
+
+// Err returns the error that stopped the cursor, or nil if it reached the end.
+func (cur *ItemCursor) Err() error {
+	return cur.err
+}
+
+// Close stops prefetching and waits for fetches in progress to finish. It is safe
+// to call more than once.
+func (cur *ItemCursor) Close() {
+	cur.cancel()
+	cur.wg.Wait()
+}
+
+// start begins fetching page i unless it has been started already.
+func (cur *ItemCursor) start(i int) {
+	if cur.pages[i] != nil {
+		return
+	}
+
+	ch := make(chan pageResult, 1)
+	cur.pages[i] = ch
+
+	start := i * cur.pageSize
+	end := start + cur.pageSize
+	if end > len(cur.ids) {
+		end = len(cur.ids)
+	}
+	ids := cur.ids[start:end]
+
+	cur.wg.Add(1)
+	go func() {
+		defer cur.wg.Done()
+		ch <- cur.fetch(ids)
+	}()
+}
+
+// fetch loads one page.
+func (cur *ItemCursor) fetch(ids []int) pageResult {
+	results := cur.client.fetchItems(cur.ctx, ids, cur.opts...)
+	if newCallOptions(cur.opts).skipTombstones {
+		results = withoutTombstones(results)
+	}
+
+	items := make([]*Item, 0, len(results))
+	for _, result := range results {
+		switch {
+		case errors.Is(result.Error, ErrNotFound):
+		case result.Error != nil:
+			return pageResult{err: fmt.Errorf("failed to get item %d: %w", result.ID, result.Error)}
+		default:
+			items = append(items, result.Item)
+		}
+	}
+	return pageResult{items: items}
+}
@@ -0,0 +1,110 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestItemCursor(t *testing.T) {
+	var mu sync.Mutex
+	requested := make(map[string]bool)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+		mu.Lock()
+		requested[id] = true
+		mu.Unlock()
+
+		switch id {
+		case "4":
+			_, _ = w.Write([]byte("null"))
+		case "9":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{"id": ` + id + `, "type": "story"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+
+	cursor := client.NewItemCursor(context.Background(), []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 1)
+	defer cursor.Close()
+
+	if cursor.Pages() != 3 {
+		t.Errorf("Expected 3 pages, got %d", cursor.Pages())
+	}
+
+	var pages [][]int
+	for cursor.Next() {
+		var ids []int
+		for _, item := range cursor.Page() {
+			ids = append(ids, item.ID)
+		}
+		pages = append(pages, ids)
+
+		if cursor.PageIndex() == 0 {
+			// The second page is prefetched while the first is consumed
+			deadline := time.Now().Add(time.Second)
+			for {
+				mu.Lock()
+				done := requested["4"] && requested["5"] && requested["6"]
+				mu.Unlock()
+				if done || time.Now().After(deadline) {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			mu.Lock()
+			if !requested["5"] || requested["7"] {
+				t.Errorf("Expected exactly the next page to be prefetched, got %v", requested)
+			}
+			mu.Unlock()
+		}
+	}
+
+	if want := [][]int{{1, 2, 3}, {5, 6}}; !reflect.DeepEqual(pages, want) {
+		t.Errorf("Pages = %v, want %v", pages, want)
+	}
+	if cursor.Err() == nil {
+		t.Errorf("Expected the error of the last page")
+	}
+	if cursor.Next() {
+		t.Errorf("Expected Next to stay false after an error")
+	}
+}
+
+func TestItemCursorClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	cursor := client.NewItemCursor(context.Background(), []int{1, 2, 3, 4}, 1, 2)
+	if !cursor.Next() {
+		t.Fatalf("Next() error = %v", cursor.Err())
+	}
+	cursor.Close()
+	cursor.Close()
+
+	// Pages prefetched before Close may still be returned; the rest are canceled
+	for cursor.Next() {
+	}
+	if err := cursor.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled after Close, got %v", err)
+	}
+
+	empty := client.NewItemCursor(context.Background(), nil, 10, 1)
+	if empty.Next() || empty.Err() != nil {
+		t.Errorf("Expected an empty cursor to end without error")
+	}
+}
@@ -0,0 +1,80 @@
+package hnapi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// debugBodyLimit is the number of response body bytes a debug log entry includes.
+const debugBodyLimit = 512
+
+// bodySnippet is an io.Writer that keeps the first debugBodyLimit bytes written to
+// it and discards the rest, recording that the body was truncated.
+type bodySnippet struct {
+	data      []byte
+	truncated bool
+}
+
+// Write keeps what fits in the snippet and reports the whole of p as written.
+func (s *bodySnippet) Write(p []byte) (int, error) {
+	room := debugBodyLimit - len(s.data)
+	if len(p) > room {
+		s.data = append(s.data, p[:room]...)
+		s.truncated = true
+		return len(p), nil
+	}
+	s.data = append(s.data, p...)
+	return len(p), nil
+}
+
+// String returns the captured body, marked with an ellipsis if it was truncated.
+func (s *bodySnippet) String() string {
+	if s == nil {
+		return ""
+	}
+	if s.truncated {
+		return string(s.data) + "..."
+	}
+	return string(s.data)
+}
+
+// capture returns body teed into the snippet, or body itself for a nil snippet.
+func (s *bodySnippet) capture(body io.Reader) io.Reader {
+	if s == nil {
+		return body
+	}
+	return io.TeeReader(body, s)
+}
+
+// debugRequest describes one attempt for the debug log.
+type debugRequest struct {
+	url        string
+	attempt    int
+	statusCode int
+	duration   time.Duration
+	body       *bodySnippet
+	err        error
+}
+
+// logRequest writes a debug log entry for an attempt when the Debug configuration
+// is set. Entries are logged at slog.LevelDebug.
+func (c *Client) logRequest(ctx context.Context, r debugRequest) {
+	if !c.Config.Debug {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", "GET"),
+		slog.String("url", r.url),
+		slog.Int("attempt", r.attempt),
+		slog.Int("status", r.statusCode),
+		slog.Duration("duration", r.duration),
+		slog.String("body", r.body.String()),
+	}
+	if r.err != nil {
+		attrs = append(attrs, slog.Any("error", r.err))
+	}
+	c.logger().LogAttrs(ctx, slog.LevelDebug, "http request", attrs...)
+}
@@ -0,0 +1,101 @@
+package hnapi
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithDebug(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`overloaded`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 8863, "type": "story", "title": "My YC app"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(WithBaseURL(server.URL+"/"), WithLogger(logger), WithDebug(), WithBackoffInterval(time.Millisecond))
+
+	if _, err := client.GetItem(context.Background(), 8863); err != nil {
+		t.Fatalf("GetItem failed: %v", err)
+	}
+
+	var entries []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, `msg="http request"`) {
+			entries = append(entries, line)
+		}
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 debug entries, got %d:\n%s", len(entries), buf.String())
+	}
+
+	for _, want := range []string{"method=GET", "url=" + server.URL + "/item/8863.json", "attempt=1", "status=503", "body=overloaded", "error="} {
+		if !strings.Contains(entries[0], want) {
+			t.Errorf("Expected first entry to contain %q, got %s", want, entries[0])
+		}
+	}
+	for _, want := range []string{"attempt=2", "status=200", `My YC app`, "duration="} {
+		if !strings.Contains(entries[1], want) {
+			t.Errorf("Expected second entry to contain %q, got %s", want, entries[1])
+		}
+	}
+}
+
+func TestWithDebugDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`8863`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(WithBaseURL(server.URL+"/"), WithLogger(logger))
+
+	if _, err := client.GetMaxItem(context.Background()); err != nil {
+		t.Fatalf("GetMaxItem failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "http request") {
+		t.Errorf("Expected no debug entries without WithDebug, got %s", buf.String())
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "short", input: "8863", want: "8863"},
+		{name: "exact", input: strings.Repeat("a", debugBodyLimit), want: strings.Repeat("a", debugBodyLimit)},
+		{name: "truncated", input: strings.Repeat("a", debugBodyLimit+10), want: strings.Repeat("a", debugBodyLimit) + "..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s bodySnippet
+			// Write in pieces, as a TeeReader would
+			for i := 0; i < len(tt.input); i += 100 {
+				chunk := tt.input[i:min(i+100, len(tt.input))]
+				if n, err := s.Write([]byte(chunk)); n != len(chunk) || err != nil {
+					t.Fatalf("Write returned %d, %v", n, err)
+				}
+			}
+			if got := s.String(); got != tt.want {
+				t.Errorf("Expected %d bytes, got %d", len(tt.want), len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package hnapi
+
+import "time"
+
+// updatesDeduper suppresses item IDs and usernames that were already emitted
+// within a sliding time window. It is used by a single poller goroutine and is
+// not safe for concurrent use.
+type updatesDeduper struct {
+	window   time.Duration
+	items    map[int]time.Time
+	profiles map[string]time.Time
+}
+
+// newUpdatesDeduper returns a deduper for the given window, or nil if the window
+// is not positive, which disables deduplication.
+func newUpdatesDeduper(window time.Duration) *updatesDeduper {
+	if window <= 0 {
+		return nil
+	}
+	return &updatesDeduper{
+		window:   window,
+		items:    make(map[int]time.Time),
+		profiles: make(map[string]time.Time),
+	}
+}
+
+// filter returns the updates with recently emitted IDs removed and records the
+// remaining IDs as emitted at now. A nil deduper returns the updates unchanged.
+func (d *updatesDeduper) filter(updates Updates, now time.Time) Updates {
+	if d == nil {
+		return updates
+	}
+
+	d.prune(now)
+
+	filtered := Updates{
+		Items:    make([]int, 0, len(updates.Items)),
+		Profiles: make([]string, 0, len(updates.Profiles)),
+	}
+
+	for _, id := range updates.Items {
+		if _, seen := d.items[id]; seen {
+			continue
+		}
+		d.items[id] = now
+		filtered.Items = append(filtered.Items, id)
+	}
+
+	for _, username := range updates.Profiles {
+		if _, seen := d.profiles[username]; seen {
+			continue
+		}
+		d.profiles[username] = now
+		filtered.Profiles = append(filtered.Profiles, username)
+	}
+
+	return filtered
+}
+
+// prune forgets IDs emitted longer ago than the window.
+func (d *updatesDeduper) prune(now time.Time) {
+	for id, emitted := range d.items {
+		if now.Sub(emitted) >= d.window {
+			delete(d.items, id)
+		}
+	}
+	for username, emitted := range d.profiles {
+		if now.Sub(emitted) >= d.window {
+			delete(d.profiles, username)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package hnapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUpdatesDeduper(t *testing.T) {
+	if newUpdatesDeduper(0) != nil {
+		t.Errorf("Expected zero window to disable deduplication")
+	}
+
+	var nilDedup *updatesDeduper
+	in := Updates{Items: []int{1}, Profiles: []string{"a"}}
+	if got := nilDedup.filter(in, time.Now()); !reflect.DeepEqual(got, in) {
+		t.Errorf("Expected nil deduper to pass updates through, got %+v", got)
+	}
+
+	dedup := newUpdatesDeduper(time.Minute)
+	start := time.Unix(1000, 0)
+
+	got := dedup.filter(Updates{Items: []int{1, 2}, Profiles: []string{"a"}}, start)
+	if !reflect.DeepEqual(got.Items, []int{1, 2}) || !reflect.DeepEqual(got.Profiles, []string{"a"}) {
+		t.Errorf("Expected first updates to pass through, got %+v", got)
+	}
+
+	got = dedup.filter(Updates{Items: []int{2, 3}, Profiles: []string{"a", "b"}}, start.Add(30*time.Second))
+	if !reflect.DeepEqual(got.Items, []int{3}) || !reflect.DeepEqual(got.Profiles, []string{"b"}) {
+		t.Errorf("Expected repeated IDs to be suppressed, got %+v", got)
+	}
+
+	got = dedup.filter(Updates{Items: []int{1, 3}, Profiles: []string{"a"}}, start.Add(61*time.Second))
+	if !reflect.DeepEqual(got.Items, []int{1}) || !reflect.DeepEqual(got.Profiles, []string{"a"}) {
+		t.Errorf("Expected IDs outside the window to be emitted again, got %+v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// DescendantsReport compares the comment count a story reports in
+// Item.Descendants with the comments actually found in its tree.
+type DescendantsReport struct {
+	// ID is the ID of the story or poll at the root of the tree.
+	ID int
+
+	// Reported is the root's Descendants field.
+	Reported int
+
+	// Live is the number of comments in the tree that are neither deleted nor dead.
+	Live int
+
+	// Deleted is the number of deleted comments in the tree.
+	Deleted int
+
+	// Dead is the number of dead comments in the tree.
+	Dead int
+
+	// Unloaded is the number of replies listed in Kids that are missing from the
+	// tree because they failed to load.
+	Unloaded int
+}
+
+// Discrepancy returns how many more comments the root reports than are live.
+// It is negative if the tree holds more live comments than reported.
+func (r DescendantsReport) Discrepancy() int {
+	return r.Reported - r.Live
+}
+
+// Consistent reports whether the reported count matches the live comments and
+// every reply was loaded.
+func (r DescendantsReport) Consistent() bool {
+	return r.Discrepancy() == 0 && r.Unloaded == 0
+}
+
+// CountDescendants recounts the comments of a fully loaded comment tree, as
+// returned by GetCommentTree with a maxDepth of 0. Replies to deleted and dead
+// comments are counted like any other.
+func CountDescendants(tree *CommentNode) DescendantsReport {
+	report := DescendantsReport{ID: tree.Item.ID, Reported: tree.Item.Descendants}
+
+	var count func(node *CommentNode)
+	count = func(node *CommentNode) {
+		report.Unloaded += len(node.Item.Kids) - len(node.Children)
+		for _, child := range node.Children {
+			switch {
+			case child.Item.Deleted:
+				report.Deleted++
+			case child.Item.Dead:
+				report.Dead++
+			default:
+				report.Live++
+			}
+			count(child)
+		}
+	}
+	count(tree)
+
+	return report
+}
+
+// VerifyDescendants loads the whole comment tree of a story and recounts its live
+// comments against Item.Descendants, for tools that need accurate comment counts.
+// Comments that fail to load are counted as Unloaded and the first failure is
+// returned with the report. With tombstone errors enabled, deleted and dead
+// comments fail to load, so they are counted as Unloaded rather than by kind.
+func (c *Client) VerifyDescendants(ctx context.Context, id int, opts ...CallOption) (*DescendantsReport, error) {
+	tree, err := c.GetCommentTree(ctx, id, 0, opts...)
+	if tree == nil {
+		return nil, fmt.Errorf("failed to verify descendants of item %d: %w", id, err)
+	}
+
+	report := CountDescendants(tree)
+	if err != nil {
+		return &report, fmt.Errorf("failed to verify descendants of item %d: %w", id, err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,98 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyDescendants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/item/1.json":
+			body = `{"id": 1, "type": "story", "kids": [2, 3, 4], "descendants": 4}`
+		case "/item/2.json":
+			body = `{"id": 2, "type": "comment", "parent": 1, "kids": [5]}`
+		case "/item/3.json":
+			body = `{"id": 3, "type": "comment", "parent": 1, "deleted": true, "kids": [6]}`
+		case "/item/4.json":
+			body = `{"id": 4, "type": "comment", "parent": 1, "dead": true}`
+		case "/item/5.json":
+			body = `{"id": 5, "type": "comment", "parent": 2}`
+		case "/item/6.json":
+			body = `{"id": 6, "type": "comment", "parent": 3}`
+		case "/item/10.json":
+			body = `{"id": 10, "type": "story", "kids": [11, 12], "descendants": 2}`
+		case "/item/11.json":
+			body = `{"id": 11, "type": "comment", "parent": 10}`
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	tests := []struct {
+		name            string
+		id              int
+		want            DescendantsReport
+		wantErr         bool
+		wantDiscrepancy int
+	}{
+		{
+			name:            "deleted and dead comments",
+			id:              1,
+			want:            DescendantsReport{ID: 1, Reported: 4, Live: 3, Deleted: 1, Dead: 1},
+			wantDiscrepancy: 1,
+		},
+		{
+			name:    "unloaded comments",
+			id:      10,
+			want:    DescendantsReport{ID: 10, Reported: 2, Live: 1, Unloaded: 1},
+			wantErr: true, wantDiscrepancy: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := client.VerifyDescendants(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyDescendants() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if *report != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, *report)
+			}
+			if got := report.Discrepancy(); got != tt.wantDiscrepancy {
+				t.Errorf("Expected discrepancy %d, got %d", tt.wantDiscrepancy, got)
+			}
+			if report.Consistent() {
+				t.Error("Expected the report not to be consistent")
+			}
+		})
+	}
+
+	// A root that fails to load yields no report
+	if report, err := client.VerifyDescendants(context.Background(), 99); err == nil || report != nil {
+		t.Errorf("Expected an error and no report, got %v and %v", report, err)
+	}
+}
+
+func TestCountDescendantsConsistent(t *testing.T) {
+	tree := &CommentNode{
+		Item: &Item{ID: 1, Kids: []int{2}, Descendants: 2},
+		Children: []*CommentNode{{
+			Item:     &Item{ID: 2, Kids: []int{3}},
+			Children: []*CommentNode{{Item: &Item{ID: 3}}},
+		}},
+	}
+
+	report := CountDescendants(tree)
+	if !report.Consistent() || report.Live != 2 {
+		t.Errorf("Expected a consistent report with 2 live comments, got %+v", report)
+	}
+}
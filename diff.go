@@ -0,0 +1,73 @@
+package hnapi
+
+import "slices"
+
+// ListChange is one ID whose position differs between two snapshots of a list.
+// Positions are 0-based indexes; -1 means the ID is not in that snapshot.
+type ListChange struct {
+	// ID is the item ID.
+	ID int
+
+	// OldIndex is the position in the old snapshot, or -1 if the ID was added.
+	OldIndex int
+
+	// NewIndex is the position in the new snapshot, or -1 if the ID was removed.
+	NewIndex int
+}
+
+// ListDiff describes how one snapshot of an ID list, such as the top stories,
+// turned into another.
+type ListDiff struct {
+	// Added are the IDs only in the new snapshot, in new order.
+	Added []ListChange
+
+	// Removed are the IDs only in the old snapshot, in old order.
+	Removed []ListChange
+
+	// Moved are the IDs in both snapshots at different positions, in new order.
+	Moved []ListChange
+}
+
+// Empty reports whether the snapshots have the same IDs in the same order.
+func (d ListDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved) == 0
+}
+
+// Diff compares two snapshots of an ID list and returns the IDs that were added,
+// removed, and moved. It is the building block for rank tracking, alerting, and
+// cache invalidation. An ID that appears more than once counts at its first position.
+func Diff(old, new []int) ListDiff {
+	oldIndex := make(map[int]int, len(old))
+	for i, id := range old {
+		if _, ok := oldIndex[id]; !ok {
+			oldIndex[id] = i
+		}
+	}
+
+	var diff ListDiff
+	inNew := make(map[int]struct{}, len(new))
+
+	for i, id := range new {
+		if _, ok := inNew[id]; ok {
+			continue
+		}
+		inNew[id] = struct{}{}
+
+		j, ok := oldIndex[id]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, ListChange{ID: id, OldIndex: -1, NewIndex: i})
+		case j != i:
+			diff.Moved = append(diff.Moved, ListChange{ID: id, OldIndex: j, NewIndex: i})
+		}
+	}
+
+	for id, i := range oldIndex {
+		if _, ok := inNew[id]; !ok {
+			diff.Removed = append(diff.Removed, ListChange{ID: id, OldIndex: i, NewIndex: -1})
+		}
+	}
+	slices.SortFunc(diff.Removed, func(a, b ListChange) int { return a.OldIndex - b.OldIndex })
+
+	return diff
+}
@@ -0,0 +1,64 @@
+package hnapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  []int
+		new  []int
+		want ListDiff
+	}{
+		{
+			name: "unchanged",
+			old:  []int{1, 2, 3},
+			new:  []int{1, 2, 3},
+			want: ListDiff{},
+		},
+		{
+			name: "from empty",
+			new:  []int{1, 2},
+			want: ListDiff{Added: []ListChange{{ID: 1, OldIndex: -1, NewIndex: 0}, {ID: 2, OldIndex: -1, NewIndex: 1}}},
+		},
+		{
+			name: "added, removed, and moved",
+			old:  []int{1, 2, 3, 4},
+			new:  []int{5, 1, 3, 2},
+			want: ListDiff{
+				Added:   []ListChange{{ID: 5, OldIndex: -1, NewIndex: 0}},
+				Removed: []ListChange{{ID: 4, OldIndex: 3, NewIndex: -1}},
+				Moved:   []ListChange{{ID: 1, OldIndex: 0, NewIndex: 1}, {ID: 2, OldIndex: 1, NewIndex: 3}},
+			},
+		},
+		{
+			name: "removed in old order",
+			old:  []int{1, 2, 3, 4},
+			new:  []int{2},
+			want: ListDiff{
+				Removed: []ListChange{{ID: 1, OldIndex: 0, NewIndex: -1}, {ID: 3, OldIndex: 2, NewIndex: -1}, {ID: 4, OldIndex: 3, NewIndex: -1}},
+				Moved:   []ListChange{{ID: 2, OldIndex: 1, NewIndex: 0}},
+			},
+		},
+		{
+			name: "duplicates count once",
+			old:  []int{1, 1, 2},
+			new:  []int{1, 2, 2},
+			want: ListDiff{Moved: []ListChange{{ID: 2, OldIndex: 2, NewIndex: 1}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff(%v, %v) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+			}
+			if got.Empty() != (len(tt.want.Added)+len(tt.want.Removed)+len(tt.want.Moved) == 0) {
+				t.Errorf("Empty() = %v for %+v", got.Empty(), got)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package hnapi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrNoURL is returned by Item.ParsedURL for items without a URL, such as Ask HN
+// posts and comments.
+var ErrNoURL = errors.New("item has no URL")
+
+// Domain returns the normalized host of the item's URL without a leading "www.",
+// such as "github.com", or an empty string if the item has no URL.
+func (i *Item) Domain() string {
+	return strings.TrimPrefix(urlHost(i.URL), "www.")
+}
+
+// ParsedURL parses the item's URL. It returns ErrNoURL if the item has none.
+func (i *Item) ParsedURL() (*url.URL, error) {
+	if i.URL == "" {
+		return nil, ErrNoURL
+	}
+
+	u, err := url.Parse(i.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL of item %d: %w", i.ID, err)
+	}
+	return u, nil
+}
+
+// WatchDomains returns a channel that receives newly created stories whose URL host
+// is one of the given domains or a subdomain of one, so "github.com" matches both
+// "github.com" and "gist.github.com" but not "notgithub.com". It is built on
+// WatchStories. The returned channel is closed when the context is canceled.
+func (c *Client) WatchDomains(ctx context.Context, domains ...string) (<-chan *Item, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	storiesCh, err := c.WatchStories(ctx, Filter{})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	matchedCh := make(chan *Item, cap(storiesCh))
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(matchedCh)
+
+		for item := range storiesCh {
+			if !urlMatchesDomains(item.URL, domains) {
+				continue
+			}
+
+			select {
+			case matchedCh <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return matchedCh, nil
+}
+
+// urlMatchesDomains reports whether the host of rawURL is one of domains or a subdomain of one.
+func urlMatchesDomains(rawURL string, domains []string) bool {
+	host := urlHost(rawURL)
+	if host == "" {
+		return false
+	}
+
+	for _, domain := range domains {
+		domain = normalizeHost(domain)
+		if domain == "" {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// urlHost returns the normalized host of rawURL, or an empty string if it has none.
+func urlHost(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return normalizeHost(u.Hostname())
+}
+
+// normalizeHost lowercases a host name and strips surrounding dots.
+func normalizeHost(host string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// DomainStat aggregates the stories of one domain.
+type DomainStat struct {
+	// Domain is the domain, as returned by Item.Domain.
+	Domain string
+
+	// Count is the number of stories linking to the domain.
+	Count int
+
+	// TotalScore is the sum of the stories' scores.
+	TotalScore int
+
+	// AverageScore is TotalScore divided by Count.
+	AverageScore float64
+}
+
+// DomainStats aggregates the story count, total score, and average score of each
+// domain among items, such as a hydrated story list. Items without a URL are
+// skipped. The report is sorted by count, then total score, both descending, and
+// then by domain.
+func DomainStats(items []*Item) []DomainStat {
+	byDomain := make(map[string]*DomainStat)
+	for _, item := range items {
+		domain := item.Domain()
+		if domain == "" {
+			continue
+		}
+
+		stat := byDomain[domain]
+		if stat == nil {
+			stat = &DomainStat{Domain: domain}
+			byDomain[domain] = stat
+		}
+		stat.Count++
+		stat.TotalScore += item.Score
+	}
+
+	stats := make([]DomainStat, 0, len(byDomain))
+	for _, stat := range byDomain {
+		stat.AverageScore = float64(stat.TotalScore) / float64(stat.Count)
+		stats = append(stats, *stat)
+	}
+
+	slices.SortFunc(stats, func(a, b DomainStat) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			cmp.Compare(b.TotalScore, a.TotalScore),
+			strings.Compare(a.Domain, b.Domain),
+		)
+	})
+
+	return stats
+}
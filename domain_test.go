@@ -0,0 +1,163 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestItemDomain(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://github.com/yarlson/hnapi", want: "github.com"},
+		{url: "https://WWW.Example.COM:8080/path", want: "example.com"},
+		{url: "http://www.bbc.co.uk/news", want: "bbc.co.uk"},
+		{url: "https://gist.github.com/abc", want: "gist.github.com"},
+		{url: "", want: ""},
+		{url: "://bad url", want: ""},
+	}
+
+	for _, tt := range tests {
+		item := &Item{URL: tt.url}
+		if got := item.Domain(); got != tt.want {
+			t.Errorf("Item{URL: %q}.Domain() = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestItemParsedURL(t *testing.T) {
+	u, err := (&Item{URL: "https://github.com/yarlson/hnapi?tab=readme"}).ParsedURL()
+	if err != nil {
+		t.Fatalf("ParsedURL() error = %v", err)
+	}
+	if u.Host != "github.com" || u.Path != "/yarlson/hnapi" {
+		t.Errorf("Unexpected URL %v", u)
+	}
+
+	if _, err := (&Item{Type: TypeStory, Title: "Ask HN: Anything?"}).ParsedURL(); !errors.Is(err, ErrNoURL) {
+		t.Errorf("Expected ErrNoURL, got %v", err)
+	}
+
+	if _, err := (&Item{URL: "://bad url"}).ParsedURL(); err == nil || errors.Is(err, ErrNoURL) {
+		t.Errorf("Expected a parse error, got %v", err)
+	}
+}
+
+func TestURLMatchesDomains(t *testing.T) {
+	domains := []string{"github.com", "ArXiv.org."}
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://github.com/yarlson/hnapi", want: true},
+		{url: "https://gist.github.com/abc", want: true},
+		{url: "https://WWW.GitHub.com:443/", want: true},
+		{url: "https://arxiv.org/abs/1234", want: true},
+		{url: "https://notgithub.com/", want: false},
+		{url: "https://github.com.evil.example/", want: false},
+		{url: "", want: false},
+		{url: "://bad url", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := urlMatchesDomains(tt.url, domains); got != tt.want {
+			t.Errorf("urlMatchesDomains(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestWatchDomains(t *testing.T) {
+	var maxItemCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/maxitem.json"):
+			if atomic.AddInt32(&maxItemCalls, 1) == 1 {
+				resp = "10"
+			} else {
+				resp = "12"
+			}
+		case strings.HasSuffix(r.URL.Path, "/item/11.json"):
+			resp = `{"id": 11, "type": "story", "url": "https://example.com/post"}`
+		case strings.HasSuffix(r.URL.Path, "/item/12.json"):
+			resp = `{"id": 12, "type": "story", "url": "https://blog.github.com/post"}`
+		default:
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(20*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	storiesCh, err := client.WatchDomains(ctx, "github.com")
+	if err != nil {
+		t.Fatalf("WatchDomains() error = %v", err)
+	}
+
+	select {
+	case item := <-storiesCh:
+		if item.ID != 12 {
+			t.Errorf("Expected story 12, got %d", item.ID)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for matching story")
+	}
+
+	cancel()
+	for range storiesCh {
+	}
+}
+
+func TestDomainStats(t *testing.T) {
+	items := []*Item{
+		{ID: 1, URL: "https://github.com/golang/go", Score: 100},
+		{ID: 2, URL: "https://www.github.com/yarlson/hnapi", Score: 50},
+		{ID: 3, URL: "https://example.com/a", Score: 300},
+		{ID: 4, Title: "Ask HN: Something?", Score: 1000},
+		{ID: 5, URL: "https://blog.example.com/b", Score: 10},
+		{ID: 6, URL: "https://go.dev/blog", Score: 300},
+	}
+
+	want := []DomainStat{
+		{Domain: "github.com", Count: 2, TotalScore: 150, AverageScore: 75},
+		{Domain: "example.com", Count: 1, TotalScore: 300, AverageScore: 300},
+		{Domain: "go.dev", Count: 1, TotalScore: 300, AverageScore: 300},
+		{Domain: "blog.example.com", Count: 1, TotalScore: 10, AverageScore: 10},
+	}
+
+	got := DomainStats(items)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d domains, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Domain %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	if stats := DomainStats(nil); len(stats) != 0 {
+		t.Errorf("Expected no domains, got %+v", stats)
+	}
+}
@@ -0,0 +1,70 @@
+package hnapi
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when the API responds with an empty body or JSON null,
+// which is how it reports items and users that do not exist (yet).
+var ErrNotFound = errors.New("item not found or null response")
+
+// ErrDeleted and ErrDead are returned for deleted and dead (flagged or killed)
+// items when tombstone errors are enabled with WithTombstoneErrors.
+var (
+	ErrDeleted = errors.New("item is deleted")
+	ErrDead    = errors.New("item is dead")
+)
+
+// tombstoneError returns ErrDeleted or ErrDead for a deleted or dead item, or nil.
+func tombstoneError(item *Item) error {
+	switch {
+	case item.Deleted:
+		return ErrDeleted
+	case item.Dead:
+		return ErrDead
+	default:
+		return nil
+	}
+}
+
+// ErrResponseTooLarge is returned when a response body exceeds the configured
+// MaxResponseSize.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// limitedBody reads from a response body and fails with ErrResponseTooLarge once
+// more than the allowed number of bytes has been read.
+type limitedBody struct {
+	r *io.LimitedReader
+}
+
+// newLimitedBody limits r to limit bytes. A limit of zero or less disables the limit.
+func newLimitedBody(r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	// Allow one extra byte so that a body of exactly limit bytes is accepted
+	return &limitedBody{r: &io.LimitedReader{R: r, N: limit + 1}}
+}
+
+// Read implements io.Reader.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if b.r.N <= 0 {
+		// Withhold the extra byte so callers never see more than the limit
+		if n > 0 {
+			n--
+		}
+		return n, ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// handleError passes an error from a background operation to the configured
+// ErrorHandler, if any.
+func (c *Client) handleError(err error) {
+	if err == nil || c.Config.ErrorHandler == nil {
+		return
+	}
+	c.Config.ErrorHandler(err)
+}
@@ -0,0 +1,118 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandleError(t *testing.T) {
+	var got []error
+	client := NewClient(WithErrorHandler(func(err error) {
+		got = append(got, err)
+	}))
+
+	testErr := errors.New("background failure")
+	client.handleError(testErr)
+	client.handleError(nil)
+
+	if len(got) != 1 || got[0] != testErr {
+		t.Errorf("Expected handler to receive exactly the non-nil error, got %v", got)
+	}
+
+	// A client without a handler must not panic
+	NewClient().handleError(testErr)
+}
+
+func TestErrorHandlerReceivesPollingErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	errCh := make(chan error, 10)
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(time.Hour),
+		WithMaxRetries(0),
+		WithErrorHandler(func(err error) {
+			errCh <- err
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatesCh, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			t.Errorf("Expected a non-nil polling error")
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for error handler to be called")
+	}
+
+	cancel()
+	for range updatesCh {
+	}
+}
+
+func TestTombstoneErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var body string
+		switch r.URL.Path {
+		case "/item/1.json":
+			body = `{"id": 1, "type": "comment", "deleted": true, "time": 1}`
+		case "/item/2.json":
+			body = `{"id": 2, "type": "comment", "dead": true, "text": "[flagged]"}`
+		case "/item/3.json":
+			body = `{"id": 3, "type": "comment", "text": "alive"}`
+		default:
+			body = `null`
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		id      int
+		wantErr error
+	}{
+		{name: "deleted is returned by default", id: 1},
+		{name: "dead is returned by default", id: 2},
+		{name: "deleted", opts: []Option{WithTombstoneErrors()}, id: 1, wantErr: ErrDeleted},
+		{name: "dead", opts: []Option{WithTombstoneErrors()}, id: 2, wantErr: ErrDead},
+		{name: "alive", opts: []Option{WithTombstoneErrors()}, id: 3},
+		{name: "missing", id: 4, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(append([]Option{WithBaseURL(server.URL + "/")}, tt.opts...)...)
+
+			item, err := client.GetItem(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetItem(%d) error = %v, want %v", tt.id, err, tt.wantErr)
+			}
+
+			// Tombstoned items are returned alongside their error
+			if tt.wantErr != ErrNotFound && (item == nil || item.ID != tt.id) {
+				t.Errorf("Expected item %d to be returned, got %v", tt.id, item)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package hnapi
+
+import "net/http"
+
+// conditional makes a request revalidate a cached response. It carries the ETag of
+// the cached response into the request and the outcome back out.
+type conditional struct {
+	// etag is sent as If-None-Match; empty asks for an ETag without revalidating
+	etag string
+
+	// newETag is the ETag of a fresh response
+	newETag string
+
+	// notModified reports that the server answered 304 and the target is untouched
+	notModified bool
+}
+
+// prepare asks for an ETag and, if there is a cached one, for a 304 when it still matches.
+func (cond *conditional) prepare(req *http.Request) {
+	// Firebase only returns ETags when asked to
+	req.Header.Set("X-Firebase-ETag", "true")
+	if cond.etag != "" {
+		req.Header.Set("If-None-Match", cond.etag)
+	}
+}
+
+// record captures the outcome of a conditional request. It reports whether the
+// response was a 304 that confirms the cached response.
+func (cond *conditional) record(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusNotModified && cond.etag != "" {
+		cond.notModified = true
+		return true
+	}
+	cond.notModified = false
+	cond.newETag = resp.Header.Get("ETag")
+	return false
+}
@@ -0,0 +1,90 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListCacheRevalidation(t *testing.T) {
+	var full, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Firebase-ETag") != "true" {
+			t.Errorf("Expected the ETag to be requested")
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	}))
+	defer server.Close()
+
+	ttl := 10 * time.Millisecond
+	client := NewClient(WithBaseURL(server.URL+"/"), WithListCache(ttl))
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		ids, err := client.GetTopStories(context.Background())
+		if err != nil {
+			t.Fatalf("GetTopStories() error = %v", err)
+		}
+		if want := []int{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("GetTopStories() = %v, want %v", ids, want)
+		}
+
+		// Expire the list so the next call revalidates it
+		time.Sleep(2 * ttl)
+	}
+
+	if got := full.Load(); got != 1 {
+		t.Errorf("Expected 1 full response, got %d", got)
+	}
+	if got := notModified.Load(); got != 1 {
+		t.Errorf("Expected 1 revalidation, got %d", got)
+	}
+	if got := client.Stats().NotModified; got != 1 {
+		t.Errorf("Expected Stats().NotModified = 1, got %d", got)
+	}
+}
+
+func TestUnconditionalRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("X-Firebase-ETag") != "" {
+			t.Errorf("Expected no conditional headers without a list cache")
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTopStories(context.Background()); err != nil {
+			t.Fatalf("GetTopStories() error = %v", err)
+		}
+	}
+}
+
+func TestNotModifiedWithoutETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	// A 304 for a request that did not revalidate anything is an error
+	client := NewClient(WithBaseURL(server.URL+"/"), WithListCache(time.Minute), WithMaxRetries(0))
+	defer client.Close()
+
+	if _, err := client.GetTopStories(context.Background()); err == nil {
+		t.Error("Expected an error for an unexpected 304")
+	}
+}
@@ -0,0 +1,92 @@
+package hnapi
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// failoverThreshold is the number of consecutive failed requests after which
+	// the client switches to the next base URL.
+	failoverThreshold = 3
+
+	// failoverRecovery is how long the client stays on a fallback URL before trying
+	// the primary again.
+	failoverRecovery = time.Minute
+)
+
+// failover tracks the health of the configured base URLs and selects the one
+// requests are sent to.
+type failover struct {
+	mu         sync.Mutex
+	urls       []string
+	active     int
+	failures   int
+	switchedAt time.Time
+	now        func() time.Time
+}
+
+// newFailover creates a failover over the primary base URL and its fallbacks.
+func newFailover(primary string, fallbacks []string) *failover {
+	urls := append([]string{primary}, fallbacks...)
+	return &failover{urls: urls, now: time.Now}
+}
+
+// current returns the base URL of config to send the next request to. After
+// failoverRecovery on a fallback, the primary is tried again. If the configured URLs
+// have changed since the last request, health tracking starts over.
+func (f *failover) current(config *Config) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.matches(config) {
+		f.urls = append([]string{config.BaseURL}, config.FallbackURLs...)
+		f.active = 0
+		f.failures = 0
+	}
+
+	if f.active != 0 && f.now().Sub(f.switchedAt) >= failoverRecovery {
+		f.active = 0
+		f.failures = 0
+	}
+	return f.urls[f.active]
+}
+
+// report records the outcome of a request sent to base and switches to the next
+// URL after failoverThreshold consecutive failures. Reports for a URL that is no
+// longer active are ignored.
+func (f *failover) report(base string, healthy bool, logger *slog.Logger) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.urls) < 2 || f.urls[f.active] != base {
+		return
+	}
+
+	if healthy {
+		f.failures = 0
+		return
+	}
+
+	f.failures++
+	if f.failures < failoverThreshold {
+		return
+	}
+
+	f.active = (f.active + 1) % len(f.urls)
+	f.failures = 0
+	f.switchedAt = f.now()
+	logger.Warn("failing over to another base URL", "from", base, "to", f.urls[f.active])
+}
+
+// matches reports whether f tracks the base URLs of config. The caller must hold f.mu.
+func (f *failover) matches(config *Config) bool {
+	return len(f.urls) > 0 && f.urls[0] == config.BaseURL && slices.Equal(f.urls[1:], config.FallbackURLs)
+}
+
+// sameBaseURLs reports whether two configurations use the same base URLs.
+func sameBaseURLs(a, b *Config) bool {
+	return a.BaseURL == b.BaseURL && slices.Equal(a.FallbackURLs, b.FallbackURLs)
+}
@@ -0,0 +1,82 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithBaseURLsFailover(t *testing.T) {
+	var primaryDown atomic.Bool
+	primaryDown.Store(true)
+
+	var primaryRequests, mirrorRequests atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryRequests.Add(1)
+		if primaryDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story", "title": "primary"}`))
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorRequests.Add(1)
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story", "title": "mirror"}`))
+	}))
+	defer mirror.Close()
+
+	client := NewClient(
+		WithBaseURLs(primary.URL, mirror.URL),
+		WithBackoffInterval(time.Millisecond),
+	)
+
+	now := time.Now()
+	client.failover.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Retries move to the mirror once the primary has failed often enough
+	item, err := client.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Title != "mirror" || primaryRequests.Load() != failoverThreshold {
+		t.Errorf("Expected the mirror after %d primary failures, got %q after %d", failoverThreshold, item.Title, primaryRequests.Load())
+	}
+
+	// Later requests stay on the mirror
+	if item, err := client.GetItem(ctx, 1); err != nil || item.Title != "mirror" {
+		t.Errorf("Expected the mirror to keep serving, got %v, %v", item, err)
+	}
+
+	// After the recovery period the primary is tried again
+	primaryDown.Store(false)
+	now = now.Add(failoverRecovery)
+
+	if item, err := client.GetItem(ctx, 1); err != nil || item.Title != "primary" {
+		t.Errorf("Expected recovery to the primary, got %v, %v", item, err)
+	}
+}
+
+func TestFailoverSingleURL(t *testing.T) {
+	f := newFailover("https://example.com/", nil)
+	config := &Config{BaseURL: "https://example.com/"}
+
+	for i := 0; i < failoverThreshold*2; i++ {
+		f.report(f.current(config), false, newDiscardLogger())
+	}
+	if got := f.current(config); got != "https://example.com/" {
+		t.Errorf("Expected the only URL to stay active, got %q", got)
+	}
+
+	// Changing the configured URL takes effect immediately
+	config.BaseURL = "https://mirror.example.com/"
+	if got := f.current(config); got != config.BaseURL {
+		t.Errorf("Expected the new base URL, got %q", got)
+	}
+}
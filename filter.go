@@ -0,0 +1,107 @@
+package hnapi
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Filter selects stories by keyword and regular expression predicates.
+// A story matches if any of the configured predicates matches; a Filter with no
+// predicates matches every story.
+type Filter struct {
+	// TitleContains matches stories whose title contains any of the keywords,
+	// compared case-insensitively.
+	TitleContains []string
+
+	// TextContains matches stories whose text contains any of the keywords,
+	// compared case-insensitively.
+	TextContains []string
+
+	// TitleRegexp matches stories whose title matches the expression.
+	TitleRegexp *regexp.Regexp
+
+	// TextRegexp matches stories whose text matches the expression.
+	TextRegexp *regexp.Regexp
+}
+
+// Match reports whether the item is a story accepted by the filter.
+func (f Filter) Match(item *Item) bool {
+	if !item.IsStory() {
+		return false
+	}
+
+	if f.isEmpty() {
+		return true
+	}
+
+	if containsAnyFold(item.Title, f.TitleContains) || containsAnyFold(item.Text, f.TextContains) {
+		return true
+	}
+	if f.TitleRegexp != nil && f.TitleRegexp.MatchString(item.Title) {
+		return true
+	}
+	if f.TextRegexp != nil && f.TextRegexp.MatchString(item.Text) {
+		return true
+	}
+
+	return false
+}
+
+// isEmpty reports whether the filter has no predicates.
+func (f Filter) isEmpty() bool {
+	return len(f.TitleContains) == 0 && len(f.TextContains) == 0 &&
+		f.TitleRegexp == nil && f.TextRegexp == nil
+}
+
+// WatchStories returns a channel that receives newly created stories accepted by
+// the filter. It is built on StartFirehose, so the same polling and retry behavior
+// applies. The returned channel is closed when the context is canceled.
+func (c *Client) WatchStories(ctx context.Context, filter Filter) (<-chan *Item, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsCh, err := c.StartFirehose(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	storiesCh := make(chan *Item, cap(itemsCh))
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(storiesCh)
+
+		for item := range itemsCh {
+			if !filter.Match(item) {
+				continue
+			}
+
+			select {
+			case storiesCh <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return storiesCh, nil
+}
+
+// containsAnyFold reports whether s contains any of the keywords, ignoring case.
+func containsAnyFold(s string, keywords []string) bool {
+	if s == "" {
+		return false
+	}
+
+	lower := strings.ToLower(s)
+	for _, keyword := range keywords {
+		if keyword != "" && strings.Contains(lower, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		item   *Item
+		want   bool
+	}{
+		{
+			name:   "empty filter matches any story",
+			filter: Filter{},
+			item:   &Item{Type: "story", Title: "Anything"},
+			want:   true,
+		},
+		{
+			name:   "comments never match",
+			filter: Filter{},
+			item:   &Item{Type: "comment", Text: "golang"},
+			want:   false,
+		},
+		{
+			name:   "nil item",
+			filter: Filter{},
+			item:   nil,
+			want:   false,
+		},
+		{
+			name:   "title keyword is case-insensitive",
+			filter: Filter{TitleContains: []string{"golang", "rust"}},
+			item:   &Item{Type: "story", Title: "Why I switched to Rust"},
+			want:   true,
+		},
+		{
+			name:   "title keyword misses",
+			filter: Filter{TitleContains: []string{"golang"}},
+			item:   &Item{Type: "story", Title: "Show HN: A Python tool"},
+			want:   false,
+		},
+		{
+			name:   "text keyword",
+			filter: Filter{TextContains: []string{"kubernetes"}},
+			item:   &Item{Type: "story", Title: "Ask HN: Infra?", Text: "We run Kubernetes"},
+			want:   true,
+		},
+		{
+			name:   "title regexp",
+			filter: Filter{TitleRegexp: regexp.MustCompile(`^Show HN:`)},
+			item:   &Item{Type: "story", Title: "Show HN: My project"},
+			want:   true,
+		},
+		{
+			name:   "text regexp misses",
+			filter: Filter{TextRegexp: regexp.MustCompile(`\bGo\b`)},
+			item:   &Item{Type: "story", Text: "Google"},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.item); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchStories(t *testing.T) {
+	var maxItemCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/maxitem.json"):
+			if atomic.AddInt32(&maxItemCalls, 1) == 1 {
+				resp = "10"
+			} else {
+				resp = "13"
+			}
+		case strings.HasSuffix(r.URL.Path, "/item/11.json"):
+			resp = `{"id": 11, "type": "story", "title": "Python 4 released"}`
+		case strings.HasSuffix(r.URL.Path, "/item/12.json"):
+			resp = `{"id": 12, "type": "comment", "text": "golang is great"}`
+		case strings.HasSuffix(r.URL.Path, "/item/13.json"):
+			resp = `{"id": 13, "type": "story", "title": "Golang 2 proposal"}`
+		default:
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(20*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	storiesCh, err := client.WatchStories(ctx, Filter{TitleContains: []string{"golang"}})
+	if err != nil {
+		t.Fatalf("WatchStories() error = %v", err)
+	}
+
+	select {
+	case item := <-storiesCh:
+		if item.ID != 13 {
+			t.Errorf("Expected story 13, got %d", item.ID)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for matching story")
+	}
+
+	cancel()
+	for range storiesCh {
+	}
+}
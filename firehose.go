@@ -0,0 +1,108 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// StartFirehose begins tracking the maxitem endpoint and returns a channel that
+// receives every item created after the call. Items found by one check are emitted
+// in ascending ID order; items that had to be retried arrive once they load.
+//
+// The maxitem endpoint is checked every PollInterval. Items that are not yet
+// available (the API briefly returns null for freshly allocated IDs) or fail to load
+// are retried on later checks, up to MaxRetries times, before being skipped and
+// reported to the ErrorHandler. The returned channel is closed when the context is
+// canceled.
+func (c *Client) StartFirehose(ctx context.Context) (<-chan *Item, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lastID, err := c.GetMaxItem(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start firehose: %w", err)
+	}
+
+	itemsCh := make(chan *Item, c.Config.Concurrency)
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(itemsCh)
+		c.runFirehose(ctx, lastID, itemsCh)
+	})
+
+	return itemsCh, nil
+}
+
+// runFirehose emits items with IDs above lastID until the context is canceled.
+func (c *Client) runFirehose(ctx context.Context, lastID int, itemsCh chan<- *Item) {
+	// attempts tracks IDs that have been tried before and are still pending
+	attempts := make(map[int]int)
+
+	ticker := c.clock().NewTicker(c.Config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		maxID, err := c.GetMaxItem(ctx)
+		if err != nil {
+			c.reportFirehoseError(ctx, err)
+			continue
+		}
+
+		// Pending IDs come first since they are lower than any new ID
+		ids := make([]int, 0, len(attempts)+maxID-lastID)
+		for id := range attempts {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for id := lastID + 1; id <= maxID; id++ {
+			ids = append(ids, id)
+		}
+		if maxID > lastID {
+			lastID = maxID
+		}
+
+		for _, result := range c.fetchItems(ctx, ids) {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if result.Error != nil {
+				attempts[result.ID]++
+				if attempts[result.ID] > c.Config.MaxRetries {
+					delete(attempts, result.ID)
+					c.reportFirehoseError(ctx, fmt.Errorf("skipping item %d: %w", result.ID, result.Error))
+				}
+				continue
+			}
+
+			delete(attempts, result.ID)
+
+			select {
+			case itemsCh <- result.Item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// reportFirehoseError logs and reports a firehose error unless the context is done.
+func (c *Client) reportFirehoseError(ctx context.Context, err error) {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	c.logger().Warn("firehose error", "error", err)
+	c.handleError(err)
+}
@@ -0,0 +1,168 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetMaxItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/maxitem.json") {
+			t.Errorf("Expected request path to end with /maxitem.json, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("8863"))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	maxID, err := client.GetMaxItem(context.Background())
+	if err != nil {
+		t.Fatalf("GetMaxItem() error = %v", err)
+	}
+	if maxID != 8863 {
+		t.Errorf("GetMaxItem() = %d, want 8863", maxID)
+	}
+}
+
+func TestStartFirehose(t *testing.T) {
+	var maxItemCalls int32
+	var item12Calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/maxitem.json"):
+			// The first call establishes the starting point
+			if atomic.AddInt32(&maxItemCalls, 1) == 1 {
+				resp = "10"
+			} else {
+				resp = "12"
+			}
+		case strings.HasSuffix(r.URL.Path, "/item/11.json"):
+			resp = `{"id": 11, "type": "story"}`
+		case strings.HasSuffix(r.URL.Path, "/item/12.json"):
+			// Item 12 is not available on the first attempt
+			if atomic.AddInt32(&item12Calls, 1) == 1 {
+				resp = "null"
+			} else {
+				resp = `{"id": 12, "type": "comment"}`
+			}
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(20*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	itemsCh, err := client.StartFirehose(ctx)
+	if err != nil {
+		t.Fatalf("StartFirehose() error = %v", err)
+	}
+
+	for _, wantID := range []int{11, 12} {
+		select {
+		case item := <-itemsCh:
+			if item.ID != wantID {
+				t.Errorf("Expected item %d, got %d", wantID, item.ID)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for item %d", wantID)
+		}
+	}
+
+	cancel()
+	for range itemsCh {
+	}
+}
+
+func TestStartFirehoseSkipsAfterRetries(t *testing.T) {
+	var maxItemCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		resp := "null"
+		if strings.HasSuffix(r.URL.Path, "/maxitem.json") {
+			if atomic.AddInt32(&maxItemCalls, 1) == 1 {
+				resp = "10"
+			} else {
+				resp = "11"
+			}
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	errCh := make(chan error, 10)
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(10*time.Millisecond),
+		WithMaxRetries(1),
+		WithErrorHandler(func(err error) {
+			errCh <- err
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	itemsCh, err := client.StartFirehose(ctx)
+	if err != nil {
+		t.Fatalf("StartFirehose() error = %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if !strings.Contains(err.Error(), "skipping item 11") {
+			t.Errorf("Expected item 11 to be skipped, got %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for skipped item error")
+	}
+
+	cancel()
+	for range itemsCh {
+	}
+}
+
+func TestStartFirehoseInitialError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+
+	if _, err := client.StartFirehose(context.Background()); err == nil {
+		t.Errorf("Expected error when maxitem cannot be fetched")
+	}
+}
@@ -0,0 +1,33 @@
+package hnapi
+
+// FlatComment is a comment of a flattened thread.
+type FlatComment struct {
+	// Item is the comment.
+	Item *Item
+
+	// Depth is the nesting level: 0 for replies to the root, 1 for their
+	// replies, and so on.
+	Depth int
+
+	// ParentIndex is the index of the parent comment in the flattened slice, or
+	// -1 for replies to the root.
+	ParentIndex int
+}
+
+// FlattenTree converts a comment tree into a slice of its comments in display
+// order: each comment is followed by its replies, depth first, the shape list-based
+// UIs need to render indented threads. The root of the tree is not included.
+func FlattenTree(tree *CommentNode) []FlatComment {
+	flat := []FlatComment{}
+
+	var walk func(node *CommentNode, depth, parent int)
+	walk = func(node *CommentNode, depth, parent int) {
+		for _, child := range node.Children {
+			flat = append(flat, FlatComment{Item: child.Item, Depth: depth, ParentIndex: parent})
+			walk(child, depth+1, len(flat)-1)
+		}
+	}
+	walk(tree, 0, -1)
+
+	return flat
+}
@@ -0,0 +1,43 @@
+package hnapi
+
+import "testing"
+
+func TestFlattenTree(t *testing.T) {
+	leaf := func(id int) *CommentNode { return &CommentNode{Item: &Item{ID: id}} }
+	tree := &CommentNode{
+		Item: &Item{ID: 1},
+		Children: []*CommentNode{
+			{Item: &Item{ID: 2}, Children: []*CommentNode{
+				{Item: &Item{ID: 4}, Children: []*CommentNode{leaf(6)}},
+				leaf(5),
+			}},
+			leaf(3),
+		},
+	}
+
+	want := []struct {
+		id, depth, parent int
+	}{
+		{id: 2, depth: 0, parent: -1},
+		{id: 4, depth: 1, parent: 0},
+		{id: 6, depth: 2, parent: 1},
+		{id: 5, depth: 1, parent: 0},
+		{id: 3, depth: 0, parent: -1},
+	}
+
+	flat := FlattenTree(tree)
+	if len(flat) != len(want) {
+		t.Fatalf("Expected %d comments, got %d", len(want), len(flat))
+	}
+	for i, w := range want {
+		got := flat[i]
+		if got.Item.ID != w.id || got.Depth != w.depth || got.ParentIndex != w.parent {
+			t.Errorf("Comment %d: expected id %d, depth %d, parent %d, got %d, %d, %d",
+				i, w.id, w.depth, w.parent, got.Item.ID, got.Depth, got.ParentIndex)
+		}
+	}
+
+	if flat := FlattenTree(leaf(1)); len(flat) != 0 {
+		t.Errorf("Expected no comments for a tree without replies, got %d", len(flat))
+	}
+}
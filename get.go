@@ -0,0 +1,34 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetInto fetches an arbitrary API endpoint, such as "item/8863.json" or a newer
+// endpoint the client has no method for, and decodes the response into target.
+// The endpoint is relative to the base URL. The request goes through the same
+// middleware, hooks, decoding limits, and tracing as the built-in methods.
+func (c *Client) GetInto(ctx context.Context, endpoint string, target interface{}) error {
+	ctx, end := c.startOperation(ctx, Operation{Name: "Get", Endpoint: endpoint})
+
+	err := c.makeRequest(ctx, endpoint, target, callOptions{})
+	end(err)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", endpoint, err)
+	}
+
+	return nil
+}
+
+// Get fetches an arbitrary API endpoint and decodes the response into a new T.
+// It is a typed wrapper around Client.GetInto:
+//
+//	ids, err := hnapi.Get[[]int](ctx, client, "topstories.json")
+func Get[T any](ctx context.Context, c *Client, endpoint string) (*T, error) {
+	var target T
+	if err := c.GetInto(ctx, endpoint, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
@@ -0,0 +1,63 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/topstories.json":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[3, 2, 1]`))
+		case "/item/8863.json":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 8863, "type": "story"}`))
+		case "/item/1.json":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`null`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	ctx := context.Background()
+
+	ids, err := Get[[]int](ctx, client, "topstories.json")
+	if err != nil {
+		t.Fatalf("Get[[]int]() error = %v", err)
+	}
+	if len(*ids) != 3 || (*ids)[0] != 3 {
+		t.Errorf("Expected [3 2 1], got %v", *ids)
+	}
+
+	item, err := Get[Item](ctx, client, "item/8863.json")
+	if err != nil {
+		t.Fatalf("Get[Item]() error = %v", err)
+	}
+	if item.ID != 8863 {
+		t.Errorf("Expected item 8863, got %d", item.ID)
+	}
+
+	if _, err := Get[Item](ctx, client, "item/1.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := Get[Item](ctx, client, "missing.json"); err == nil {
+		t.Errorf("Expected error for missing endpoint")
+	}
+
+	var raw map[string]interface{}
+	if err := client.GetInto(ctx, "item/8863.json", &raw); err != nil {
+		t.Fatalf("GetInto() error = %v", err)
+	}
+	if raw["type"] != "story" {
+		t.Errorf("Expected type story, got %v", raw["type"])
+	}
+}
@@ -1 +1,3 @@
 module github.com/yarlson/hnapi
+
+go 1.23
@@ -16,9 +16,37 @@ const Version = "0.1.0"
 type Client struct {
 	// Config contains the client configuration
 	Config *Config
+
+	// lifecycle tracks background goroutines for Close
+	lifecycle *lifecycle
+
+	// metrics holds runtime counters
+	metrics *metrics
+
+	// failover selects the base URL requests are sent to
+	failover *failover
+
+	// lists caches story lists; nil unless ListCacheTTL or a Lists policy is set
+	lists *listCache
+
+	// responses caches item, user, and updates responses; nil unless the
+	// CachePolicy enables one of them
+	responses *responseCache
+
+	// scheduler hands out transport slots by priority; nil unless MaxInFlight is set
+	scheduler *scheduler
+
+	// retryBudget caps retries across requests; nil unless RetryBudget is set
+	retryBudget *retryBudget
+
+	// defaultHTTP reports whether Config.HTTPClient was created by the client
+	defaultHTTP bool
 }
 
 // NewClient creates a new Hacker News API client with the provided options.
+// Invalid values, such as a negative Concurrency or a zero PollInterval, are
+// replaced with their defaults and logged as warnings; use NewClientE to get an
+// error instead. A missing trailing slash on the BaseURL is added.
 func NewClient(opts ...Option) *Client {
 	config := DefaultConfig()
 
@@ -26,9 +54,139 @@ func NewClient(opts ...Option) *Client {
 	for _, opt := range opts {
 		opt(config)
 	}
+	config.repair()
+
+	return newClient(config)
+}
+
+// newClient creates a client from a complete configuration.
+func newClient(config *Config) *Client {
+	defaultHTTP := config.HTTPClient == nil
+	if defaultHTTP {
+		config.HTTPClient = newDefaultHTTPClient(config)
+	}
+
+	client := &Client{
+		Config:      config,
+		lifecycle:   newLifecycle(),
+		metrics:     &metrics{},
+		failover:    newFailover(config.BaseURL, config.FallbackURLs),
+		lists:       newListCache(config.listCacheTTL()),
+		responses:   newResponseCache(config.CachePolicy),
+		scheduler:   newScheduler(config.MaxInFlight),
+		retryBudget: newRetryBudget(config.RetryBudget),
+		defaultHTTP: defaultHTTP,
+	}
+
+	if config.ExpvarPrefix != "" && !client.metrics.publishExpvar(config.ExpvarPrefix) {
+		client.logger().Warn("expvar name already in use, counters not published", "name", config.ExpvarPrefix)
+	}
+
+	return client
+}
+
+// With returns a copy of the client with the given options applied on top of its
+// configuration. The copy shares the original's HTTP client and connection pool
+// (unless overridden or retuned with the connection pool options), statistics, and
+// background lifecycle, so closing either client stops the background work of
+// both. Deriving a client is cheap, which makes it suitable for per-profile
+// settings such as a higher Concurrency for batch jobs.
+func (c *Client) With(opts ...Option) *Client {
+	config := *c.Config
+
+	// Options append to these, so the copy must not share their backing storage
+	config.Middleware = append([]Middleware(nil), c.Config.Middleware...)
+	config.Hooks = append([]Hooks(nil), c.Config.Hooks...)
+	config.DefaultHeaders = c.Config.DefaultHeaders.Clone()
+	config.FallbackURLs = append([]string(nil), c.Config.FallbackURLs...)
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.repair()
+
+	if config.HTTPClient == nil {
+		config.HTTPClient = c.Config.HTTPClient
+	}
+
+	// A copy that tunes the default connection pool differently gets its own pool
+	defaultHTTP := c.defaultHTTP && config.HTTPClient == c.Config.HTTPClient
+	if defaultHTTP && !samePool(&config, c.Config) {
+		config.HTTPClient = newDefaultHTTPClient(&config)
+	}
+
+	// Base URL health is only shared while the copy talks to the same servers
+	failover := c.failover
+	if !sameBaseURLs(&config, c.Config) {
+		failover = newFailover(config.BaseURL, config.FallbackURLs)
+	}
+
+	// Cached lists are only shared while the copy would fetch the same lists
+	lists := c.lists
+	if config.listCacheTTL() != c.Config.listCacheTTL() || !sameBaseURLs(&config, c.Config) {
+		lists = newListCache(config.listCacheTTL())
+	}
+
+	// Cached responses likewise, and only under the same policy
+	responses := c.responses
+	if config.CachePolicy != c.Config.CachePolicy || !sameBaseURLs(&config, c.Config) {
+		responses = newResponseCache(config.CachePolicy)
+	}
+
+	// Copies with the same limit share its slots, so the limit holds across them
+	scheduler := c.scheduler
+	if config.MaxInFlight != c.Config.MaxInFlight {
+		scheduler = newScheduler(config.MaxInFlight)
+	}
+
+	// Copies with the same budget draw from it together
+	retryBudget := c.retryBudget
+	if config.RetryBudget != c.Config.RetryBudget {
+		retryBudget = newRetryBudget(config.RetryBudget)
+	}
 
 	return &Client{
-		Config: config,
+		Config:      &config,
+		lifecycle:   c.lifecycle,
+		metrics:     c.metrics,
+		failover:    failover,
+		lists:       lists,
+		responses:   responses,
+		scheduler:   scheduler,
+		retryBudget: retryBudget,
+		defaultHTTP: defaultHTTP,
+	}
+}
+
+// Capabilities describes which optional subsystems are available on a client.
+// Frameworks embedding hnapi can use it to feature-detect at runtime.
+type Capabilities struct {
+	// Version is the version of the hnapi package.
+	Version string
+
+	// Cache reports whether response caching is configured.
+	Cache bool
+
+	// Search reports whether search over fetched data, such as SearchTree, is
+	// available. It needs no configuration, so it is always true.
+	Search bool
+
+	// SSE reports whether updates are streamed over Server-Sent Events.
+	SSE bool
+
+	// Store reports whether a persistent store is configured.
+	Store bool
+}
+
+// Capabilities reports the package version and the optional subsystems that are
+// compiled in and configured for this client.
+func (c *Client) Capabilities() Capabilities {
+	return Capabilities{
+		Version: Version,
+		Cache:   c.lists != nil || c.responses != nil,
+		Search:  true,
+		SSE:     c.Config.UpdatesMode == UpdatesModeStream,
+		Store:   c.Config.Offline != nil,
 	}
 }
 
@@ -1,7 +1,11 @@
 package hnapi
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestHelloHackerNews(t *testing.T) {
@@ -12,3 +16,63 @@ func TestHelloHackerNews(t *testing.T) {
 		t.Errorf("HelloHackerNews() = %q, want %q", actual, expected)
 	}
 }
+
+func TestCapabilities(t *testing.T) {
+	client := NewClient()
+	caps := client.Capabilities()
+
+	if caps.Version != Version {
+		t.Errorf("Capabilities().Version = %q, want %q", caps.Version, Version)
+	}
+
+	if caps.Cache || caps.SSE || caps.Store {
+		t.Errorf("Expected no configured subsystems on a default client, got %+v", caps)
+	}
+	if !caps.Search {
+		t.Error("Expected Capabilities().Search to be true")
+	}
+}
+
+func TestClientWith(t *testing.T) {
+	base := NewClient(
+		WithConcurrency(5),
+		WithDefaultHeaders(http.Header{"X-Base": {"1"}}),
+		WithHooks(Hooks{}),
+	)
+
+	derived := base.With(
+		WithConcurrency(50),
+		WithPollInterval(time.Second),
+		WithDefaultHeaders(http.Header{"X-Derived": {"1"}}),
+		WithHooks(Hooks{}),
+	)
+
+	if derived.Config.Concurrency != 50 || derived.Config.PollInterval != time.Second {
+		t.Errorf("Expected overridden options on the copy, got %+v", derived.Config)
+	}
+	if base.Config.Concurrency != 5 || base.Config.PollInterval == time.Second {
+		t.Errorf("Expected the original config to be unchanged, got %+v", base.Config)
+	}
+
+	if derived.Config.HTTPClient != base.Config.HTTPClient {
+		t.Errorf("Expected the copy to share the HTTP client")
+	}
+	if derived.metrics != base.metrics || derived.lifecycle != base.lifecycle {
+		t.Errorf("Expected the copy to share metrics and lifecycle")
+	}
+
+	if base.Config.DefaultHeaders.Get("X-Derived") != "" || len(base.Config.Hooks) != 1 {
+		t.Errorf("Expected appending options not to leak into the original")
+	}
+	if derived.Config.DefaultHeaders.Get("X-Base") != "1" || len(derived.Config.Hooks) != 2 {
+		t.Errorf("Expected the copy to extend the original's headers and hooks")
+	}
+
+	// Closing the copy stops background work of the original too
+	if err := derived.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := base.StartUpdates(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed from the original, got %v", err)
+	}
+}
@@ -0,0 +1,364 @@
+// Package hnapiarchive persists Hacker News items, users, and list snapshots to
+// SQLite, for durable local mirrors.
+//
+// It is a separate module, github.com/yarlson/hnapi/hnapiarchive, so the SQLite
+// driver is only pulled into builds that use it. Records are upserted, so storing an item again replaces the archived
+// version with the newer one:
+//
+//	archive, err := hnapiarchive.Open("hn.db")
+//	if err != nil {
+//		return err
+//	}
+//	defer archive.Close()
+//
+//	items, err := client.StartFirehose(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	return archive.ArchiveItems(ctx, items)
+package hnapiarchive
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yarlson/hnapi"
+
+	// Register the pure Go SQLite driver
+	_ "modernc.org/sqlite"
+)
+
+// schema creates the archive tables. Item and user ID lists are stored as JSON arrays.
+const schema = `
+CREATE TABLE IF NOT EXISTS items (
+	id          INTEGER PRIMARY KEY,
+	type        TEXT NOT NULL DEFAULT '',
+	by          TEXT NOT NULL DEFAULT '',
+	time        INTEGER NOT NULL DEFAULT 0,
+	text        TEXT NOT NULL DEFAULT '',
+	title       TEXT NOT NULL DEFAULT '',
+	url         TEXT NOT NULL DEFAULT '',
+	score       INTEGER NOT NULL DEFAULT 0,
+	descendants INTEGER NOT NULL DEFAULT 0,
+	parent      INTEGER NOT NULL DEFAULT 0,
+	poll        INTEGER NOT NULL DEFAULT 0,
+	kids        TEXT NOT NULL DEFAULT '[]',
+	parts       TEXT NOT NULL DEFAULT '[]',
+	deleted     INTEGER NOT NULL DEFAULT 0,
+	dead        INTEGER NOT NULL DEFAULT 0,
+	archived_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS items_by ON items (by);
+CREATE INDEX IF NOT EXISTS items_parent ON items (parent);
+
+CREATE TABLE IF NOT EXISTS users (
+	id          TEXT PRIMARY KEY,
+	created     INTEGER NOT NULL DEFAULT 0,
+	karma       INTEGER NOT NULL DEFAULT 0,
+	about       TEXT NOT NULL DEFAULT '',
+	submitted   TEXT NOT NULL DEFAULT '[]',
+	archived_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS list_snapshots (
+	list     TEXT NOT NULL,
+	taken_at INTEGER NOT NULL,
+	ids      TEXT NOT NULL,
+	PRIMARY KEY (list, taken_at)
+);
+
+CREATE TABLE IF NOT EXISTS checkpoints (
+	key TEXT PRIMARY KEY,
+	id  INTEGER NOT NULL
+);
+`
+
+// Archive stores Hacker News data in a SQLite database.
+// All methods are safe for concurrent use.
+type Archive struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Open opens or creates the SQLite archive at path.
+func Open(path string) (*Archive, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	// SQLite allows one writer at a time; a single connection avoids busy errors
+	db.SetMaxOpenConns(1)
+
+	archive, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return archive, nil
+}
+
+// New creates an archive in an already open SQLite database, creating the tables
+// if needed.
+func New(db *sql.DB) (*Archive, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create archive schema: %w", err)
+	}
+	return &Archive{db: db, now: time.Now}, nil
+}
+
+// Close closes the database.
+func (a *Archive) Close() error {
+	return a.db.Close()
+}
+
+// StoreItems inserts or replaces items in one transaction. Nil items are skipped.
+func (a *Archive) StoreItems(ctx context.Context, items ...*hnapi.Item) error {
+	return a.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO items (id, type, by, time, text, title, url, score, descendants, parent, poll, kids, parts, deleted, dead, archived_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				type = excluded.type, by = excluded.by, time = excluded.time, text = excluded.text,
+				title = excluded.title, url = excluded.url, score = excluded.score,
+				descendants = excluded.descendants, parent = excluded.parent, poll = excluded.poll,
+				kids = excluded.kids, parts = excluded.parts, deleted = excluded.deleted,
+				dead = excluded.dead, archived_at = excluded.archived_at`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		archivedAt := a.now().Unix()
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+			_, err := stmt.ExecContext(ctx, item.ID, string(item.Type), item.By, item.Time, item.Text,
+				item.Title, item.URL, item.Score, item.Descendants, item.Parent, item.Poll,
+				encodeIDs(item.Kids), encodeIDs(item.Parts), item.Deleted, item.Dead, archivedAt)
+			if err != nil {
+				return fmt.Errorf("item %d: %w", item.ID, err)
+			}
+		}
+		return nil
+	}, "failed to store items")
+}
+
+// StoreUsers inserts or replaces users in one transaction. Nil users are skipped.
+func (a *Archive) StoreUsers(ctx context.Context, users ...*hnapi.User) error {
+	return a.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO users (id, created, karma, about, submitted, archived_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				created = excluded.created, karma = excluded.karma, about = excluded.about,
+				submitted = excluded.submitted, archived_at = excluded.archived_at`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		archivedAt := a.now().Unix()
+		for _, user := range users {
+			if user == nil {
+				continue
+			}
+			_, err := stmt.ExecContext(ctx, user.ID, user.Created, user.Karma, user.About, encodeIDs(user.Submitted), archivedAt)
+			if err != nil {
+				return fmt.Errorf("user %s: %w", user.ID, err)
+			}
+		}
+		return nil
+	}, "failed to store users")
+}
+
+// StoreList records a snapshot of a list's story IDs taken at the given time.
+// Storing another snapshot with the same time replaces it.
+func (a *Archive) StoreList(ctx context.Context, list hnapi.List, ids []int, takenAt time.Time) error {
+	_, err := a.db.ExecContext(ctx, `
+		INSERT INTO list_snapshots (list, taken_at, ids) VALUES (?, ?, ?)
+		ON CONFLICT (list, taken_at) DO UPDATE SET ids = excluded.ids`,
+		list.String(), takenAt.Unix(), encodeIDs(ids))
+	if err != nil {
+		return fmt.Errorf("failed to store %s list: %w", list, err)
+	}
+	return nil
+}
+
+// Item returns an archived item. Items that are not archived yield an error
+// wrapping hnapi.ErrNotFound.
+func (a *Archive) Item(ctx context.Context, id int) (*hnapi.Item, error) {
+	var item hnapi.Item
+	var itemType, kids, parts string
+	err := a.db.QueryRowContext(ctx, `
+		SELECT id, type, by, time, text, title, url, score, descendants, parent, poll, kids, parts, deleted, dead
+		FROM items WHERE id = ?`, id).Scan(
+		&item.ID, &itemType, &item.By, &item.Time, &item.Text, &item.Title, &item.URL, &item.Score,
+		&item.Descendants, &item.Parent, &item.Poll, &kids, &parts, &item.Deleted, &item.Dead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load item %d: %w", id, notFound(err))
+	}
+
+	item.Type = hnapi.ItemType(itemType)
+	if item.Kids, err = decodeIDs(kids); err != nil {
+		return nil, fmt.Errorf("failed to load item %d: %w", id, err)
+	}
+	if item.Parts, err = decodeIDs(parts); err != nil {
+		return nil, fmt.Errorf("failed to load item %d: %w", id, err)
+	}
+
+	return &item, nil
+}
+
+// User returns an archived user. Users that are not archived yield an error
+// wrapping hnapi.ErrNotFound.
+func (a *Archive) User(ctx context.Context, username string) (*hnapi.User, error) {
+	var user hnapi.User
+	var submitted string
+	err := a.db.QueryRowContext(ctx, `
+		SELECT id, created, karma, about, submitted FROM users WHERE id = ?`, username).Scan(
+		&user.ID, &user.Created, &user.Karma, &user.About, &submitted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user %s: %w", username, notFound(err))
+	}
+
+	if user.Submitted, err = decodeIDs(submitted); err != nil {
+		return nil, fmt.Errorf("failed to load user %s: %w", username, err)
+	}
+
+	return &user, nil
+}
+
+// LatestList returns the most recent snapshot of a list and when it was taken.
+// Lists without snapshots yield an error wrapping hnapi.ErrNotFound.
+func (a *Archive) LatestList(ctx context.Context, list hnapi.List) ([]int, time.Time, error) {
+	var takenAt int64
+	var encoded string
+	err := a.db.QueryRowContext(ctx, `
+		SELECT taken_at, ids FROM list_snapshots WHERE list = ?
+		ORDER BY taken_at DESC LIMIT 1`, list.String()).Scan(&takenAt, &encoded)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to load %s list: %w", list, notFound(err))
+	}
+
+	ids, err := decodeIDs(encoded)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to load %s list: %w", list, err)
+	}
+
+	return ids, time.Unix(takenAt, 0), nil
+}
+
+// ArchiveItems stores every item received from items, such as the channel of
+// StartFirehose or StartItemUpdates. It returns nil when the channel is closed, or
+// the first storage error.
+func (a *Archive) ArchiveItems(ctx context.Context, items <-chan *hnapi.Item) error {
+	for item := range items {
+		if err := a.StoreItems(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArchiveUsers stores every user received from users, such as the channel of
+// StartProfileUpdates. It returns nil when the channel is closed, or the first
+// storage error.
+func (a *Archive) ArchiveUsers(ctx context.Context, users <-chan *hnapi.User) error {
+	for user := range users {
+		if err := a.StoreUsers(ctx, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ArchiveLists stores a snapshot for every update received from updates, such as
+// the channel of StartListUpdates. It returns nil when the channel is closed, or
+// the first storage error.
+func (a *Archive) ArchiveLists(ctx context.Context, updates <-chan hnapi.ListUpdate) error {
+	for update := range updates {
+		if err := a.StoreList(ctx, update.List, update.IDs, a.now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCheckpoint returns the ID saved under key, or 0 if there is none. Together
+// with SaveCheckpoint it makes the archive an hnapi.CheckpointStore, so a crawl can
+// keep its progress in the same database as the items it archives.
+func (a *Archive) LoadCheckpoint(ctx context.Context, key string) (int, error) {
+	var id int
+	err := a.db.QueryRowContext(ctx, `SELECT id FROM checkpoints WHERE key = ?`, key).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load checkpoint %s: %w", key, err)
+	}
+	return id, nil
+}
+
+// SaveCheckpoint saves id under key, replacing any previous value.
+func (a *Archive) SaveCheckpoint(ctx context.Context, key string, id int) error {
+	_, err := a.db.ExecContext(ctx, `
+		INSERT INTO checkpoints (key, id) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET id = excluded.id`, key, id)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint %s: %w", key, err)
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction, committing if it succeeds.
+func (a *Archive) inTx(ctx context.Context, fn func(*sql.Tx) error, errContext string) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errContext, err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("%s: %w", errContext, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", errContext, err)
+	}
+	return nil
+}
+
+// notFound translates a missing row into hnapi.ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return hnapi.ErrNotFound
+	}
+	return err
+}
+
+// encodeIDs encodes IDs as a JSON array.
+func encodeIDs(ids []int) string {
+	if len(ids) == 0 {
+		return "[]"
+	}
+	encoded, _ := json.Marshal(ids)
+	return string(encoded)
+}
+
+// decodeIDs decodes a JSON array of IDs, returning nil for an empty array.
+func decodeIDs(encoded string) ([]int, error) {
+	var ids []int
+	if err := json.Unmarshal([]byte(encoded), &ids); err != nil {
+		return nil, fmt.Errorf("failed to decode IDs: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return ids, nil
+}
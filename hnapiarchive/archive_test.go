@@ -0,0 +1,214 @@
+package hnapiarchive
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+func openTestArchive(t *testing.T) *Archive {
+	t.Helper()
+
+	archive, err := Open(filepath.Join(t.TempDir(), "hn.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { archive.Close() })
+	return archive
+}
+
+func TestStoreItems(t *testing.T) {
+	archive := openTestArchive(t)
+	ctx := context.Background()
+
+	story := &hnapi.Item{ID: 1, Type: hnapi.TypeStory, By: "alice", Time: 1700000000, Title: "Hello", URL: "https://example.com", Score: 5, Kids: []int{2, 3}}
+	if err := archive.StoreItems(ctx, story, nil, &hnapi.Item{ID: 2, Type: hnapi.TypeComment, Parent: 1, Deleted: true}); err != nil {
+		t.Fatalf("StoreItems() error = %v", err)
+	}
+
+	got, err := archive.Item(ctx, 1)
+	if err != nil {
+		t.Fatalf("Item() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, story) {
+		t.Errorf("Item() = %+v, want %+v", got, story)
+	}
+
+	// Storing again replaces the archived version
+	updated := *story
+	updated.Score = 50
+	updated.Kids = nil
+	if err := archive.StoreItems(ctx, &updated); err != nil {
+		t.Fatalf("StoreItems() error = %v", err)
+	}
+	if got, _ := archive.Item(ctx, 1); got.Score != 50 || got.Kids != nil {
+		t.Errorf("Expected the item to be updated, got %+v", got)
+	}
+
+	if got, _ := archive.Item(ctx, 2); got == nil || !got.Deleted {
+		t.Errorf("Expected the deleted flag to be archived, got %+v", got)
+	}
+
+	if _, err := archive.Item(ctx, 99); !errors.Is(err, hnapi.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing item, got %v", err)
+	}
+}
+
+func TestStoreUsers(t *testing.T) {
+	archive := openTestArchive(t)
+	ctx := context.Background()
+
+	user := &hnapi.User{ID: "pg", Created: 1160418092, Karma: 155111, About: "Bug fixer.", Submitted: []int{1, 2}}
+	if err := archive.StoreUsers(ctx, user); err != nil {
+		t.Fatalf("StoreUsers() error = %v", err)
+	}
+
+	got, err := archive.User(ctx, "pg")
+	if err != nil {
+		t.Fatalf("User() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, user) {
+		t.Errorf("User() = %+v, want %+v", got, user)
+	}
+
+	if _, err := archive.User(ctx, "nobody"); !errors.Is(err, hnapi.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing user, got %v", err)
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	archive := openTestArchive(t)
+	ctx := context.Background()
+
+	if _, _, err := archive.LatestList(ctx, hnapi.ListTop); !errors.Is(err, hnapi.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound before any snapshot, got %v", err)
+	}
+
+	first := time.Unix(1000, 0)
+	if err := archive.StoreList(ctx, hnapi.ListTop, []int{1, 2}, first); err != nil {
+		t.Fatalf("StoreList() error = %v", err)
+	}
+	if err := archive.StoreList(ctx, hnapi.ListTop, []int{2, 3}, first.Add(time.Minute)); err != nil {
+		t.Fatalf("StoreList() error = %v", err)
+	}
+	if err := archive.StoreList(ctx, hnapi.ListNew, []int{9}, first.Add(time.Hour)); err != nil {
+		t.Fatalf("StoreList() error = %v", err)
+	}
+
+	ids, takenAt, err := archive.LatestList(ctx, hnapi.ListTop)
+	if err != nil {
+		t.Fatalf("LatestList() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{2, 3}) || !takenAt.Equal(first.Add(time.Minute)) {
+		t.Errorf("LatestList() = %v at %v, want [2 3] at %v", ids, takenAt, first.Add(time.Minute))
+	}
+}
+
+func TestArchiveStreams(t *testing.T) {
+	archive := openTestArchive(t)
+	ctx := context.Background()
+
+	items := make(chan *hnapi.Item, 2)
+	items <- &hnapi.Item{ID: 1}
+	items <- &hnapi.Item{ID: 2}
+	close(items)
+	if err := archive.ArchiveItems(ctx, items); err != nil {
+		t.Fatalf("ArchiveItems() error = %v", err)
+	}
+
+	users := make(chan *hnapi.User, 1)
+	users <- &hnapi.User{ID: "pg"}
+	close(users)
+	if err := archive.ArchiveUsers(ctx, users); err != nil {
+		t.Fatalf("ArchiveUsers() error = %v", err)
+	}
+
+	updates := make(chan hnapi.ListUpdate, 1)
+	updates <- hnapi.ListUpdate{List: hnapi.ListBest, IDs: []int{1}}
+	close(updates)
+	if err := archive.ArchiveLists(ctx, updates); err != nil {
+		t.Fatalf("ArchiveLists() error = %v", err)
+	}
+
+	for _, id := range []int{1, 2} {
+		if _, err := archive.Item(ctx, id); err != nil {
+			t.Errorf("Expected item %d to be archived, got %v", id, err)
+		}
+	}
+	if _, err := archive.User(ctx, "pg"); err != nil {
+		t.Errorf("Expected user to be archived, got %v", err)
+	}
+	if ids, _, err := archive.LatestList(ctx, hnapi.ListBest); err != nil || !reflect.DeepEqual(ids, []int{1}) {
+		t.Errorf("Expected list snapshot to be archived, got %v, %v", ids, err)
+	}
+}
+
+func TestCheckpoints(t *testing.T) {
+	archive := openTestArchive(t)
+	ctx := context.Background()
+
+	var _ hnapi.CheckpointStore = archive
+
+	if id, err := archive.LoadCheckpoint(ctx, "crawl"); err != nil || id != 0 {
+		t.Fatalf("LoadCheckpoint() = %d, %v, want 0 before saving", id, err)
+	}
+	for _, id := range []int{10, 20} {
+		if err := archive.SaveCheckpoint(ctx, "crawl", id); err != nil {
+			t.Fatalf("SaveCheckpoint() error = %v", err)
+		}
+	}
+	if id, err := archive.LoadCheckpoint(ctx, "crawl"); err != nil || id != 20 {
+		t.Errorf("LoadCheckpoint() = %d, %v, want 20", id, err)
+	}
+}
+
+func TestOfflineClient(t *testing.T) {
+	archive := openTestArchive(t)
+	ctx := context.Background()
+
+	if err := archive.StoreItems(ctx, &hnapi.Item{ID: 1, Type: hnapi.TypeStory, Title: "Mirrored"}); err != nil {
+		t.Fatalf("StoreItems() error = %v", err)
+	}
+	if err := archive.StoreList(ctx, hnapi.ListTop, []int{1}, time.Now()); err != nil {
+		t.Fatalf("StoreList() error = %v", err)
+	}
+
+	client := hnapi.NewClient(hnapi.WithOffline(archive), hnapi.WithBaseURL("http://127.0.0.1:1/"))
+
+	items, err := client.GetListItems(ctx, hnapi.ListTop, 0)
+	if err != nil {
+		t.Fatalf("GetListItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Title != "Mirrored" {
+		t.Errorf("Expected the archived story, got %v", items)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hn.db")
+	ctx := context.Background()
+
+	archive, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := archive.StoreItems(ctx, &hnapi.Item{ID: 7, Title: "Kept"}); err != nil {
+		t.Fatalf("StoreItems() error = %v", err)
+	}
+	archive.Close()
+
+	archive, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() again error = %v", err)
+	}
+	defer archive.Close()
+
+	if item, err := archive.Item(ctx, 7); err != nil || item.Title != "Kept" {
+		t.Errorf("Expected the item to survive reopening, got %v, %v", item, err)
+	}
+}
@@ -0,0 +1,200 @@
+package hnapiexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Column is a named CSV column that extracts its value from a record.
+type Column[T any] struct {
+	// Name is the column header.
+	Name string
+
+	// Value formats the column value of a record.
+	Value func(T) string
+}
+
+// ItemColumn is a CSV column of items.
+type ItemColumn = Column[*hnapi.Item]
+
+// UserColumn is a CSV column of users.
+type UserColumn = Column[*hnapi.User]
+
+// ItemColumns are the predefined item columns, in their default order. Times are
+// formatted as RFC 3339 in UTC, text fields are HTML as returned by the API, and
+// ID lists are separated by spaces.
+var ItemColumns = []ItemColumn{
+	{Name: "id", Value: func(i *hnapi.Item) string { return strconv.Itoa(i.ID) }},
+	{Name: "type", Value: func(i *hnapi.Item) string { return string(i.Type) }},
+	{Name: "by", Value: func(i *hnapi.Item) string { return i.By }},
+	{Name: "time", Value: func(i *hnapi.Item) string { return formatTime(i.Time) }},
+	{Name: "title", Value: func(i *hnapi.Item) string { return i.Title }},
+	{Name: "url", Value: func(i *hnapi.Item) string { return i.URL }},
+	{Name: "domain", Value: func(i *hnapi.Item) string { return i.Domain() }},
+	{Name: "score", Value: func(i *hnapi.Item) string { return strconv.Itoa(i.Score) }},
+	{Name: "descendants", Value: func(i *hnapi.Item) string { return strconv.Itoa(i.Descendants) }},
+	{Name: "parent", Value: func(i *hnapi.Item) string { return formatID(i.Parent) }},
+	{Name: "poll", Value: func(i *hnapi.Item) string { return formatID(i.Poll) }},
+	{Name: "kids", Value: func(i *hnapi.Item) string { return formatIDs(i.Kids) }},
+	{Name: "parts", Value: func(i *hnapi.Item) string { return formatIDs(i.Parts) }},
+	{Name: "text", Value: func(i *hnapi.Item) string { return i.Text }},
+	{Name: "plain_text", Value: func(i *hnapi.Item) string { return i.PlainText() }},
+	{Name: "deleted", Value: func(i *hnapi.Item) string { return strconv.FormatBool(i.Deleted) }},
+	{Name: "dead", Value: func(i *hnapi.Item) string { return strconv.FormatBool(i.Dead) }},
+}
+
+// UserColumns are the predefined user columns, in their default order.
+var UserColumns = []UserColumn{
+	{Name: "id", Value: func(u *hnapi.User) string { return u.ID }},
+	{Name: "created", Value: func(u *hnapi.User) string { return formatTime(u.Created) }},
+	{Name: "karma", Value: func(u *hnapi.User) string { return strconv.Itoa(u.Karma) }},
+	{Name: "about", Value: func(u *hnapi.User) string { return u.About }},
+	{Name: "plain_about", Value: func(u *hnapi.User) string { return u.PlainAbout() }},
+	{Name: "submitted", Value: func(u *hnapi.User) string { return formatIDs(u.Submitted) }},
+	{Name: "submitted_count", Value: func(u *hnapi.User) string { return strconv.Itoa(len(u.Submitted)) }},
+}
+
+// DefaultItemColumns are the names of the item columns written when none are given.
+var DefaultItemColumns = []string{"id", "type", "by", "time", "title", "url", "score", "descendants", "parent", "text"}
+
+// DefaultUserColumns are the names of the user columns written when none are given.
+var DefaultUserColumns = []string{"id", "created", "karma", "about", "submitted_count"}
+
+// CSVWriter streams records to CSV, one row per record, after a header row.
+type CSVWriter[T any] struct {
+	w             *csv.Writer
+	columns       []Column[T]
+	headerWritten bool
+}
+
+// NewCSVWriter creates a CSV writer with the given columns.
+func NewCSVWriter[T any](w io.Writer, columns []Column[T]) *CSVWriter[T] {
+	return &CSVWriter[T]{w: csv.NewWriter(w), columns: columns}
+}
+
+// NewItemCSVWriter creates a CSV writer of items with the named predefined columns,
+// or DefaultItemColumns if no names are given.
+func NewItemCSVWriter(w io.Writer, names ...string) (*CSVWriter[*hnapi.Item], error) {
+	if len(names) == 0 {
+		names = DefaultItemColumns
+	}
+	columns, err := selectColumns(ItemColumns, names)
+	if err != nil {
+		return nil, err
+	}
+	return NewCSVWriter(w, columns), nil
+}
+
+// NewUserCSVWriter creates a CSV writer of users with the named predefined columns,
+// or DefaultUserColumns if no names are given.
+func NewUserCSVWriter(w io.Writer, names ...string) (*CSVWriter[*hnapi.User], error) {
+	if len(names) == 0 {
+		names = DefaultUserColumns
+	}
+	columns, err := selectColumns(UserColumns, names)
+	if err != nil {
+		return nil, err
+	}
+	return NewCSVWriter(w, columns), nil
+}
+
+// Write writes one row per record, preceded by the header row on the first call.
+// Nil records are skipped. Rows are buffered; call Flush when done.
+func (w *CSVWriter[T]) Write(records ...T) error {
+	if !w.headerWritten {
+		header := make([]string, len(w.columns))
+		for i, column := range w.columns {
+			header[i] = column.Name
+		}
+		if err := w.w.Write(header); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		w.headerWritten = true
+	}
+
+	row := make([]string, len(w.columns))
+	for _, record := range records {
+		if isNil(record) {
+			continue
+		}
+		for i, column := range w.columns {
+			row[i] = column.Value(record)
+		}
+		if err := w.w.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (w *CSVWriter[T]) Flush() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+	return nil
+}
+
+// selectColumns returns the named columns in the order of names.
+func selectColumns[T any](available []Column[T], names []string) ([]Column[T], error) {
+	columns := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, column := range available {
+			if column.Name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+	return columns, nil
+}
+
+// isNil reports whether a record is a nil pointer.
+func isNil(record interface{}) bool {
+	switch r := record.(type) {
+	case *hnapi.Item:
+		return r == nil
+	case *hnapi.User:
+		return r == nil
+	default:
+		return record == nil
+	}
+}
+
+// formatTime formats Unix seconds as RFC 3339 in UTC, or an empty string for 0.
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+// formatID formats an optional ID, leaving 0 empty.
+func formatID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// formatIDs formats a list of IDs separated by spaces.
+func formatIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,90 @@
+package hnapiexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/hnapi"
+)
+
+func TestItemCSVWriter(t *testing.T) {
+	items := []*hnapi.Item{
+		{ID: 1, Type: hnapi.TypeStory, By: "alice", Time: 1700000000, Title: `Quotes "and", commas`, URL: "https://www.example.com/a", Score: 10, Kids: []int{3, 4}},
+		nil,
+		{ID: 3, Type: hnapi.TypeComment, By: "bob", Parent: 1, Text: "Line one<p>Line &amp; two"},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewItemCSVWriter(&buf, "id", "time", "title", "domain", "parent", "kids", "plain_text")
+	if err != nil {
+		t.Fatalf("NewItemCSVWriter() error = %v", err)
+	}
+	if err := w.Write(items[:2]...); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Write(items[2]); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"id", "time", "title", "domain", "parent", "kids", "plain_text"},
+		{"1", "2023-11-14T22:13:20Z", `Quotes "and", commas`, "example.com", "", "3 4", ""},
+		{"3", "", "", "", "1", "", "Line one\n\nLine & two"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Rows = %q, want %q", rows, want)
+	}
+}
+
+func TestUserCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewUserCSVWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewUserCSVWriter() error = %v", err)
+	}
+	if err := w.Write(&hnapi.User{ID: "pg", Created: 1160418092, Karma: 155111, About: "Bug fixer.", Submitted: []int{1, 2}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "id,created,karma,about,submitted_count\npg,2006-10-09T18:21:32Z,155111,Bug fixer.,2\n"
+	if buf.String() != want {
+		t.Errorf("Output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCustomColumns(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, []ItemColumn{
+		{Name: "title_upper", Value: func(i *hnapi.Item) string { return strings.ToUpper(i.Title) }},
+	})
+	if err := w.Write(&hnapi.Item{Title: "hello"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if buf.String() != "title_upper\nHELLO\n" {
+		t.Errorf("Output = %q", buf.String())
+	}
+}
+
+func TestUnknownColumn(t *testing.T) {
+	if _, err := NewItemCSVWriter(&bytes.Buffer{}, "id", "nope"); err == nil {
+		t.Errorf("Expected an error for an unknown column")
+	}
+}
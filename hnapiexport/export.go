@@ -0,0 +1,24 @@
+// Package hnapiexport writes Hacker News items and users to files for analysis in
+// spreadsheets, pandas, or data pipelines.
+//
+// CSV writers stream rows as they are written, with a configurable set of columns:
+//
+//	w, err := hnapiexport.NewItemCSVWriter(os.Stdout, "id", "title", "score", "time")
+//	if err != nil {
+//		return err
+//	}
+//	if err := w.Write(items...); err != nil {
+//		return err
+//	}
+//	return w.Flush()
+//
+// JSON Lines writers stream any records, optionally gzip-compressed, and plug
+// directly onto the client's channels:
+//
+//	w, err := hnapiexport.NewJSONLWriter(file, hnapiexport.WithGzip(gzip.DefaultCompression))
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+//	return hnapiexport.WriteStream(w, items)
+package hnapiexport
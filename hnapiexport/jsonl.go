@@ -0,0 +1,115 @@
+package hnapiexport
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONLOption configures a JSONLWriter.
+type JSONLOption func(*jsonlConfig)
+
+// jsonlConfig holds the JSONLWriter settings.
+type jsonlConfig struct {
+	gzip      bool
+	gzipLevel int
+}
+
+// WithGzip compresses the output with gzip at the given level, such as
+// gzip.DefaultCompression or gzip.BestSpeed.
+func WithGzip(level int) JSONLOption {
+	return func(c *jsonlConfig) {
+		c.gzip = true
+		c.gzipLevel = level
+	}
+}
+
+// JSONLWriter streams records as JSON Lines: one JSON value per line. Items,
+// users, and updates are written in the same format the API returns them.
+type JSONLWriter struct {
+	buf     *bufio.Writer
+	gz      *gzip.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONLWriter creates a JSON Lines writer. Output is buffered; call Flush to
+// push written records through, and Close when done.
+func NewJSONLWriter(w io.Writer, opts ...JSONLOption) (*JSONLWriter, error) {
+	var config jsonlConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	jw := &JSONLWriter{}
+	if config.gzip {
+		gz, err := gzip.NewWriterLevel(w, config.gzipLevel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip writer: %w", err)
+		}
+		jw.gz = gz
+		w = gz
+	}
+
+	jw.buf = bufio.NewWriter(w)
+	jw.encoder = json.NewEncoder(jw.buf)
+	jw.encoder.SetEscapeHTML(false)
+
+	return jw, nil
+}
+
+// Write writes each record on its own line.
+func (w *JSONLWriter) Write(records ...interface{}) error {
+	for _, record := range records {
+		if err := w.encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to write JSON line: %w", err)
+		}
+	}
+	return nil
+}
+
+// Flush pushes buffered records to the underlying writer. With gzip, the records
+// become decodable by a reader without waiting for Close.
+func (w *JSONLWriter) Flush() error {
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush JSON lines: %w", err)
+	}
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return fmt.Errorf("failed to flush JSON lines: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close flushes buffered records and, with gzip, ends the compressed stream. It
+// does not close the underlying writer.
+func (w *JSONLWriter) Close() error {
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to flush JSON lines: %w", err)
+	}
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			return fmt.Errorf("failed to close gzip stream: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteStream writes every record received from records, such as the channel of
+// StartFirehose or StartUpdates, until it is closed. Output is flushed whenever
+// the stream goes idle, so downstream consumers see records promptly.
+func WriteStream[T any](w *JSONLWriter, records <-chan T) error {
+	for record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Flush()
+}
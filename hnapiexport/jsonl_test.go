@@ -0,0 +1,96 @@
+package hnapiexport
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/yarlson/hnapi"
+)
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewJSONLWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewJSONLWriter() error = %v", err)
+	}
+
+	if err := w.Write(&hnapi.Item{ID: 1, Type: hnapi.TypeStory, Title: "<b>Hi</b>"}, &hnapi.User{ID: "pg"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := `{"id":1,"type":"story","time":0,"title":"<b>Hi</b>"}` + "\n" + `{"id":"pg","created":0,"karma":0}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestJSONLWriterGzip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewJSONLWriter(&buf, WithGzip(gzip.BestSpeed))
+	if err != nil {
+		t.Fatalf("NewJSONLWriter() error = %v", err)
+	}
+
+	updates := make(chan hnapi.Updates, 2)
+	updates <- hnapi.Updates{Items: []int{1, 2}, Profiles: []string{"pg"}}
+	updates <- hnapi.Updates{Items: []int{3}, Profiles: []string{}}
+	close(updates)
+
+	if err := WriteStream(w, updates); err != nil {
+		t.Fatalf("WriteStream() error = %v", err)
+	}
+
+	// Flushed records are readable before the gzip stream is closed
+	gz, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Output is not gzip: %v", err)
+	}
+	lines := readLines(t, gz)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines after flushing, got %d", len(lines))
+	}
+
+	var got hnapi.Updates
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil || len(got.Items) != 2 || got.Profiles[0] != "pg" {
+		t.Errorf("Unexpected first line %q: %v", lines[0], err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	gz, err = gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("Output is not gzip: %v", err)
+	}
+	if _, err := io.ReadAll(gz); err != nil {
+		t.Errorf("Expected a complete gzip stream after Close, got %v", err)
+	}
+}
+
+func TestJSONLWriterInvalidLevel(t *testing.T) {
+	if _, err := NewJSONLWriter(io.Discard, WithGzip(42)); err == nil {
+		t.Errorf("Expected an error for an invalid gzip level")
+	}
+}
+
+// readLines reads lines until the end of the data available so far.
+func readLines(t *testing.T, r io.Reader) []string {
+	t.Helper()
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil && err != io.ErrUnexpectedEOF {
+		t.Fatalf("Failed to read lines: %v", err)
+	}
+	return lines
+}
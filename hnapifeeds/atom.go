@@ -0,0 +1,85 @@
+package hnapifeeds
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// atomFeed is the root element of an Atom document.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is an Atom entry element.
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published,omitempty"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Links     []atomLink  `xml:"link"`
+	Summary   atomText    `xml:"summary"`
+}
+
+// atomLink is an Atom link element.
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+// atomAuthor is an Atom person element.
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+// atomText is an Atom text construct.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteAtom writes the feed as an Atom document. An empty feed is dated at the
+// Unix epoch, since Atom requires an update time.
+func (f *Feed) WriteAtom(w io.Writer) error {
+	updated := f.updated()
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	doc := atomFeed{
+		ID:      f.Link,
+		Title:   f.Title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: f.Link},
+	}
+
+	for _, item := range f.Items {
+		created := item.CreatedAt().UTC().Format(time.RFC3339)
+		entry := atomEntry{
+			ID:        item.HNLink(),
+			Title:     entryTitle(item),
+			Updated:   created,
+			Published: created,
+			Links:     []atomLink{{Href: entryLink(item), Rel: "alternate"}},
+			Summary:   atomText{Type: "html", Value: entrySummary(item)},
+		}
+		if item.URL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.HNLink(), Rel: "related"})
+		}
+		if item.By != "" {
+			entry.Author = &atomAuthor{Name: item.By, URI: hnapi.UserURL(item.By)}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return writeXML(w, doc)
+}
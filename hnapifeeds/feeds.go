@@ -0,0 +1,213 @@
+// Package hnapifeeds renders Hacker News lists and user submissions as RSS 2.0 or
+// Atom feeds.
+//
+//	feed, err := hnapifeeds.ListFeed(ctx, client, hnapi.ListTop, 30)
+//	if err != nil {
+//		return err
+//	}
+//	return feed.WriteAtom(w)
+//
+// Entries link to the submitted URL when there is one and to the Hacker News
+// discussion otherwise. Deleted and dead items are left out, and so are items that
+// fail to load; building a feed only fails when its list or user cannot be fetched.
+package hnapifeeds
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Format is a feed format.
+type Format int
+
+const (
+	// FormatRSS is RSS 2.0.
+	FormatRSS Format = iota
+
+	// FormatAtom is Atom (RFC 4287).
+	FormatAtom
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatRSS:
+		return "rss"
+	case FormatAtom:
+		return "atom"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatAtom {
+		return "application/atom+xml; charset=utf-8"
+	}
+	return "application/rss+xml; charset=utf-8"
+}
+
+// Feed is a titled sequence of items that can be written as RSS or Atom.
+type Feed struct {
+	// Title is the feed title.
+	Title string
+
+	// Link is the page the feed describes.
+	Link string
+
+	// Description is a short summary of the feed.
+	Description string
+
+	// Items are the feed entries, in display order.
+	Items []*hnapi.Item
+}
+
+// ListFeed builds a feed of the first n stories of a list, or all of them if n is
+// 0 or less.
+func ListFeed(ctx context.Context, client *hnapi.Client, list hnapi.List, n int) (*Feed, error) {
+	ids, err := client.GetList(ctx, list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s feed: %w", list, err)
+	}
+	if n > 0 && n < len(ids) {
+		ids = ids[:n]
+	}
+	items := feedItems(ctx, client, ids)
+
+	return &Feed{
+		Title:       "Hacker News: " + listTitles[list],
+		Link:        listLinks[list],
+		Description: "The " + listTitles[list] + " list on Hacker News",
+		Items:       items,
+	}, nil
+}
+
+// UserFeed builds a feed of a user's n most recent submissions, or all of them if n
+// is 0 or less. Submissions include comments as well as stories, jobs, and polls.
+func UserFeed(ctx context.Context, client *hnapi.Client, username string, n int) (*Feed, error) {
+	user, err := client.GetUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build feed of %s: %w", username, err)
+	}
+
+	ids := user.Submitted
+	if n > 0 && n < len(ids) {
+		ids = ids[:n]
+	}
+
+	return &Feed{
+		Title:       "Hacker News: " + username,
+		Link:        user.HNLink(),
+		Description: "Submissions by " + username + " on Hacker News",
+		Items:       feedItems(ctx, client, ids),
+	}, nil
+}
+
+// feedItems fetches the items of ids in order, leaving out deleted and dead items
+// and those that fail to load, so one bad item does not take down the whole feed.
+func feedItems(ctx context.Context, client *hnapi.Client, ids []int) []*hnapi.Item {
+	items := make([]*hnapi.Item, 0, len(ids))
+	for _, r := range client.GetItemsBatchResults(ctx, ids, hnapi.SkipDeadAndDeleted()) {
+		if r.Err == nil {
+			items = append(items, r.Item)
+		}
+	}
+	return items
+}
+
+// listTitles are the human-readable names of the lists.
+var listTitles = map[hnapi.List]string{
+	hnapi.ListTop:  "Top Stories",
+	hnapi.ListNew:  "New Stories",
+	hnapi.ListBest: "Best Stories",
+	hnapi.ListAsk:  "Ask HN",
+	hnapi.ListShow: "Show HN",
+	hnapi.ListJob:  "Jobs",
+}
+
+// listLinks are the website pages showing the lists.
+var listLinks = map[hnapi.List]string{
+	hnapi.ListTop:  "https://news.ycombinator.com/news",
+	hnapi.ListNew:  "https://news.ycombinator.com/newest",
+	hnapi.ListBest: "https://news.ycombinator.com/best",
+	hnapi.ListAsk:  "https://news.ycombinator.com/ask",
+	hnapi.ListShow: "https://news.ycombinator.com/show",
+	hnapi.ListJob:  "https://news.ycombinator.com/jobs",
+}
+
+// Write writes the feed in the given format.
+func (f *Feed) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatRSS:
+		return f.WriteRSS(w)
+	case FormatAtom:
+		return f.WriteAtom(w)
+	default:
+		return fmt.Errorf("unknown feed format %v", format)
+	}
+}
+
+// updated returns the creation time of the newest item, or the zero time for an
+// empty feed.
+func (f *Feed) updated() time.Time {
+	var newest time.Time
+	for _, item := range f.Items {
+		if t := item.CreatedAt(); t.After(newest) {
+			newest = t
+		}
+	}
+	return newest
+}
+
+// entryTitle returns the title of an item's entry. Comments have no title of
+// their own.
+func entryTitle(item *hnapi.Item) string {
+	if item.Title != "" {
+		return item.Title
+	}
+	if item.By != "" {
+		return fmt.Sprintf("Comment by %s", item.By)
+	}
+	return fmt.Sprintf("Item %d", item.ID)
+}
+
+// entryLink returns the link of an item's entry.
+func entryLink(item *hnapi.Item) string {
+	if item.URL != "" {
+		return item.URL
+	}
+	return item.HNLink()
+}
+
+// entrySummary returns the HTML description of an item's entry: its text, if any,
+// and a link to the discussion.
+func entrySummary(item *hnapi.Item) string {
+	footer := fmt.Sprintf(`%d points | <a href="%s">%d comments</a>`, item.Score, item.HNLink(), item.Descendants)
+	if item.IsComment() {
+		footer = fmt.Sprintf(`<a href="%s">Comment</a>`, item.HNLink())
+	}
+	return item.Text + "<p>" + footer + "</p>"
+}
+
+// writeXML writes v as an indented XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write feed: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write feed: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write feed: %w", err)
+	}
+	return nil
+}
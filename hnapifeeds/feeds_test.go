@@ -0,0 +1,150 @@
+package hnapifeeds
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+func newServer() *hnapitest.Server {
+	srv := hnapitest.NewServer()
+	srv.AddItems(
+		&hnapi.Item{ID: 1, Type: hnapi.TypeStory, By: "alice", Title: "Link & story", URL: "https://example.com/a", Score: 10, Time: 1700000000, Descendants: 3},
+		&hnapi.Item{ID: 2, Type: hnapi.TypeStory, By: "bob", Title: "Ask HN: Why?", Text: "<p>Because</p>", Time: 1700000100},
+		&hnapi.Item{ID: 3, Type: hnapi.TypeComment, By: "alice", Text: "A comment", Parent: 2, Time: 1700000200},
+		&hnapi.Item{ID: 4, Type: hnapi.TypeStory, Deleted: true},
+	)
+	srv.AddUsers(&hnapi.User{ID: "alice", Submitted: []int{3, 1}})
+	srv.SetList(hnapi.ListTop, []int{1, 4, 2})
+	return srv
+}
+
+func TestListFeed(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	feed, err := ListFeed(context.Background(), srv.Client(), hnapi.ListTop, 0)
+	if err != nil {
+		t.Fatalf("ListFeed() error = %v", err)
+	}
+
+	if feed.Title != "Hacker News: Top Stories" || feed.Link != "https://news.ycombinator.com/news" {
+		t.Errorf("Unexpected feed metadata: %q, %q", feed.Title, feed.Link)
+	}
+	if len(feed.Items) != 2 || feed.Items[0].ID != 1 || feed.Items[1].ID != 2 {
+		t.Errorf("Expected stories 1 and 2 without the deleted one, got %v", feed.Items)
+	}
+}
+
+func TestListFeedItemFailure(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	srv.FailNext("item/1.json", http.StatusInternalServerError, 1)
+
+	// A failed item is left out instead of failing the feed
+	feed, err := ListFeed(context.Background(), srv.Client(hnapi.WithMaxRetries(0)), hnapi.ListTop, 0)
+	if err != nil {
+		t.Fatalf("ListFeed() error = %v", err)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].ID != 2 {
+		t.Errorf("Expected only story 2, got %v", feed.Items)
+	}
+
+	srv.FailNext("topstories.json", http.StatusInternalServerError, 1)
+	if _, err := ListFeed(context.Background(), srv.Client(hnapi.WithMaxRetries(0)), hnapi.ListTop, 0); err == nil {
+		t.Errorf("Expected an error when the list cannot be fetched")
+	}
+}
+
+func TestUserFeed(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	feed, err := UserFeed(context.Background(), srv.Client(), "alice", 1)
+	if err != nil {
+		t.Fatalf("UserFeed() error = %v", err)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].ID != 3 {
+		t.Errorf("Expected the most recent submission, got %v", feed.Items)
+	}
+
+	if _, err := UserFeed(context.Background(), srv.Client(), "nobody", 0); err == nil {
+		t.Errorf("Expected an error for a missing user")
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	feed, err := UserFeed(context.Background(), srv.Client(), "alice", 0)
+	if err != nil {
+		t.Fatalf("UserFeed() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := feed.Write(&buf, FormatRSS); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var doc rss
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	items := doc.Channel.Items
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+	if items[0].Title != "Comment by alice" || items[0].Link != "https://news.ycombinator.com/item?id=3" {
+		t.Errorf("Unexpected comment entry: %+v", items[0])
+	}
+	if items[1].Title != "Link & story" || items[1].Link != "https://example.com/a" || items[1].PubDate != "Tue, 14 Nov 2023 22:13:20 +0000" {
+		t.Errorf("Unexpected story entry: %+v", items[1])
+	}
+	if doc.Channel.LastBuildDate != "Tue, 14 Nov 2023 22:16:40 +0000" {
+		t.Errorf("Expected the newest item time as build date, got %q", doc.Channel.LastBuildDate)
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	feed, err := ListFeed(context.Background(), srv.Client(), hnapi.ListTop, 0)
+	if err != nil {
+		t.Fatalf("ListFeed() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := feed.Write(&buf, FormatAtom); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var doc atomFeed
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	if doc.Updated != "2023-11-14T22:15:00Z" || len(doc.Entries) != 2 {
+		t.Fatalf("Unexpected feed: updated %q, %d entries", doc.Updated, len(doc.Entries))
+	}
+	entry := doc.Entries[1]
+	if entry.Title != "Ask HN: Why?" || entry.Author == nil || entry.Author.Name != "bob" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if !strings.Contains(entry.Summary.Value, "<p>Because</p>") {
+		t.Errorf("Expected summary to carry the story text, got %q", entry.Summary.Value)
+	}
+
+	if err := feed.Write(&buf, Format(9)); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
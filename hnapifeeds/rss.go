@@ -0,0 +1,74 @@
+package hnapifeeds
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// rss is the root element of an RSS 2.0 document.
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is the RSS channel element.
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+// rssItem is an RSS item element.
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	Creator     string  `xml:"dc:creator,omitempty"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	Comments    string  `xml:"comments"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+// rssGUID is the unique identifier of an RSS item.
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// WriteRSS writes the feed as an RSS 2.0 document.
+func (f *Feed) WriteRSS(w io.Writer) error {
+	doc := rss{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+		},
+	}
+	if updated := f.updated(); !updated.IsZero() {
+		doc.Channel.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range f.Items {
+		entry := rssItem{
+			Title:       entryTitle(item),
+			Link:        entryLink(item),
+			Description: entrySummary(item),
+			Creator:     item.By,
+			Comments:    item.HNLink(),
+			GUID:        rssGUID{IsPermaLink: true, Value: item.HNLink()},
+		}
+		if item.Time != 0 {
+			entry.PubDate = item.CreatedAt().UTC().Format(time.RFC1123Z)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+
+	return writeXML(w, doc)
+}
@@ -0,0 +1,392 @@
+// Package hnapigateway provides a REST gateway with the convenience endpoints the
+// Firebase API lacks, for frontends that want ready-to-render JSON:
+//
+//	GET /top?limit=30&offset=0         hydrated stories of a list (also /new, /best, /ask, /show, /job)
+//	GET /item/{id}                     one item
+//	GET /item/{id}/tree?depth=3        an item with its nested comment tree, up to a maximum depth
+//	GET /user/{id}                     one user
+//	GET /user/{id}/submissions?limit=  the user's hydrated submissions, most recent first
+//
+// Paged endpoints return a Page envelope with the offset of the next page. Deleted
+// and dead items are left out of pages and trees. A tree whose comments partly
+// failed to load is served as far as it loaded, with an error on its root, and is
+// not cached. Responses are cached in memory and concurrent requests for the same
+// uncached response share one fetch:
+//
+//	http.Handle("/api/", http.StripPrefix("/api", hnapigateway.NewHandler(client)))
+package hnapigateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/internal/cache"
+)
+
+// Defaults used by NewHandler.
+const (
+	DefaultTTL        = 30 * time.Second
+	DefaultMaxEntries = 1000
+	DefaultLimit      = 30
+	DefaultMaxLimit   = 100
+	DefaultMaxDepth   = 10
+)
+
+// lists maps list paths to lists.
+var lists = map[string]hnapi.List{
+	"top":  hnapi.ListTop,
+	"new":  hnapi.ListNew,
+	"best": hnapi.ListBest,
+	"ask":  hnapi.ListAsk,
+	"show": hnapi.ListShow,
+	"job":  hnapi.ListJob,
+}
+
+// Route patterns served by the gateway.
+var (
+	listPattern        = regexp.MustCompile(`^/(top|new|best|ask|show|job)$`)
+	itemPattern        = regexp.MustCompile(`^/item/(\d+)$`)
+	treePattern        = regexp.MustCompile(`^/item/(\d+)/tree$`)
+	userPattern        = regexp.MustCompile(`^/user/([A-Za-z0-9_-]+)$`)
+	submissionsPattern = regexp.MustCompile(`^/user/([A-Za-z0-9_-]+)/submissions$`)
+)
+
+// Page is one page of a paged endpoint.
+type Page struct {
+	// Items are the hydrated items of the page.
+	Items []*hnapi.Item `json:"items"`
+
+	// Offset and Limit are the window the page was requested with.
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+
+	// Total is the number of IDs in the whole list, before deleted and dead items
+	// are left out.
+	Total int `json:"total"`
+
+	// Next is the offset of the next page, or nil on the last page.
+	Next *int `json:"next,omitempty"`
+}
+
+// Node is an item in a comment tree, serialized as the item's fields plus its
+// loaded replies.
+type Node struct {
+	*hnapi.Item
+
+	// Children are the loaded replies in ranked display order.
+	Children []*Node `json:"children"`
+
+	// Error is set on the root of a tree whose comments partly failed to load.
+	Error string `json:"error,omitempty"`
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithTTL sets how long responses are cached. A TTL of 0 disables caching.
+func WithTTL(ttl time.Duration) Option {
+	return func(h *Handler) {
+		h.ttl = ttl
+	}
+}
+
+// WithMaxEntries sets the maximum number of cached responses.
+func WithMaxEntries(n int) Option {
+	return func(h *Handler) {
+		h.maxEntries = n
+	}
+}
+
+// WithLimits sets the page size used when a request has no limit parameter and the
+// largest page size a request may ask for.
+func WithLimits(defaultLimit, maxLimit int) Option {
+	return func(h *Handler) {
+		h.defaultLimit = defaultLimit
+		h.maxLimit = maxLimit
+	}
+}
+
+// WithMaxDepth sets the deepest comment tree a request may ask for. Trees requested
+// without a depth, or with a depth of 0, are loaded to this depth.
+func WithMaxDepth(depth int) Option {
+	return func(h *Handler) {
+		h.maxDepth = depth
+	}
+}
+
+// Handler is an http.Handler serving the gateway endpoints.
+type Handler struct {
+	client       *hnapi.Client
+	ttl          time.Duration
+	maxEntries   int
+	defaultLimit int
+	maxLimit     int
+	maxDepth     int
+	now          func() time.Time
+	cache        *cache.Cache
+}
+
+// partialError marks a response that failed after part of it was built. The part
+// is served, but not cached.
+type partialError struct {
+	err error
+}
+
+func (e *partialError) Error() string { return e.err.Error() }
+
+func (e *partialError) Unwrap() error { return e.err }
+
+// NewHandler creates a gateway handler that fetches through client.
+func NewHandler(client *hnapi.Client, opts ...Option) *Handler {
+	h := &Handler{
+		client:       client,
+		ttl:          DefaultTTL,
+		maxEntries:   DefaultMaxEntries,
+		defaultLimit: DefaultLimit,
+		maxLimit:     DefaultMaxLimit,
+		maxDepth:     DefaultMaxDepth,
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.cache = cache.New(h.ttl, h.maxEntries, func() time.Time { return h.now() })
+
+	return h
+}
+
+// ServeHTTP serves a gateway endpoint. Errors are reported as JSON objects with an
+// "error" field: 400 for invalid parameters, 404 for missing items and users, and
+// 502 for upstream failures. Partial trees are served with 200.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	build, key, err := h.route(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if build == nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	body, hit, err := h.get(r.Context(), key, build)
+	switch {
+	case err == nil:
+	case body != nil:
+		// A partial tree: serve what loaded
+	case r.Context().Err() != nil:
+		return
+	case errors.Is(err, hnapi.ErrNotFound):
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	default:
+		writeError(w, http.StatusBadGateway, "upstream request failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if hit {
+		w.Header().Set("X-Cache", "HIT")
+	} else {
+		w.Header().Set("X-Cache", "MISS")
+	}
+	if h.ttl > 0 && err == nil {
+		w.Header().Set("Cache-Control", "max-age="+strconv.Itoa(int(h.ttl/time.Second)))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(body)
+	}
+}
+
+// route returns the function building the response for r and its cache key. Both
+// are zero if no endpoint matches.
+func (h *Handler) route(r *http.Request) (func(context.Context) (interface{}, error), string, error) {
+	path := r.URL.Path
+	query := r.URL.Query()
+
+	if m := listPattern.FindStringSubmatch(path); m != nil {
+		offset, limit, err := h.window(query)
+		if err != nil {
+			return nil, "", err
+		}
+		list := lists[m[1]]
+		return func(ctx context.Context) (interface{}, error) {
+			ids, err := h.client.GetList(ctx, list)
+			if err != nil {
+				return nil, err
+			}
+			return h.page(ctx, ids, offset, limit)
+		}, pageKey(path, offset, limit), nil
+	}
+
+	if m := itemPattern.FindStringSubmatch(path); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		return func(ctx context.Context) (interface{}, error) {
+			return h.client.GetItem(ctx, id)
+		}, path, nil
+	}
+
+	if m := treePattern.FindStringSubmatch(path); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		depth, err := intParam(query, "depth", 0)
+		if err != nil {
+			return nil, "", err
+		}
+		if depth > h.maxDepth {
+			return nil, "", errors.New("depth must be between 0 and " + strconv.Itoa(h.maxDepth))
+		}
+		if depth == 0 {
+			depth = h.maxDepth
+		}
+		return func(ctx context.Context) (interface{}, error) {
+			tree, err := h.client.GetCommentTree(ctx, id, depth)
+			if tree == nil {
+				return nil, err
+			}
+			node := toNode(tree)
+			if err != nil {
+				node.Error = "some comments failed to load"
+				return node, &partialError{err: err}
+			}
+			return node, nil
+		}, path + "?depth=" + strconv.Itoa(depth), nil
+	}
+
+	if m := userPattern.FindStringSubmatch(path); m != nil {
+		username := m[1]
+		return func(ctx context.Context) (interface{}, error) {
+			return h.client.GetUser(ctx, username)
+		}, path, nil
+	}
+
+	if m := submissionsPattern.FindStringSubmatch(path); m != nil {
+		offset, limit, err := h.window(query)
+		if err != nil {
+			return nil, "", err
+		}
+		username := m[1]
+		return func(ctx context.Context) (interface{}, error) {
+			user, err := h.client.GetUser(ctx, username)
+			if err != nil {
+				return nil, err
+			}
+			return h.page(ctx, user.Submitted, offset, limit)
+		}, pageKey(path, offset, limit), nil
+	}
+
+	return nil, "", nil
+}
+
+// window parses the offset and limit parameters of a paged request.
+func (h *Handler) window(query url.Values) (int, int, error) {
+	offset, err := intParam(query, "offset", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	limit, err := intParam(query, "limit", h.defaultLimit)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit < 1 || limit > h.maxLimit {
+		return 0, 0, errors.New("limit must be between 1 and " + strconv.Itoa(h.maxLimit))
+	}
+	return offset, limit, nil
+}
+
+// page hydrates the window of ids starting at offset.
+func (h *Handler) page(ctx context.Context, ids []int, offset, limit int) (*Page, error) {
+	p := &Page{Items: []*hnapi.Item{}, Offset: offset, Limit: limit, Total: len(ids)}
+	if offset >= len(ids) {
+		return p, nil
+	}
+
+	end := offset + limit
+	if end < len(ids) {
+		p.Next = &end
+	} else {
+		end = len(ids)
+	}
+
+	items, err := h.client.GetItemsBatch(ctx, ids[offset:end], hnapi.SkipDeadAndDeleted())
+	if err != nil && len(items) == 0 {
+		return nil, err
+	}
+	p.Items = items
+	return p, nil
+}
+
+// get returns the response body for key, building it with build unless it is
+// cached, and whether it came from the cache. A partial response is returned
+// together with its error.
+func (h *Handler) get(ctx context.Context, key string, build func(context.Context) (interface{}, error)) ([]byte, bool, error) {
+	return h.cache.Get(ctx, key, func(ctx context.Context) ([]byte, error) {
+		v, err := build(ctx)
+		var partial *partialError
+		if err != nil && !errors.As(err, &partial) {
+			return nil, err
+		}
+
+		body, marshalErr := json.Marshal(v)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		return body, err
+	})
+}
+
+// toNode converts a comment tree, leaving out deleted and dead comments.
+func toNode(tree *hnapi.CommentNode) *Node {
+	node := &Node{Item: tree.Item, Children: []*Node{}}
+	for _, child := range tree.Children {
+		if !child.Item.IsLive() {
+			continue
+		}
+		node.Children = append(node.Children, toNode(child))
+	}
+	return node
+}
+
+// pageKey returns the cache key of a paged request.
+func pageKey(path string, offset, limit int) string {
+	return path + "?offset=" + strconv.Itoa(offset) + "&limit=" + strconv.Itoa(limit)
+}
+
+// intParam parses a non-negative integer query parameter, returning def if it is
+// absent.
+func intParam(query url.Values, name string, def int) (int, error) {
+	values := query[name]
+	if len(values) == 0 || values[0] == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(values[0])
+	if err != nil || n < 0 {
+		return 0, errors.New(name + " must be a non-negative integer")
+	}
+	return n, nil
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(map[string]string{"error": message})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
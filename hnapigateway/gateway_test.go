@@ -0,0 +1,194 @@
+package hnapigateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+func newServer() *hnapitest.Server {
+	srv := hnapitest.NewServer()
+	srv.AddItems(
+		&hnapi.Item{ID: 1, Type: hnapi.TypeStory, By: "alice", Title: "One", Kids: []int{4, 5}},
+		&hnapi.Item{ID: 2, Type: hnapi.TypeStory, By: "bob", Title: "Two", Dead: true},
+		&hnapi.Item{ID: 3, Type: hnapi.TypeStory, By: "alice", Title: "Three"},
+		&hnapi.Item{ID: 4, Type: hnapi.TypeComment, By: "bob", Text: "Reply", Parent: 1, Kids: []int{6}},
+		&hnapi.Item{ID: 5, Type: hnapi.TypeComment, Deleted: true, Parent: 1},
+		&hnapi.Item{ID: 6, Type: hnapi.TypeComment, By: "alice", Text: "Nested", Parent: 4},
+	)
+	srv.AddUsers(&hnapi.User{ID: "alice", Karma: 3, Submitted: []int{6, 3, 1}})
+	srv.SetList(hnapi.ListTop, []int{1, 2, 3})
+	return srv
+}
+
+func get(t *testing.T, h http.Handler, target string, wantStatus int, v interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != wantStatus {
+		t.Fatalf("GET %s: expected status %d, got %d: %s", target, wantStatus, rec.Code, rec.Body)
+	}
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("GET %s: failed to decode response: %v", target, err)
+		}
+	}
+	return rec
+}
+
+func ids(items []*hnapi.Item) []int {
+	var out []int
+	for _, item := range items {
+		out = append(out, item.ID)
+	}
+	return out
+}
+
+func TestListPages(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h := NewHandler(srv.Client())
+
+	var first Page
+	get(t, h, "/top?limit=2", http.StatusOK, &first)
+	if got := ids(first.Items); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Expected story 1 without the dead story 2, got %v", got)
+	}
+	if first.Total != 3 || first.Limit != 2 || first.Next == nil || *first.Next != 2 {
+		t.Errorf("Unexpected page metadata %+v", first)
+	}
+
+	var second Page
+	get(t, h, "/top?limit=2&offset=2", http.StatusOK, &second)
+	if got := ids(second.Items); len(got) != 1 || got[0] != 3 || second.Next != nil {
+		t.Errorf("Unexpected last page %+v", second)
+	}
+
+	var past Page
+	get(t, h, "/top?offset=10", http.StatusOK, &past)
+	if len(past.Items) != 0 || past.Next != nil {
+		t.Errorf("Expected an empty page past the end, got %+v", past)
+	}
+
+	get(t, h, "/top?limit=0", http.StatusBadRequest, nil)
+	get(t, h, "/top?limit=1000", http.StatusBadRequest, nil)
+	get(t, h, "/top?offset=x", http.StatusBadRequest, nil)
+}
+
+func TestTree(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h := NewHandler(srv.Client())
+
+	var tree struct {
+		ID       int `json:"id"`
+		Children []struct {
+			ID       int `json:"id"`
+			Text     string
+			Children []struct {
+				ID int `json:"id"`
+			} `json:"children"`
+		} `json:"children"`
+	}
+	get(t, h, "/item/1/tree", http.StatusOK, &tree)
+	if tree.ID != 1 || len(tree.Children) != 1 || tree.Children[0].ID != 4 {
+		t.Fatalf("Expected story 1 with reply 4 and no deleted reply, got %+v", tree)
+	}
+	if len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].ID != 6 {
+		t.Errorf("Expected nested reply 6, got %+v", tree.Children[0])
+	}
+
+	get(t, h, "/item/1/tree?depth=1", http.StatusOK, &tree)
+	if len(tree.Children) != 1 || len(tree.Children[0].Children) != 0 {
+		t.Errorf("Expected one level of replies, got %+v", tree)
+	}
+
+	get(t, h, "/item/99/tree", http.StatusNotFound, nil)
+	get(t, h, "/item/1/tree?depth=1000", http.StatusBadRequest, nil)
+}
+
+func TestPartialTree(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+	srv.FailNext("item/6.json", http.StatusInternalServerError, 1)
+
+	h := NewHandler(srv.Client(hnapi.WithMaxRetries(0)))
+
+	var tree struct {
+		ID       int    `json:"id"`
+		Error    string `json:"error"`
+		Children []struct {
+			ID       int               `json:"id"`
+			Children []json.RawMessage `json:"children"`
+		} `json:"children"`
+	}
+	rec := get(t, h, "/item/1/tree", http.StatusOK, &tree)
+	if tree.Error == "" || len(tree.Children) != 1 || len(tree.Children[0].Children) != 0 {
+		t.Errorf("Expected a partial tree without reply 6 and with an error, got %+v", tree)
+	}
+	if rec.Header().Get("Cache-Control") != "" {
+		t.Errorf("Expected a partial tree not to be cacheable, got Cache-Control %q", rec.Header().Get("Cache-Control"))
+	}
+
+	// The partial tree was not cached, so the next request loads the whole tree
+	tree.Error = ""
+	get(t, h, "/item/1/tree", http.StatusOK, &tree)
+	if tree.Error != "" || len(tree.Children[0].Children) != 1 {
+		t.Errorf("Expected the whole tree once the failure cleared, got %+v", tree)
+	}
+}
+
+func TestUserSubmissions(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h := NewHandler(srv.Client())
+
+	var user hnapi.User
+	get(t, h, "/user/alice", http.StatusOK, &user)
+	if user.Karma != 3 {
+		t.Errorf("Unexpected user %+v", user)
+	}
+
+	var page Page
+	get(t, h, "/user/alice/submissions?limit=2", http.StatusOK, &page)
+	if got := ids(page.Items); len(got) != 2 || got[0] != 6 || got[1] != 3 {
+		t.Errorf("Expected submissions 6 and 3, got %v", got)
+	}
+
+	get(t, h, "/user/nobody/submissions", http.StatusNotFound, nil)
+	get(t, h, "/user/alice/karma", http.StatusNotFound, nil)
+}
+
+func TestCaching(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h := NewHandler(srv.Client())
+
+	if rec := get(t, h, "/item/3", http.StatusOK, nil); rec.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("Expected a cache miss")
+	}
+	if rec := get(t, h, "/item/3", http.StatusOK, nil); rec.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("Expected a cache hit")
+	}
+	if n := srv.Requests("item/3.json"); n != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", n)
+	}
+
+	srv.FailNext("item/1.json", http.StatusInternalServerError, 1)
+	get(t, NewHandler(srv.Client(hnapi.WithMaxRetries(0))), "/item/1", http.StatusBadGateway, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/item/1", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/yarlson/hnapi/hnapigraphql
+
+go 1.23
+
+require (
+	github.com/graph-gophers/graphql-go v1.7.0
+	github.com/yarlson/hnapi v0.0.0-00010101000000-000000000000
+)
+
+replace github.com/yarlson/hnapi => ../
@@ -0,0 +1,135 @@
+// Package hnapigraphql serves the Hacker News API over GraphQL.
+//
+// The schema exposes items, users, and story lists with nested resolvers, so one
+// query can fetch a story, its author, and several levels of comments:
+//
+//	{
+//	  stories(list: TOP, first: 10) {
+//	    title
+//	    by { id karma }
+//	    kids(first: 5) { text kids { text } }
+//	  }
+//	}
+//
+// Item and user lookups made while resolving a query are batched dataloader-style:
+// lookups that arrive together, such as the replies of all comments on one level,
+// are fetched with one GetItemsBatch call, and every item is fetched at most once
+// per query.
+//
+// It is a separate module, github.com/yarlson/hnapi/hnapigraphql, so the GraphQL
+// library is only pulled into builds that use it.
+package hnapigraphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Schema is the GraphQL schema served by the handler.
+//
+//go:embed schema.graphql
+var Schema string
+
+// DefaultBatchWait is how long a loader collects lookups before fetching them.
+const DefaultBatchWait = 2 * time.Millisecond
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithBatchWait sets how long lookups are collected into one batch. Longer waits
+// batch more lookups at the cost of latency.
+func WithBatchWait(wait time.Duration) Option {
+	return func(h *Handler) {
+		h.batchWait = wait
+	}
+}
+
+// WithMaxDepth limits how deeply queries may nest, guarding against queries that
+// would fetch entire comment threads many levels deep. Zero means no limit.
+func WithMaxDepth(depth int) Option {
+	return func(h *Handler) {
+		h.maxDepth = depth
+	}
+}
+
+// Handler is an http.Handler that executes GraphQL queries sent as JSON POST
+// bodies with "query", "operationName", and "variables" fields.
+type Handler struct {
+	client    *hnapi.Client
+	schema    *graphql.Schema
+	batchWait time.Duration
+	maxDepth  int
+}
+
+// NewHandler creates a GraphQL handler backed by client.
+func NewHandler(client *hnapi.Client, opts ...Option) (*Handler, error) {
+	h := &Handler{client: client, batchWait: DefaultBatchWait}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	schemaOpts := []graphql.SchemaOpt{}
+	if h.maxDepth > 0 {
+		schemaOpts = append(schemaOpts, graphql.MaxDepth(h.maxDepth))
+	}
+
+	schema, err := graphql.ParseSchema(Schema, &queryResolver{client: client}, schemaOpts...)
+	if err != nil {
+		return nil, err
+	}
+	h.schema = schema
+
+	return h, nil
+}
+
+// Exec executes a query and returns its response.
+func (h *Handler) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Response {
+	ctx = withLoaders(ctx, h.client, h.batchWait)
+	return h.schema.Exec(ctx, query, operationName, variables)
+}
+
+// ServeHTTP executes the query in the request body.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response := h.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	body, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// ignoreNotFound drops ErrNotFound, which GraphQL reports as null rather than as
+// an error.
+func ignoreNotFound(err error) error {
+	if errors.Is(err, hnapi.ErrNotFound) {
+		return nil
+	}
+	return err
+}
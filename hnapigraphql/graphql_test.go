@@ -0,0 +1,164 @@
+package hnapigraphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+func newServer() *hnapitest.Server {
+	srv := hnapitest.NewServer()
+	srv.AddItems(
+		&hnapi.Item{ID: 1, Type: hnapi.TypeStory, By: "alice", Title: "First", URL: "https://example.com/a", Score: 10, Kids: []int{3, 4}},
+		&hnapi.Item{ID: 2, Type: hnapi.TypeStory, By: "bob", Title: "Second", Kids: []int{5}},
+		&hnapi.Item{ID: 3, Type: hnapi.TypeComment, By: "bob", Text: "Reply", Parent: 1, Kids: []int{6}},
+		&hnapi.Item{ID: 4, Type: hnapi.TypeComment, By: "alice", Text: "Another", Parent: 1},
+		&hnapi.Item{ID: 5, Type: hnapi.TypeComment, By: "alice", Text: "Third", Parent: 2},
+		&hnapi.Item{ID: 6, Type: hnapi.TypeComment, By: "bob", Text: "Nested", Parent: 3},
+	)
+	srv.AddUsers(
+		&hnapi.User{ID: "alice", Karma: 42, Submitted: []int{5, 4, 1}},
+		&hnapi.User{ID: "bob", Karma: 7, Submitted: []int{6, 3, 2}},
+	)
+	srv.SetList(hnapi.ListTop, []int{1, 2})
+	return srv
+}
+
+func exec(t *testing.T, h *Handler, query string, variables map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var resp struct {
+		Data   map[string]interface{} `json:"data"`
+		Errors []interface{}          `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", resp.Errors)
+	}
+	return resp.Data
+}
+
+func TestStoriesWithNestedComments(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h, err := NewHandler(srv.Client())
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	data := exec(t, h, `{
+		stories(list: TOP, first: 2) {
+			id title domain
+			by { id karma }
+			kids { id text parent { id } kids { id by { id } } }
+		}
+	}`, nil)
+
+	got, _ := json.Marshal(data)
+	want := `{"stories":[` +
+		`{"by":{"id":"alice","karma":42},"domain":"example.com","id":1,"kids":[` +
+		`{"id":3,"kids":[{"by":{"id":"bob"},"id":6}],"parent":{"id":1},"text":"Reply"},` +
+		`{"id":4,"kids":[],"parent":{"id":1},"text":"Another"}],"title":"First"},` +
+		`{"by":{"id":"bob","karma":7},"domain":null,"id":2,"kids":[` +
+		`{"id":5,"kids":[],"parent":{"id":2},"text":"Third"}],"title":"Second"}]}`
+	if string(got) != want {
+		t.Errorf("Unexpected result:\n got %s\nwant %s", got, want)
+	}
+
+	// Every item and user is fetched once, however often the query refers to it
+	for _, path := range []string{"item/1.json", "item/3.json", "user/bob.json"} {
+		if n := srv.Requests(path); n != 1 {
+			t.Errorf("Expected 1 request to %s, got %d", path, n)
+		}
+	}
+}
+
+func TestItemAndUser(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h, err := NewHandler(srv.Client())
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	data := exec(t, h, `query($id: Int!) {
+		item(id: $id) { id type }
+		missing: item(id: 99) { id }
+		nobody: user(id: "nobody") { id }
+		user(id: "alice") { karma submittedCount submitted(first: 2, offset: 1) { id } }
+		items(ids: [2, 99, 1]) { id }
+	}`, map[string]interface{}{"id": 3})
+
+	got, _ := json.Marshal(data)
+	want := `{"item":{"id":3,"type":"comment"},"items":[{"id":2},{"id":1}],"missing":null,"nobody":null,` +
+		`"user":{"karma":42,"submitted":[{"id":4},{"id":1}],"submittedCount":3}}`
+	if string(got) != want {
+		t.Errorf("Unexpected result:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestItemErrorsArePerItem(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+	srv.FailNext("item/2.json", http.StatusInternalServerError, 1)
+
+	h, err := NewHandler(srv.Client(hnapi.WithMaxRetries(0)))
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	// Item 99 does not exist, which is not an error, but the failure of item 2 is
+	resp := h.Exec(context.Background(), `{ items(ids: [99, 2, 1]) { id } }`, "", nil)
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Error(), "item 2") {
+		t.Errorf("Expected one error for item 2, got %v", resp.Errors)
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h, err := NewHandler(srv.Client(), WithMaxDepth(2))
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	resp := h.Exec(context.Background(), `{ item(id: 1) { kids { kids { id } } } }`, "", nil)
+	if len(resp.Errors) == 0 {
+		t.Errorf("Expected a depth error")
+	}
+}
+
+func TestServeHTTPRejectsGet(t *testing.T) {
+	srv := newServer()
+	defer srv.Close()
+
+	h, err := NewHandler(srv.Client())
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql", strings.NewReader("")))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+}
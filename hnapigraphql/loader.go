@@ -0,0 +1,177 @@
+package hnapigraphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// loader batches and memoizes lookups made during one GraphQL request. Keys
+// requested within the batch wait of each other, such as the kids of sibling
+// comments, are fetched together with a single batch call.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch fetchFunc[K, V]
+	wait  time.Duration
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+}
+
+// fetchFunc fetches a batch of keys. It returns the values of the keys that exist
+// and the errors of the keys that failed; keys in neither map do not exist.
+type fetchFunc[K comparable, V any] func(context.Context, []K) (map[K]V, map[K]error)
+
+// result is the eventual value of a key.
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+// newLoader creates a loader that fetches batches with fetch.
+func newLoader[K comparable, V any](ctx context.Context, wait time.Duration, fetch fetchFunc[K, V]) *loader[K, V] {
+	return &loader[K, V]{ctx: ctx, fetch: fetch, wait: wait, results: make(map[K]*result[V])}
+}
+
+// load returns the value of key and whether it exists.
+func (l *loader[K, V]) load(ctx context.Context, key K) (V, bool, error) {
+	r := l.request(key)
+	select {
+	case <-r.done:
+		return r.value, r.found, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// loadMany returns the existing values of keys, in order, and the first error.
+func (l *loader[K, V]) loadMany(ctx context.Context, keys []K) ([]V, error) {
+	pending := make([]*result[V], len(keys))
+	for i, key := range keys {
+		pending[i] = l.request(key)
+	}
+
+	values := make([]V, 0, len(keys))
+	var firstErr error
+	for _, r := range pending {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if r.err != nil && firstErr == nil {
+			firstErr = r.err
+		}
+		if r.found {
+			values = append(values, r.value)
+		}
+	}
+	return values, firstErr
+}
+
+// request returns the result for key, queueing it for the next batch if it has
+// not been requested before.
+func (l *loader[K, V]) request(key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+
+	r := &result[V]{done: make(chan struct{})}
+	l.results[key] = r
+	l.pending = append(l.pending, key)
+	if len(l.pending) == 1 {
+		time.AfterFunc(l.wait, l.dispatch)
+	}
+	return r
+}
+
+// dispatch fetches the pending keys as one batch.
+func (l *loader[K, V]) dispatch() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	values, errs := l.fetch(l.ctx, keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		r := l.results[key]
+		r.value, r.found = values[key]
+		if !r.found {
+			r.err = errs[key]
+		}
+		close(r.done)
+	}
+}
+
+// loaders holds the loaders of one request.
+type loaders struct {
+	items *loader[int, *hnapi.Item]
+	users *loader[string, *hnapi.User]
+}
+
+// loadersKey is the context key of the request's loaders.
+type loadersKey struct{}
+
+// withLoaders returns a context carrying fresh loaders for one request.
+func withLoaders(ctx context.Context, client *hnapi.Client, wait time.Duration) context.Context {
+	l := &loaders{
+		items: newLoader(ctx, wait, func(ctx context.Context, ids []int) (map[int]*hnapi.Item, map[int]error) {
+			found := make(map[int]*hnapi.Item, len(ids))
+			errs := make(map[int]error)
+			for _, result := range client.GetItemsBatchResults(ctx, ids) {
+				switch {
+				case result.Err == nil:
+					found[result.ID] = result.Item
+				case ignoreNotFound(result.Err) != nil:
+					errs[result.ID] = result.Err
+				}
+			}
+			return found, errs
+		}),
+		users: newLoader(ctx, wait, func(ctx context.Context, names []string) (map[string]*hnapi.User, map[string]error) {
+			users, err := client.GetUsersBatch(ctx, names)
+			found := make(map[string]*hnapi.User, len(users))
+			for _, user := range users {
+				found[user.ID] = user
+			}
+			errs := make(map[string]error)
+			if ignoreNotFound(err) == nil {
+				return found, errs
+			}
+
+			// The batch reports only its first error, so the users it left out are
+			// looked up one by one to tell missing users from failed ones
+			for _, name := range names {
+				if _, ok := found[name]; ok {
+					continue
+				}
+				user, err := client.GetUser(ctx, name)
+				switch {
+				case err == nil:
+					found[name] = user
+				case ignoreNotFound(err) != nil:
+					errs[name] = err
+				}
+			}
+			return found, errs
+		}),
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns the request's loaders.
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
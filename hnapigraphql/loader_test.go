@@ -0,0 +1,82 @@
+package hnapigraphql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]int
+
+	l := newLoader(context.Background(), 5*time.Millisecond, func(_ context.Context, keys []int) (map[int]string, map[int]error) {
+		mu.Lock()
+		batches = append(batches, append([]int(nil), keys...))
+		mu.Unlock()
+
+		values := make(map[int]string)
+		for _, key := range keys {
+			if key != 3 {
+				values[key] = string(rune('a' + key))
+			}
+		}
+		return values, nil
+	})
+
+	var wg sync.WaitGroup
+	for _, key := range []int{1, 2, 3, 2, 1} {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			value, found, err := l.load(context.Background(), key)
+			if err != nil || found != (key != 3) || (found && value != string(rune('a'+key))) {
+				t.Errorf("load(%d) = %q, %v, %v", key, value, found, err)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if len(batches) != 1 {
+		t.Fatalf("Expected 1 batch, got %v", batches)
+	}
+	sort.Ints(batches[0])
+	if len(batches[0]) != 3 {
+		t.Errorf("Expected keys to be deduplicated, got %v", batches[0])
+	}
+
+	// Memoized keys are not fetched again
+	values, err := l.loadMany(context.Background(), []int{2, 3, 1})
+	if err != nil || len(values) != 2 || values[0] != "c" || values[1] != "b" {
+		t.Errorf("loadMany() = %v, %v", values, err)
+	}
+	if len(batches) != 1 {
+		t.Errorf("Expected no further batches, got %v", batches)
+	}
+}
+
+func TestLoaderReportsErrors(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	l := newLoader(context.Background(), time.Millisecond, func(_ context.Context, keys []int) (map[int]int, map[int]error) {
+		return map[int]int{1: 1}, map[int]error{3: errFetch}
+	})
+
+	values, err := l.loadMany(context.Background(), []int{1, 2, 3})
+	if !errors.Is(err, errFetch) {
+		t.Errorf("Expected fetch error, got %v", err)
+	}
+	if len(values) != 1 || values[0] != 1 {
+		t.Errorf("Expected the loaded value, got %v", values)
+	}
+
+	// Errors belong to the keys that failed, not to missing keys in the same batch
+	if _, found, err := l.load(context.Background(), 2); found || err != nil {
+		t.Errorf("load(2) = %v, %v, want a missing key without an error", found, err)
+	}
+	if _, _, err := l.load(context.Background(), 3); !errors.Is(err, errFetch) {
+		t.Errorf("load(3) error = %v, want %v", err, errFetch)
+	}
+}
@@ -0,0 +1,205 @@
+package hnapigraphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yarlson/hnapi"
+)
+
+// storyLists maps StoryList enum values to lists.
+var storyLists = map[string]hnapi.List{
+	"TOP":  hnapi.ListTop,
+	"NEW":  hnapi.ListNew,
+	"BEST": hnapi.ListBest,
+	"ASK":  hnapi.ListAsk,
+	"SHOW": hnapi.ListShow,
+	"JOB":  hnapi.ListJob,
+}
+
+// queryResolver resolves the Query type.
+type queryResolver struct {
+	client *hnapi.Client
+}
+
+// Item resolves Query.item.
+func (r *queryResolver) Item(ctx context.Context, args struct{ ID int32 }) (*itemResolver, error) {
+	return loadItem(ctx, int(args.ID))
+}
+
+// Items resolves Query.items.
+func (r *queryResolver) Items(ctx context.Context, args struct{ IDs []int32 }) ([]*itemResolver, error) {
+	ids := make([]int, len(args.IDs))
+	for i, id := range args.IDs {
+		ids[i] = int(id)
+	}
+	return loadItems(ctx, ids)
+}
+
+// User resolves Query.user.
+func (r *queryResolver) User(ctx context.Context, args struct{ ID string }) (*userResolver, error) {
+	return loadUser(ctx, args.ID)
+}
+
+// Stories resolves Query.stories.
+func (r *queryResolver) Stories(ctx context.Context, args struct {
+	List   string
+	First  int32
+	Offset int32
+}) ([]*itemResolver, error) {
+	list, ok := storyLists[args.List]
+	if !ok {
+		return nil, fmt.Errorf("unknown list %s", args.List)
+	}
+
+	ids, err := r.client.GetList(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	return loadItems(ctx, page(ids, &args.First, args.Offset))
+}
+
+// MaxItem resolves Query.maxItem.
+func (r *queryResolver) MaxItem(ctx context.Context) (int32, error) {
+	id, err := r.client.GetMaxItem(ctx)
+	return int32(id), err
+}
+
+// itemResolver resolves the Item type.
+type itemResolver struct {
+	item *hnapi.Item
+}
+
+func (r *itemResolver) ID() int32          { return int32(r.item.ID) }
+func (r *itemResolver) Type() string       { return string(r.item.Type) }
+func (r *itemResolver) Author() *string    { return optional(r.item.By) }
+func (r *itemResolver) Time() int32        { return int32(r.item.Time) }
+func (r *itemResolver) Text() *string      { return optional(r.item.Text) }
+func (r *itemResolver) PlainText() *string { return optional(r.item.PlainText()) }
+func (r *itemResolver) Deleted() bool      { return r.item.Deleted }
+func (r *itemResolver) Dead() bool         { return r.item.Dead }
+func (r *itemResolver) KidCount() int32    { return int32(len(r.item.Kids)) }
+func (r *itemResolver) URL() *string       { return optional(r.item.URL) }
+func (r *itemResolver) Domain() *string    { return optional(r.item.Domain()) }
+func (r *itemResolver) Score() int32       { return int32(r.item.Score) }
+func (r *itemResolver) Title() *string     { return optional(r.item.Title) }
+func (r *itemResolver) Descendants() int32 { return int32(r.item.Descendants) }
+func (r *itemResolver) HnLink() string     { return r.item.HNLink() }
+
+// By resolves Item.by.
+func (r *itemResolver) By(ctx context.Context) (*userResolver, error) {
+	if r.item.By == "" {
+		return nil, nil
+	}
+	return loadUser(ctx, r.item.By)
+}
+
+// Parent resolves Item.parent.
+func (r *itemResolver) Parent(ctx context.Context) (*itemResolver, error) {
+	if r.item.Parent == 0 {
+		return nil, nil
+	}
+	return loadItem(ctx, r.item.Parent)
+}
+
+// Poll resolves Item.poll.
+func (r *itemResolver) Poll(ctx context.Context) (*itemResolver, error) {
+	if r.item.Poll == 0 {
+		return nil, nil
+	}
+	return loadItem(ctx, r.item.Poll)
+}
+
+// Kids resolves Item.kids.
+func (r *itemResolver) Kids(ctx context.Context, args struct {
+	First  *int32
+	Offset int32
+}) ([]*itemResolver, error) {
+	return loadItems(ctx, page(r.item.Kids, args.First, args.Offset))
+}
+
+// Parts resolves Item.parts.
+func (r *itemResolver) Parts(ctx context.Context) ([]*itemResolver, error) {
+	return loadItems(ctx, r.item.Parts)
+}
+
+// userResolver resolves the User type.
+type userResolver struct {
+	user *hnapi.User
+}
+
+func (r *userResolver) ID() string            { return r.user.ID }
+func (r *userResolver) Created() int32        { return int32(r.user.Created) }
+func (r *userResolver) Karma() int32          { return int32(r.user.Karma) }
+func (r *userResolver) About() *string        { return optional(r.user.About) }
+func (r *userResolver) SubmittedCount() int32 { return int32(len(r.user.Submitted)) }
+func (r *userResolver) HnLink() string        { return r.user.HNLink() }
+
+// Submitted resolves User.submitted.
+func (r *userResolver) Submitted(ctx context.Context, args struct {
+	First  int32
+	Offset int32
+}) ([]*itemResolver, error) {
+	return loadItems(ctx, page(r.user.Submitted, &args.First, args.Offset))
+}
+
+// loadItem loads one item through the request's loader. Items that do not exist
+// resolve to null.
+func loadItem(ctx context.Context, id int) (*itemResolver, error) {
+	item, found, err := loadersFrom(ctx).items.load(ctx, id)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &itemResolver{item: item}, nil
+}
+
+// loadItems loads items through the request's loader, leaving out items that do
+// not exist.
+func loadItems(ctx context.Context, ids []int) ([]*itemResolver, error) {
+	items, err := loadersFrom(ctx).items.loadMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*itemResolver, len(items))
+	for i, item := range items {
+		resolvers[i] = &itemResolver{item: item}
+	}
+	return resolvers, nil
+}
+
+// loadUser loads one user through the request's loader. Users that do not exist
+// resolve to null.
+func loadUser(ctx context.Context, username string) (*userResolver, error) {
+	user, found, err := loadersFrom(ctx).users.load(ctx, username)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &userResolver{user: user}, nil
+}
+
+// page returns the window of ids selected by the first and offset arguments. A
+// nil first selects everything after offset.
+func page(ids []int, first *int32, offset int32) []int {
+	start := 0
+	if offset > 0 {
+		start = int(offset)
+	}
+	if start >= len(ids) {
+		return nil
+	}
+	ids = ids[start:]
+
+	if first != nil && *first >= 0 && int(*first) < len(ids) {
+		ids = ids[:*first]
+	}
+	return ids
+}
+
+// optional returns nil for an empty string, which GraphQL reports as null.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
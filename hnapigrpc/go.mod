@@ -0,0 +1,18 @@
+module github.com/yarlson/hnapi/hnapigrpc
+
+go 1.23
+
+require (
+	github.com/yarlson/hnapi v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.66.2
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
+)
+
+replace github.com/yarlson/hnapi => ../
@@ -0,0 +1,1168 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: hnapi.proto
+
+// Package hnapi.v1 serves Hacker News data through a Go-hosted hnapi client.
+
+package hnapipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// List is a Hacker News story list.
+type List int32
+
+const (
+	List_LIST_UNSPECIFIED List = 0
+	List_LIST_TOP         List = 1
+	List_LIST_NEW         List = 2
+	List_LIST_BEST        List = 3
+	List_LIST_ASK         List = 4
+	List_LIST_SHOW        List = 5
+	List_LIST_JOB         List = 6
+)
+
+// Enum value maps for List.
+var (
+	List_name = map[int32]string{
+		0: "LIST_UNSPECIFIED",
+		1: "LIST_TOP",
+		2: "LIST_NEW",
+		3: "LIST_BEST",
+		4: "LIST_ASK",
+		5: "LIST_SHOW",
+		6: "LIST_JOB",
+	}
+	List_value = map[string]int32{
+		"LIST_UNSPECIFIED": 0,
+		"LIST_TOP":         1,
+		"LIST_NEW":         2,
+		"LIST_BEST":        3,
+		"LIST_ASK":         4,
+		"LIST_SHOW":        5,
+		"LIST_JOB":         6,
+	}
+)
+
+func (x List) Enum() *List {
+	p := new(List)
+	*p = x
+	return p
+}
+
+func (x List) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (List) Descriptor() protoreflect.EnumDescriptor {
+	return file_hnapi_proto_enumTypes[0].Descriptor()
+}
+
+func (List) Type() protoreflect.EnumType {
+	return &file_hnapi_proto_enumTypes[0]
+}
+
+func (x List) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use List.Descriptor instead.
+func (List) EnumDescriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{0}
+}
+
+// Item is a story, comment, job, poll, or poll option.
+type Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Deleted bool  `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// One of "job", "story", "comment", "poll", or "pollopt".
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	By   string `protobuf:"bytes,4,opt,name=by,proto3" json:"by,omitempty"`
+	// Creation time in Unix seconds.
+	Time int64 `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	// HTML text.
+	Text        string  `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Dead        bool    `protobuf:"varint,7,opt,name=dead,proto3" json:"dead,omitempty"`
+	Parent      int64   `protobuf:"varint,8,opt,name=parent,proto3" json:"parent,omitempty"`
+	Poll        int64   `protobuf:"varint,9,opt,name=poll,proto3" json:"poll,omitempty"`
+	Kids        []int64 `protobuf:"varint,10,rep,packed,name=kids,proto3" json:"kids,omitempty"`
+	Url         string  `protobuf:"bytes,11,opt,name=url,proto3" json:"url,omitempty"`
+	Score       int64   `protobuf:"varint,12,opt,name=score,proto3" json:"score,omitempty"`
+	Title       string  `protobuf:"bytes,13,opt,name=title,proto3" json:"title,omitempty"`
+	Parts       []int64 `protobuf:"varint,14,rep,packed,name=parts,proto3" json:"parts,omitempty"`
+	Descendants int64   `protobuf:"varint,15,opt,name=descendants,proto3" json:"descendants,omitempty"`
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Item) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Item) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Item) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+func (x *Item) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Item) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Item) GetDead() bool {
+	if x != nil {
+		return x.Dead
+	}
+	return false
+}
+
+func (x *Item) GetParent() int64 {
+	if x != nil {
+		return x.Parent
+	}
+	return 0
+}
+
+func (x *Item) GetPoll() int64 {
+	if x != nil {
+		return x.Poll
+	}
+	return 0
+}
+
+func (x *Item) GetKids() []int64 {
+	if x != nil {
+		return x.Kids
+	}
+	return nil
+}
+
+func (x *Item) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Item) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Item) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Item) GetParts() []int64 {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *Item) GetDescendants() int64 {
+	if x != nil {
+		return x.Descendants
+	}
+	return 0
+}
+
+// User is a Hacker News user profile.
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Creation time in Unix seconds.
+	Created int64 `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Karma   int64 `protobuf:"varint,3,opt,name=karma,proto3" json:"karma,omitempty"`
+	// HTML self-description.
+	About     string  `protobuf:"bytes,4,opt,name=about,proto3" json:"about,omitempty"`
+	Submitted []int64 `protobuf:"varint,5,rep,packed,name=submitted,proto3" json:"submitted,omitempty"`
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *User) GetKarma() int64 {
+	if x != nil {
+		return x.Karma
+	}
+	return 0
+}
+
+func (x *User) GetAbout() string {
+	if x != nil {
+		return x.About
+	}
+	return ""
+}
+
+func (x *User) GetSubmitted() []int64 {
+	if x != nil {
+		return x.Submitted
+	}
+	return nil
+}
+
+// Updates are recently changed items and profiles.
+type Updates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items    []int64  `protobuf:"varint,1,rep,packed,name=items,proto3" json:"items,omitempty"`
+	Profiles []string `protobuf:"bytes,2,rep,name=profiles,proto3" json:"profiles,omitempty"`
+}
+
+func (x *Updates) Reset() {
+	*x = Updates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Updates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Updates) ProtoMessage() {}
+
+func (x *Updates) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Updates.ProtoReflect.Descriptor instead.
+func (*Updates) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Updates) GetItems() []int64 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Updates) GetProfiles() []string {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+type GetItemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetItemsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *GetItemsRequest) Reset() {
+	*x = GetItemsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsRequest) ProtoMessage() {}
+
+func (x *GetItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetItemsRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetItemsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetItemsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Item `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *GetItemsResponse) Reset() {
+	*x = GetItemsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsResponse) ProtoMessage() {}
+
+func (x *GetItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetItemsResponse) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List List `protobuf:"varint,1,opt,name=list,proto3,enum=hnapi.v1.List" json:"list,omitempty"`
+}
+
+func (x *GetListRequest) Reset() {
+	*x = GetListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListRequest) ProtoMessage() {}
+
+func (x *GetListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListRequest.ProtoReflect.Descriptor instead.
+func (*GetListRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetListRequest) GetList() List {
+	if x != nil {
+		return x.List
+	}
+	return List_LIST_UNSPECIFIED
+}
+
+type GetListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *GetListResponse) Reset() {
+	*x = GetListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListResponse) ProtoMessage() {}
+
+func (x *GetListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListResponse.ProtoReflect.Descriptor instead.
+func (*GetListResponse) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetListResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetMaxItemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetMaxItemRequest) Reset() {
+	*x = GetMaxItemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMaxItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaxItemRequest) ProtoMessage() {}
+
+func (x *GetMaxItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaxItemRequest.ProtoReflect.Descriptor instead.
+func (*GetMaxItemRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{9}
+}
+
+type GetMaxItemResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetMaxItemResponse) Reset() {
+	*x = GetMaxItemResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMaxItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaxItemResponse) ProtoMessage() {}
+
+func (x *GetMaxItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaxItemResponse.ProtoReflect.Descriptor instead.
+func (*GetMaxItemResponse) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMaxItemResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type StreamUpdatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamUpdatesRequest) Reset() {
+	*x = StreamUpdatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamUpdatesRequest) ProtoMessage() {}
+
+func (x *StreamUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{11}
+}
+
+type StreamFirehoseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamFirehoseRequest) Reset() {
+	*x = StreamFirehoseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hnapi_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamFirehoseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFirehoseRequest) ProtoMessage() {}
+
+func (x *StreamFirehoseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hnapi_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFirehoseRequest.ProtoReflect.Descriptor instead.
+func (*StreamFirehoseRequest) Descriptor() ([]byte, []int) {
+	return file_hnapi_proto_rawDescGZIP(), []int{12}
+}
+
+var File_hnapi_proto protoreflect.FileDescriptor
+
+var file_hnapi_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x68,
+	0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x22, 0xc6, 0x02, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x62, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x61, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x64, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x6b, 0x69, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74,
+	0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x73,
+	0x22, 0x7a, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x61, 0x72, 0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6b, 0x61, 0x72, 0x6d, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x62, 0x6f, 0x75,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x62, 0x6f, 0x75, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x22, 0x3b, 0x0a, 0x07,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x23, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x22, 0x38, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x34, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
+	0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x68,
+	0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x04, 0x6c, 0x69,
+	0x73, 0x74, 0x22, 0x23, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4d, 0x61,
+	0x78, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x24, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x46, 0x69, 0x72, 0x65, 0x68, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2a, 0x72, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x10, 0x4c,
+	0x49, 0x53, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x54, 0x4f, 0x50, 0x10, 0x01, 0x12,
+	0x0c, 0x0a, 0x08, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x4e, 0x45, 0x57, 0x10, 0x02, 0x12, 0x0d, 0x0a,
+	0x09, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x42, 0x45, 0x53, 0x54, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
+	0x4c, 0x49, 0x53, 0x54, 0x5f, 0x41, 0x53, 0x4b, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x49,
+	0x53, 0x54, 0x5f, 0x53, 0x48, 0x4f, 0x57, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x49, 0x53,
+	0x54, 0x5f, 0x4a, 0x4f, 0x42, 0x10, 0x06, 0x32, 0xcd, 0x03, 0x0a, 0x0a, 0x48, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x4e, 0x65, 0x77, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65,
+	0x6d, 0x12, 0x18, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x68, 0x6e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x41, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x19, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x68, 0x6e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18,
+	0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x78, 0x49, 0x74, 0x65,
+	0x6d, 0x12, 0x1b, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x4d, 0x61, 0x78, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x78,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0d,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x2e,
+	0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x30, 0x01, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x69, 0x72, 0x65,
+	0x68, 0x6f, 0x73, 0x65, 0x12, 0x1f, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x69, 0x72, 0x65, 0x68, 0x6f, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x74, 0x65, 0x6d, 0x30, 0x01, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x61, 0x72, 0x6c, 0x73, 0x6f, 0x6e, 0x2f, 0x68, 0x6e,
+	0x61, 0x70, 0x69, 0x2f, 0x68, 0x6e, 0x61, 0x70, 0x69, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x68, 0x6e,
+	0x61, 0x70, 0x69, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hnapi_proto_rawDescOnce sync.Once
+	file_hnapi_proto_rawDescData = file_hnapi_proto_rawDesc
+)
+
+func file_hnapi_proto_rawDescGZIP() []byte {
+	file_hnapi_proto_rawDescOnce.Do(func() {
+		file_hnapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_hnapi_proto_rawDescData)
+	})
+	return file_hnapi_proto_rawDescData
+}
+
+var file_hnapi_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_hnapi_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_hnapi_proto_goTypes = []any{
+	(List)(0),                     // 0: hnapi.v1.List
+	(*Item)(nil),                  // 1: hnapi.v1.Item
+	(*User)(nil),                  // 2: hnapi.v1.User
+	(*Updates)(nil),               // 3: hnapi.v1.Updates
+	(*GetItemRequest)(nil),        // 4: hnapi.v1.GetItemRequest
+	(*GetItemsRequest)(nil),       // 5: hnapi.v1.GetItemsRequest
+	(*GetItemsResponse)(nil),      // 6: hnapi.v1.GetItemsResponse
+	(*GetUserRequest)(nil),        // 7: hnapi.v1.GetUserRequest
+	(*GetListRequest)(nil),        // 8: hnapi.v1.GetListRequest
+	(*GetListResponse)(nil),       // 9: hnapi.v1.GetListResponse
+	(*GetMaxItemRequest)(nil),     // 10: hnapi.v1.GetMaxItemRequest
+	(*GetMaxItemResponse)(nil),    // 11: hnapi.v1.GetMaxItemResponse
+	(*StreamUpdatesRequest)(nil),  // 12: hnapi.v1.StreamUpdatesRequest
+	(*StreamFirehoseRequest)(nil), // 13: hnapi.v1.StreamFirehoseRequest
+}
+var file_hnapi_proto_depIdxs = []int32{
+	1,  // 0: hnapi.v1.GetItemsResponse.items:type_name -> hnapi.v1.Item
+	0,  // 1: hnapi.v1.GetListRequest.list:type_name -> hnapi.v1.List
+	4,  // 2: hnapi.v1.HackerNews.GetItem:input_type -> hnapi.v1.GetItemRequest
+	5,  // 3: hnapi.v1.HackerNews.GetItems:input_type -> hnapi.v1.GetItemsRequest
+	7,  // 4: hnapi.v1.HackerNews.GetUser:input_type -> hnapi.v1.GetUserRequest
+	8,  // 5: hnapi.v1.HackerNews.GetList:input_type -> hnapi.v1.GetListRequest
+	10, // 6: hnapi.v1.HackerNews.GetMaxItem:input_type -> hnapi.v1.GetMaxItemRequest
+	12, // 7: hnapi.v1.HackerNews.StreamUpdates:input_type -> hnapi.v1.StreamUpdatesRequest
+	13, // 8: hnapi.v1.HackerNews.StreamFirehose:input_type -> hnapi.v1.StreamFirehoseRequest
+	1,  // 9: hnapi.v1.HackerNews.GetItem:output_type -> hnapi.v1.Item
+	6,  // 10: hnapi.v1.HackerNews.GetItems:output_type -> hnapi.v1.GetItemsResponse
+	2,  // 11: hnapi.v1.HackerNews.GetUser:output_type -> hnapi.v1.User
+	9,  // 12: hnapi.v1.HackerNews.GetList:output_type -> hnapi.v1.GetListResponse
+	11, // 13: hnapi.v1.HackerNews.GetMaxItem:output_type -> hnapi.v1.GetMaxItemResponse
+	3,  // 14: hnapi.v1.HackerNews.StreamUpdates:output_type -> hnapi.v1.Updates
+	1,  // 15: hnapi.v1.HackerNews.StreamFirehose:output_type -> hnapi.v1.Item
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_hnapi_proto_init() }
+func file_hnapi_proto_init() {
+	if File_hnapi_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hnapi_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*User); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Updates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetItemsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*GetItemsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*GetListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMaxItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GetMaxItemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamUpdatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hnapi_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamFirehoseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hnapi_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hnapi_proto_goTypes,
+		DependencyIndexes: file_hnapi_proto_depIdxs,
+		EnumInfos:         file_hnapi_proto_enumTypes,
+		MessageInfos:      file_hnapi_proto_msgTypes,
+	}.Build()
+	File_hnapi_proto = out.File
+	file_hnapi_proto_rawDesc = nil
+	file_hnapi_proto_goTypes = nil
+	file_hnapi_proto_depIdxs = nil
+}
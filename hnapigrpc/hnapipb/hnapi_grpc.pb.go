@@ -0,0 +1,376 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: hnapi.proto
+
+// Package hnapi.v1 serves Hacker News data through a Go-hosted hnapi client.
+
+package hnapipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	HackerNews_GetItem_FullMethodName        = "/hnapi.v1.HackerNews/GetItem"
+	HackerNews_GetItems_FullMethodName       = "/hnapi.v1.HackerNews/GetItems"
+	HackerNews_GetUser_FullMethodName        = "/hnapi.v1.HackerNews/GetUser"
+	HackerNews_GetList_FullMethodName        = "/hnapi.v1.HackerNews/GetList"
+	HackerNews_GetMaxItem_FullMethodName     = "/hnapi.v1.HackerNews/GetMaxItem"
+	HackerNews_StreamUpdates_FullMethodName  = "/hnapi.v1.HackerNews/StreamUpdates"
+	HackerNews_StreamFirehose_FullMethodName = "/hnapi.v1.HackerNews/StreamFirehose"
+)
+
+// HackerNewsClient is the client API for HackerNews service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HackerNews exposes the Hacker News API.
+type HackerNewsClient interface {
+	// GetItem returns an item by ID. Missing items fail with NOT_FOUND.
+	GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error)
+	// GetItems returns items by ID, in order. Missing items are left out.
+	GetItems(ctx context.Context, in *GetItemsRequest, opts ...grpc.CallOption) (*GetItemsResponse, error)
+	// GetUser returns a user by username. Missing users fail with NOT_FOUND.
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// GetList returns the item IDs of a story list.
+	GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*GetListResponse, error)
+	// GetMaxItem returns the current largest item ID.
+	GetMaxItem(ctx context.Context, in *GetMaxItemRequest, opts ...grpc.CallOption) (*GetMaxItemResponse, error)
+	// StreamUpdates streams changed item IDs and usernames as they are reported.
+	StreamUpdates(ctx context.Context, in *StreamUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Updates], error)
+	// StreamFirehose streams every new item as it is created.
+	StreamFirehose(ctx context.Context, in *StreamFirehoseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Item], error)
+}
+
+type hackerNewsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHackerNewsClient(cc grpc.ClientConnInterface) HackerNewsClient {
+	return &hackerNewsClient{cc}
+}
+
+func (c *hackerNewsClient) GetItem(ctx context.Context, in *GetItemRequest, opts ...grpc.CallOption) (*Item, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Item)
+	err := c.cc.Invoke(ctx, HackerNews_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hackerNewsClient) GetItems(ctx context.Context, in *GetItemsRequest, opts ...grpc.CallOption) (*GetItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemsResponse)
+	err := c.cc.Invoke(ctx, HackerNews_GetItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hackerNewsClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, HackerNews_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hackerNewsClient) GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*GetListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListResponse)
+	err := c.cc.Invoke(ctx, HackerNews_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hackerNewsClient) GetMaxItem(ctx context.Context, in *GetMaxItemRequest, opts ...grpc.CallOption) (*GetMaxItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMaxItemResponse)
+	err := c.cc.Invoke(ctx, HackerNews_GetMaxItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hackerNewsClient) StreamUpdates(ctx context.Context, in *StreamUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Updates], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HackerNews_ServiceDesc.Streams[0], HackerNews_StreamUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamUpdatesRequest, Updates]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HackerNews_StreamUpdatesClient = grpc.ServerStreamingClient[Updates]
+
+func (c *hackerNewsClient) StreamFirehose(ctx context.Context, in *StreamFirehoseRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Item], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HackerNews_ServiceDesc.Streams[1], HackerNews_StreamFirehose_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamFirehoseRequest, Item]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HackerNews_StreamFirehoseClient = grpc.ServerStreamingClient[Item]
+
+// HackerNewsServer is the server API for HackerNews service.
+// All implementations must embed UnimplementedHackerNewsServer
+// for forward compatibility.
+//
+// HackerNews exposes the Hacker News API.
+type HackerNewsServer interface {
+	// GetItem returns an item by ID. Missing items fail with NOT_FOUND.
+	GetItem(context.Context, *GetItemRequest) (*Item, error)
+	// GetItems returns items by ID, in order. Missing items are left out.
+	GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error)
+	// GetUser returns a user by username. Missing users fail with NOT_FOUND.
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// GetList returns the item IDs of a story list.
+	GetList(context.Context, *GetListRequest) (*GetListResponse, error)
+	// GetMaxItem returns the current largest item ID.
+	GetMaxItem(context.Context, *GetMaxItemRequest) (*GetMaxItemResponse, error)
+	// StreamUpdates streams changed item IDs and usernames as they are reported.
+	StreamUpdates(*StreamUpdatesRequest, grpc.ServerStreamingServer[Updates]) error
+	// StreamFirehose streams every new item as it is created.
+	StreamFirehose(*StreamFirehoseRequest, grpc.ServerStreamingServer[Item]) error
+	mustEmbedUnimplementedHackerNewsServer()
+}
+
+// UnimplementedHackerNewsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHackerNewsServer struct{}
+
+func (UnimplementedHackerNewsServer) GetItem(context.Context, *GetItemRequest) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedHackerNewsServer) GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItems not implemented")
+}
+func (UnimplementedHackerNewsServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedHackerNewsServer) GetList(context.Context, *GetListRequest) (*GetListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedHackerNewsServer) GetMaxItem(context.Context, *GetMaxItemRequest) (*GetMaxItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaxItem not implemented")
+}
+func (UnimplementedHackerNewsServer) StreamUpdates(*StreamUpdatesRequest, grpc.ServerStreamingServer[Updates]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUpdates not implemented")
+}
+func (UnimplementedHackerNewsServer) StreamFirehose(*StreamFirehoseRequest, grpc.ServerStreamingServer[Item]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFirehose not implemented")
+}
+func (UnimplementedHackerNewsServer) mustEmbedUnimplementedHackerNewsServer() {}
+func (UnimplementedHackerNewsServer) testEmbeddedByValue()                    {}
+
+// UnsafeHackerNewsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HackerNewsServer will
+// result in compilation errors.
+type UnsafeHackerNewsServer interface {
+	mustEmbedUnimplementedHackerNewsServer()
+}
+
+func RegisterHackerNewsServer(s grpc.ServiceRegistrar, srv HackerNewsServer) {
+	// If the following call pancis, it indicates UnimplementedHackerNewsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HackerNews_ServiceDesc, srv)
+}
+
+func _HackerNews_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HackerNewsServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HackerNews_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HackerNewsServer).GetItem(ctx, req.(*GetItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HackerNews_GetItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HackerNewsServer).GetItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HackerNews_GetItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HackerNewsServer).GetItems(ctx, req.(*GetItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HackerNews_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HackerNewsServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HackerNews_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HackerNewsServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HackerNews_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HackerNewsServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HackerNews_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HackerNewsServer).GetList(ctx, req.(*GetListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HackerNews_GetMaxItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaxItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HackerNewsServer).GetMaxItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HackerNews_GetMaxItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HackerNewsServer).GetMaxItem(ctx, req.(*GetMaxItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HackerNews_StreamUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HackerNewsServer).StreamUpdates(m, &grpc.GenericServerStream[StreamUpdatesRequest, Updates]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HackerNews_StreamUpdatesServer = grpc.ServerStreamingServer[Updates]
+
+func _HackerNews_StreamFirehose_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFirehoseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HackerNewsServer).StreamFirehose(m, &grpc.GenericServerStream[StreamFirehoseRequest, Item]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HackerNews_StreamFirehoseServer = grpc.ServerStreamingServer[Item]
+
+// HackerNews_ServiceDesc is the grpc.ServiceDesc for HackerNews service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HackerNews_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hnapi.v1.HackerNews",
+	HandlerType: (*HackerNewsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetItem",
+			Handler:    _HackerNews_GetItem_Handler,
+		},
+		{
+			MethodName: "GetItems",
+			Handler:    _HackerNews_GetItems_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _HackerNews_GetUser_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _HackerNews_GetList_Handler,
+		},
+		{
+			MethodName: "GetMaxItem",
+			Handler:    _HackerNews_GetMaxItem_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamUpdates",
+			Handler:       _HackerNews_StreamUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamFirehose",
+			Handler:       _HackerNews_StreamFirehose_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hnapi.proto",
+}
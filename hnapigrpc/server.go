@@ -0,0 +1,254 @@
+// Package hnapigrpc serves the Hacker News API over gRPC, so services written in
+// any language can consume Hacker News data through one Go-hosted client.
+//
+// The service is defined in hnapipb/hnapi.proto. It offers unary lookups of items,
+// users, and lists, and server-streaming RPCs for the updates feed and the firehose
+// of new items:
+//
+//	s := grpc.NewServer()
+//	hnapigrpc.Register(s, hnapi.NewClient())
+//	s.Serve(listener)
+//
+// Requests go through the client, so its caching, retries, and concurrency limits
+// apply to every caller.
+//
+// It is a separate module, github.com/yarlson/hnapi/hnapigrpc, so gRPC and
+// protobuf are only pulled into builds that use it.
+package hnapigrpc
+
+//go:generate protoc -I hnapipb --go_out=hnapipb --go_opt=paths=source_relative --go-grpc_out=hnapipb --go-grpc_opt=paths=source_relative hnapi.proto
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapigrpc/hnapipb"
+)
+
+// lists maps List enum values to lists.
+var lists = map[hnapipb.List]hnapi.List{
+	hnapipb.List_LIST_TOP:  hnapi.ListTop,
+	hnapipb.List_LIST_NEW:  hnapi.ListNew,
+	hnapipb.List_LIST_BEST: hnapi.ListBest,
+	hnapipb.List_LIST_ASK:  hnapi.ListAsk,
+	hnapipb.List_LIST_SHOW: hnapi.ListShow,
+	hnapipb.List_LIST_JOB:  hnapi.ListJob,
+}
+
+// Server implements the HackerNews gRPC service with an hnapi.Client.
+type Server struct {
+	hnapipb.UnimplementedHackerNewsServer
+
+	client *hnapi.Client
+}
+
+// NewServer creates a server backed by client.
+func NewServer(client *hnapi.Client) *Server {
+	return &Server{client: client}
+}
+
+// Register registers a server backed by client with s.
+func Register(s grpc.ServiceRegistrar, client *hnapi.Client) {
+	hnapipb.RegisterHackerNewsServer(s, NewServer(client))
+}
+
+// GetItem returns an item by ID.
+func (s *Server) GetItem(ctx context.Context, req *hnapipb.GetItemRequest) (*hnapipb.Item, error) {
+	item, err := s.client.GetItem(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ItemToProto(item), nil
+}
+
+// GetItems returns items by ID, leaving out items that do not exist. Any other
+// failure of an item fails the call.
+func (s *Server) GetItems(ctx context.Context, req *hnapipb.GetItemsRequest) (*hnapipb.GetItemsResponse, error) {
+	results := s.client.GetItemsBatchResults(ctx, toInts(req.GetIds()))
+
+	resp := &hnapipb.GetItemsResponse{Items: make([]*hnapipb.Item, 0, len(results))}
+	for _, r := range results {
+		switch {
+		case errors.Is(r.Err, hnapi.ErrNotFound):
+			continue
+		case r.Err != nil:
+			return nil, toStatus(r.Err)
+		}
+		resp.Items = append(resp.Items, ItemToProto(r.Item))
+	}
+	return resp, nil
+}
+
+// GetUser returns a user by username.
+func (s *Server) GetUser(ctx context.Context, req *hnapipb.GetUserRequest) (*hnapipb.User, error) {
+	user, err := s.client.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return UserToProto(user), nil
+}
+
+// GetList returns the item IDs of a story list.
+func (s *Server) GetList(ctx context.Context, req *hnapipb.GetListRequest) (*hnapipb.GetListResponse, error) {
+	list, ok := lists[req.GetList()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown list %v", req.GetList())
+	}
+
+	ids, err := s.client.GetList(ctx, list)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &hnapipb.GetListResponse{Ids: toInt64s(ids)}, nil
+}
+
+// GetMaxItem returns the current largest item ID.
+func (s *Server) GetMaxItem(ctx context.Context, _ *hnapipb.GetMaxItemRequest) (*hnapipb.GetMaxItemResponse, error) {
+	id, err := s.client.GetMaxItem(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &hnapipb.GetMaxItemResponse{Id: int64(id)}, nil
+}
+
+// StreamUpdates streams the updates feed until the caller goes away.
+func (s *Server) StreamUpdates(_ *hnapipb.StreamUpdatesRequest, stream hnapipb.HackerNews_StreamUpdatesServer) error {
+	ctx := stream.Context()
+
+	updatesCh, err := s.client.StartUpdates(ctx)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for updates := range updatesCh {
+		msg := &hnapipb.Updates{Items: toInt64s(updates.Items), Profiles: updates.Profiles}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return toStatus(ctx.Err())
+}
+
+// StreamFirehose streams new items until the caller goes away.
+func (s *Server) StreamFirehose(_ *hnapipb.StreamFirehoseRequest, stream hnapipb.HackerNews_StreamFirehoseServer) error {
+	ctx := stream.Context()
+
+	itemsCh, err := s.client.StartFirehose(ctx)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for item := range itemsCh {
+		if err := stream.Send(ItemToProto(item)); err != nil {
+			return err
+		}
+	}
+	return toStatus(ctx.Err())
+}
+
+// ItemToProto converts an item to its protobuf message.
+func ItemToProto(item *hnapi.Item) *hnapipb.Item {
+	return &hnapipb.Item{
+		Id:          int64(item.ID),
+		Deleted:     item.Deleted,
+		Type:        string(item.Type),
+		By:          item.By,
+		Time:        item.Time,
+		Text:        item.Text,
+		Dead:        item.Dead,
+		Parent:      int64(item.Parent),
+		Poll:        int64(item.Poll),
+		Kids:        toInt64s(item.Kids),
+		Url:         item.URL,
+		Score:       int64(item.Score),
+		Title:       item.Title,
+		Parts:       toInt64s(item.Parts),
+		Descendants: int64(item.Descendants),
+	}
+}
+
+// ItemFromProto converts a protobuf message to an item.
+func ItemFromProto(msg *hnapipb.Item) *hnapi.Item {
+	return &hnapi.Item{
+		ID:          int(msg.GetId()),
+		Deleted:     msg.GetDeleted(),
+		Type:        hnapi.ItemType(msg.GetType()),
+		By:          msg.GetBy(),
+		Time:        msg.GetTime(),
+		Text:        msg.GetText(),
+		Dead:        msg.GetDead(),
+		Parent:      int(msg.GetParent()),
+		Poll:        int(msg.GetPoll()),
+		Kids:        toInts(msg.GetKids()),
+		URL:         msg.GetUrl(),
+		Score:       int(msg.GetScore()),
+		Title:       msg.GetTitle(),
+		Parts:       toInts(msg.GetParts()),
+		Descendants: int(msg.GetDescendants()),
+	}
+}
+
+// UserToProto converts a user to its protobuf message.
+func UserToProto(user *hnapi.User) *hnapipb.User {
+	return &hnapipb.User{
+		Id:        user.ID,
+		Created:   user.Created,
+		Karma:     int64(user.Karma),
+		About:     user.About,
+		Submitted: toInt64s(user.Submitted),
+	}
+}
+
+// UserFromProto converts a protobuf message to a user.
+func UserFromProto(msg *hnapipb.User) *hnapi.User {
+	return &hnapi.User{
+		ID:        msg.GetId(),
+		Created:   msg.GetCreated(),
+		Karma:     int(msg.GetKarma()),
+		About:     msg.GetAbout(),
+		Submitted: toInts(msg.GetSubmitted()),
+	}
+}
+
+// toStatus converts a client error to a gRPC status error.
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, hnapi.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// toInt64s converts IDs to their protobuf representation.
+func toInt64s(ids []int) []int64 {
+	if ids == nil {
+		return nil
+	}
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+	return out
+}
+
+// toInts converts protobuf IDs to ints.
+func toInts(ids []int64) []int {
+	if ids == nil {
+		return nil
+	}
+	out := make([]int, len(ids))
+	for i, id := range ids {
+		out[i] = int(id)
+	}
+	return out
+}
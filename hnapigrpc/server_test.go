@@ -0,0 +1,160 @@
+package hnapigrpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapigrpc/hnapipb"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+// dial serves client over an in-memory connection and returns a gRPC client for it.
+func dial(t *testing.T, client *hnapi.Client) hnapipb.HackerNewsClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s, client)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return hnapipb.NewHackerNewsClient(conn)
+}
+
+func TestUnary(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	story := &hnapi.Item{ID: 1, Type: hnapi.TypeStory, By: "alice", Title: "Hello", URL: "https://example.com", Score: 5, Kids: []int{2}, Time: 1700000000}
+	srv.AddItems(story, &hnapi.Item{ID: 2, Type: hnapi.TypeComment, Parent: 1, Text: "Hi"})
+	srv.AddUsers(&hnapi.User{ID: "alice", Karma: 10, Submitted: []int{1}})
+	srv.SetList(hnapi.ListTop, []int{1})
+	srv.SetMaxItem(2)
+
+	c := dial(t, srv.Client(hnapi.WithMaxRetries(0)))
+	ctx := context.Background()
+
+	item, err := c.GetItem(ctx, &hnapipb.GetItemRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if got := ItemFromProto(item); !reflect.DeepEqual(got, story) {
+		t.Errorf("GetItem() = %+v, want %+v", got, story)
+	}
+
+	if _, err := c.GetItem(ctx, &hnapipb.GetItemRequest{Id: 99}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing item, got %v", err)
+	}
+
+	items, err := c.GetItems(ctx, &hnapipb.GetItemsRequest{Ids: []int64{2, 99, 1}})
+	if err != nil {
+		t.Fatalf("GetItems() error = %v", err)
+	}
+	if len(items.Items) != 2 || items.Items[0].Id != 2 || items.Items[1].Id != 1 {
+		t.Errorf("GetItems() = %v, want items 2 and 1", items.Items)
+	}
+
+	user, err := c.GetUser(ctx, &hnapipb.GetUserRequest{Id: "alice"})
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.Karma != 10 || !reflect.DeepEqual(user.Submitted, []int64{1}) {
+		t.Errorf("GetUser() = %v", user)
+	}
+
+	list, err := c.GetList(ctx, &hnapipb.GetListRequest{List: hnapipb.List_LIST_TOP})
+	if err != nil {
+		t.Fatalf("GetList() error = %v", err)
+	}
+	if !reflect.DeepEqual(list.Ids, []int64{1}) {
+		t.Errorf("GetList() = %v, want [1]", list.Ids)
+	}
+
+	if _, err := c.GetList(ctx, &hnapipb.GetListRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unspecified list, got %v", err)
+	}
+
+	maxItem, err := c.GetMaxItem(ctx, &hnapipb.GetMaxItemRequest{})
+	if err != nil || maxItem.Id != 2 {
+		t.Errorf("GetMaxItem() = %v, %v, want 2", maxItem, err)
+	}
+}
+
+func TestGetItemsFailure(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 2, Type: hnapi.TypeStory}, &hnapi.Item{ID: 3, Type: hnapi.TypeStory})
+	srv.FailNext("item/2.json", http.StatusInternalServerError, 1)
+
+	c := dial(t, srv.Client(hnapi.WithMaxRetries(0)))
+
+	// A missing item is left out, but a server error must not be swallowed with it
+	_, err := c.GetItems(context.Background(), &hnapipb.GetItemsRequest{Ids: []int64{99, 2, 3}})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable for a failed item, got %v", err)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	srv.SetMaxItem(1)
+	srv.QueueUpdates(hnapi.Updates{Items: []int{5, 6}, Profiles: []string{"bob"}})
+
+	c := dial(t, srv.Client(hnapi.WithPollInterval(10*time.Millisecond)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updates, err := c.StreamUpdates(ctx, &hnapipb.StreamUpdatesRequest{})
+	if err != nil {
+		t.Fatalf("StreamUpdates() error = %v", err)
+	}
+	msg, err := updates.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if !reflect.DeepEqual(msg.Items, []int64{5, 6}) || !reflect.DeepEqual(msg.Profiles, []string{"bob"}) {
+		t.Errorf("Unexpected updates %v", msg)
+	}
+
+	firehose, err := c.StreamFirehose(ctx, &hnapipb.StreamFirehoseRequest{})
+	if err != nil {
+		t.Fatalf("StreamFirehose() error = %v", err)
+	}
+
+	// The firehose starts from the current maxitem, so add items after it connects
+	time.Sleep(50 * time.Millisecond)
+	srv.AddItems(&hnapi.Item{ID: 2, Type: hnapi.TypeStory, Title: "New"})
+	srv.SetMaxItem(2)
+
+	item, err := firehose.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if item.Id != 2 || item.Title != "New" {
+		t.Errorf("Unexpected firehose item %v", item)
+	}
+}
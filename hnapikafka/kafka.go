@@ -0,0 +1,70 @@
+// Package hnapikafka publishes Hacker News events to Kafka.
+//
+// The adapter accepts any Producer, so this package does not pin a Kafka client.
+// With github.com/segmentio/kafka-go, wrap a writer that does not set a Topic of
+// its own, since every message carries its topic:
+//
+//	w := &kafka.Writer{Addr: kafka.TCP("localhost:9092"), AllowAutoTopicCreation: true}
+//	defer w.Close()
+//
+//	publisher := hnapikafka.NewPublisher(hnapikafka.ProducerFunc(
+//		func(ctx context.Context, topic string, key, value []byte) error {
+//			return w.WriteMessages(ctx, kafka.Message{Topic: topic, Key: key, Value: value})
+//		}))
+//	return client.StartPublishing(ctx, publisher, hnapi.PublishTopics{NewItems: "hn-new-items"})
+package hnapikafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Producer is the part of a Kafka client the publisher uses: it writes one
+// message to a topic and waits for the broker to accept it.
+type Producer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// ProducerFunc adapts a function to the Producer interface.
+type ProducerFunc func(ctx context.Context, topic string, key, value []byte) error
+
+// Produce calls f(ctx, topic, key, value).
+func (f ProducerFunc) Produce(ctx context.Context, topic string, key, value []byte) error {
+	return f(ctx, topic, key, value)
+}
+
+// Publisher is an hnapi.Publisher that writes each payload as a Kafka message.
+// Messages are keyed by the "id" field of the payload, when it has one, so all
+// versions of an item or user land in the same partition in order.
+type Publisher struct {
+	producer Producer
+}
+
+var _ hnapi.Publisher = (*Publisher)(nil)
+
+// NewPublisher creates a publisher that writes through producer.
+func NewPublisher(producer Producer) *Publisher {
+	return &Publisher{producer: producer}
+}
+
+// Publish writes payload to topic and waits for the producer to accept it.
+func (p *Publisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := p.producer.Produce(ctx, topic, messageKey(payload), payload); err != nil {
+		return fmt.Errorf("failed to write to Kafka topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+// messageKey returns the raw "id" field of a JSON object payload, or nil.
+func messageKey(payload []byte) []byte {
+	var record struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(payload, &record); err != nil || len(record.ID) == 0 {
+		return nil
+	}
+	return record.ID
+}
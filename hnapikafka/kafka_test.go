@@ -0,0 +1,69 @@
+package hnapikafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// message is a produced Kafka message.
+type message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// fakeProducer records produced messages.
+type fakeProducer struct {
+	messages []message
+	err      error
+}
+
+func (w *fakeProducer) Produce(_ context.Context, topic string, key, value []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, message{Topic: topic, Key: key, Value: value})
+	return nil
+}
+
+func TestPublisher(t *testing.T) {
+	w := &fakeProducer{}
+	p := NewPublisher(w)
+	ctx := context.Background()
+
+	tests := []struct {
+		payload string
+		wantKey string
+	}{
+		{payload: `{"id":8863,"type":"story"}`, wantKey: `8863`},
+		{payload: `{"id":"pg","karma":1}`, wantKey: `"pg"`},
+		{payload: `{"items":[1]}`, wantKey: ``},
+		{payload: `[1,2]`, wantKey: ``},
+	}
+
+	for _, tt := range tests {
+		if err := p.Publish(ctx, "hn", []byte(tt.payload)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		msg := w.messages[len(w.messages)-1]
+		if msg.Topic != "hn" || string(msg.Value) != tt.payload || string(msg.Key) != tt.wantKey {
+			t.Errorf("Publish(%s) wrote topic %q key %q value %q", tt.payload, msg.Topic, msg.Key, msg.Value)
+		}
+	}
+
+	// Functions adapt to Producer
+	var produced string
+	fn := NewPublisher(ProducerFunc(func(_ context.Context, topic string, _, _ []byte) error {
+		produced = topic
+		return nil
+	}))
+	if err := fn.Publish(ctx, "hn-new", []byte(`{}`)); err != nil || produced != "hn-new" {
+		t.Errorf("Expected ProducerFunc to produce to hn-new, got %q, %v", produced, err)
+	}
+
+	w.err = errors.New("leader not available")
+	if err := p.Publish(ctx, "hn", []byte(`{}`)); !errors.Is(err, w.err) {
+		t.Errorf("Expected the writer error, got %v", err)
+	}
+}
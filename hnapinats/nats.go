@@ -0,0 +1,67 @@
+// Package hnapinats publishes Hacker News events to NATS.
+//
+// The adapter accepts any connection with a Publish(subject, data) method, which
+// *nats.Conn from github.com/nats-io/nats.go satisfies, so this package does not
+// pin a NATS client version:
+//
+//	nc, err := nats.Connect(nats.DefaultURL)
+//	if err != nil {
+//		return err
+//	}
+//	publisher := hnapinats.NewPublisher(nc, hnapinats.WithSubjectPrefix("hn."))
+//	return client.StartPublishing(ctx, publisher, hnapi.PublishTopics{Items: "items"})
+package hnapinats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Conn is the part of a NATS connection the publisher uses.
+type Conn interface {
+	Publish(subject string, data []byte) error
+}
+
+// Option configures a Publisher.
+type Option func(*Publisher)
+
+// WithSubjectPrefix prepends prefix to every topic to form the NATS subject.
+func WithSubjectPrefix(prefix string) Option {
+	return func(p *Publisher) {
+		p.prefix = prefix
+	}
+}
+
+// Publisher is an hnapi.Publisher that publishes each payload as a NATS message
+// whose subject is the topic.
+type Publisher struct {
+	conn   Conn
+	prefix string
+}
+
+var _ hnapi.Publisher = (*Publisher)(nil)
+
+// NewPublisher creates a publisher on the given connection.
+func NewPublisher(conn Conn, opts ...Option) *Publisher {
+	p := &Publisher{conn: conn}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Publish publishes payload to the subject named by topic. NATS publishing is
+// asynchronous, so ctx is only checked before sending.
+func (p *Publisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	subject := p.prefix + topic
+	if err := p.conn.Publish(subject, payload); err != nil {
+		return fmt.Errorf("failed to publish to NATS subject %s: %w", subject, err)
+	}
+	return nil
+}
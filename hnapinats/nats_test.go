@@ -0,0 +1,46 @@
+package hnapinats
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeConn records published messages.
+type fakeConn struct {
+	subjects []string
+	data     []string
+	err      error
+}
+
+func (c *fakeConn) Publish(subject string, data []byte) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.subjects = append(c.subjects, subject)
+	c.data = append(c.data, string(data))
+	return nil
+}
+
+func TestPublisher(t *testing.T) {
+	conn := &fakeConn{}
+	p := NewPublisher(conn, WithSubjectPrefix("hn."))
+
+	if err := p.Publish(context.Background(), "items", []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(conn.subjects) != 1 || conn.subjects[0] != "hn.items" || conn.data[0] != `{"id":1}` {
+		t.Errorf("Unexpected messages %v %v", conn.subjects, conn.data)
+	}
+
+	conn.err = errors.New("connection closed")
+	if err := p.Publish(context.Background(), "items", nil); !errors.Is(err, conn.err) {
+		t.Errorf("Expected the connection error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Publish(ctx, "items", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+// Package hnapiproxy provides an embeddable caching proxy for the Hacker News API.
+//
+// A Handler serves the same paths as the Firebase API, so existing clients only
+// need a different base URL. Responses are fetched through an hnapi.Client, cached
+// in memory, and upstream fetches are rate limited, letting many services share one
+// proxy instead of each polling Firebase directly:
+//
+//	client := hnapi.NewClient()
+//	handler := hnapiproxy.NewHandler(client, hnapiproxy.WithTTL(time.Minute))
+//	http.Handle("/v0/", http.StripPrefix("/v0", handler))
+//
+// Concurrent requests for the same uncached path are coalesced into one upstream
+// fetch.
+package hnapiproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/internal/cache"
+)
+
+// Defaults used by NewHandler.
+const (
+	DefaultTTL        = 30 * time.Second
+	DefaultMaxEntries = 10000
+)
+
+// pathPattern matches the API paths the proxy serves.
+var pathPattern = regexp.MustCompile(`^/(item/\d+|user/[A-Za-z0-9_-]+|(top|new|best|ask|show|job)stories|maxitem|updates)\.json$`)
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithTTL sets how long responses are cached. A TTL of 0 disables caching.
+func WithTTL(ttl time.Duration) Option {
+	return func(h *Handler) {
+		h.ttl = ttl
+	}
+}
+
+// WithMaxEntries sets the maximum number of cached responses.
+func WithMaxEntries(n int) Option {
+	return func(h *Handler) {
+		h.maxEntries = n
+	}
+}
+
+// WithRateLimit limits upstream fetches to rate per second, allowing bursts of up
+// to burst fetches. Requests over the limit wait for their turn. By default
+// upstream fetches are not limited beyond the client's own Concurrency; a rate of 0
+// or less restores that.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(h *Handler) {
+		h.limiter = nil
+		if rate > 0 {
+			h.limiter = hnapi.NewTokenBucket(rate, burst)
+		}
+	}
+}
+
+// Handler is an http.Handler that serves Hacker News API paths from a cache,
+// fetching missing or expired responses through an hnapi.Client.
+type Handler struct {
+	client     *hnapi.Client
+	ttl        time.Duration
+	maxEntries int
+	limiter    *hnapi.TokenBucket
+	now        func() time.Time
+	cache      *cache.Cache
+}
+
+// NewHandler creates a proxy handler that fetches through client.
+func NewHandler(client *hnapi.Client, opts ...Option) *Handler {
+	h := &Handler{
+		client:     client,
+		ttl:        DefaultTTL,
+		maxEntries: DefaultMaxEntries,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.cache = cache.New(h.ttl, h.maxEntries, func() time.Time { return h.now() })
+
+	return h
+}
+
+// ServeHTTP serves a Hacker News API path such as /item/8863.json. Missing items
+// and users are served as null, as the upstream API does. Upstream failures are
+// reported as 502 Bad Gateway.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !pathPattern.MatchString(r.URL.Path) {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, hit, err := h.get(r.Context(), r.URL.Path[1:])
+	if err != nil {
+		if r.Context().Err() != nil {
+			return
+		}
+		http.Error(w, "upstream request failed", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if hit {
+		w.Header().Set("X-Cache", "HIT")
+	} else {
+		w.Header().Set("X-Cache", "MISS")
+	}
+	if h.ttl > 0 {
+		w.Header().Set("Cache-Control", "max-age="+strconv.Itoa(int(h.ttl/time.Second)))
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(body)
+	}
+}
+
+// get returns the response body for endpoint and whether it came from the cache.
+func (h *Handler) get(ctx context.Context, endpoint string) ([]byte, bool, error) {
+	return h.cache.Get(ctx, endpoint, func(ctx context.Context) ([]byte, error) {
+		return h.fetchUpstream(ctx, endpoint)
+	})
+}
+
+// fetchUpstream retrieves the raw JSON body of endpoint through the client.
+func (h *Handler) fetchUpstream(ctx context.Context, endpoint string) ([]byte, error) {
+	if h.limiter != nil {
+		if err := h.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var body json.RawMessage
+	err := h.client.GetInto(ctx, endpoint, &body)
+	if errors.Is(err, hnapi.ErrNotFound) {
+		return []byte("null"), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
@@ -0,0 +1,137 @@
+package hnapiproxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+func TestHandler(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 1, Type: hnapi.TypeStory, Title: "Hello"})
+	srv.SetList(hnapi.ListTop, []int{1})
+
+	proxy := httptest.NewServer(NewHandler(srv.Client()))
+	defer proxy.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantCache  string
+	}{
+		{name: "item miss", path: "/item/1.json", wantStatus: http.StatusOK, wantBody: `"title":"Hello"`, wantCache: "MISS"},
+		{name: "item hit", path: "/item/1.json", wantStatus: http.StatusOK, wantBody: `"title":"Hello"`, wantCache: "HIT"},
+		{name: "list", path: "/topstories.json", wantStatus: http.StatusOK, wantBody: `[1]`, wantCache: "MISS"},
+		{name: "missing item", path: "/item/2.json", wantStatus: http.StatusOK, wantBody: `null`, wantCache: "MISS"},
+		{name: "unknown path", path: "/secret.json", wantStatus: http.StatusNotFound},
+		{name: "bad item ID", path: "/item/abc.json", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(proxy.URL + tt.path)
+			if err != nil {
+				t.Fatalf("GET %s error = %v", tt.path, err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if tt.wantBody != "" && !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("Expected body to contain %s, got %s", tt.wantBody, body)
+			}
+			if got := resp.Header.Get("X-Cache"); got != tt.wantCache {
+				t.Errorf("Expected X-Cache %q, got %q", tt.wantCache, got)
+			}
+		})
+	}
+
+	if n := srv.Requests("item/1.json"); n != 1 {
+		t.Errorf("Expected one upstream request for a cached item, got %d", n)
+	}
+}
+
+func TestHandlerExpiry(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+	srv.AddItems(&hnapi.Item{ID: 1, Type: hnapi.TypeStory})
+
+	now := time.Unix(0, 0)
+	h := NewHandler(srv.Client(), WithTTL(time.Minute))
+	h.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for _, advance := range []time.Duration{0, 30 * time.Second, time.Minute} {
+		now = now.Add(advance)
+		if _, _, err := h.get(ctx, "item/1.json"); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+
+	if n := srv.Requests("item/1.json"); n != 2 {
+		t.Errorf("Expected the item to be refetched once after expiry, got %d requests", n)
+	}
+}
+
+func TestHandlerUpstreamFailure(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+	srv.FailNext("item/1.json", http.StatusInternalServerError, 1)
+
+	rec := httptest.NewRecorder()
+	NewHandler(srv.Client(hnapi.WithMaxRetries(0))).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/item/1.json", nil))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("Expected status 502, got %d", rec.Code)
+	}
+}
+
+func TestHandlerCoalescesFetches(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := 0
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-release
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer upstream.Close()
+
+	h := NewHandler(hnapi.NewClient(hnapi.WithBaseURL(upstream.URL + "/")))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := h.get(context.Background(), "item/1.json"); err != nil {
+				t.Errorf("get() error = %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("Expected concurrent requests to share one upstream fetch, got %d", requests)
+	}
+}
@@ -0,0 +1,149 @@
+package hnapitest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Clock is a synthetic hnapi.Clock whose time only moves when Advance is called,
+// so tests of pollers and retries run instantly and deterministically:
+//
+//	clock := hnapitest.NewClock(time.Now())
+//	client := srv.Client(hnapi.WithClock(clock), hnapi.WithPollInterval(time.Minute))
+//	updates, _ := client.StartUpdates(ctx)
+//	<-updates // the first poll happens immediately
+//	clock.BlockUntil(1)
+//	clock.Advance(time.Minute) // triggers the next poll
+//
+// All methods are safe for concurrent use.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*clockWaiter
+}
+
+// clockWaiter is a pending timer or an active ticker.
+type clockWaiter struct {
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// NewClock returns a clock set to start.
+func NewClock(start time.Time) *Clock {
+	c := &Clock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the time once the clock has been advanced
+// by d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.addLocked(&clockWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// NewTicker returns a ticker that ticks every d of synthetic time. Like
+// time.Ticker, it drops ticks the receiver is not ready for.
+func (c *Clock) NewTicker(d time.Duration) hnapi.Ticker {
+	if d <= 0 {
+		panic("hnapitest: non-positive interval for NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &clockWaiter{at: c.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	c.addLocked(w)
+	return &clockTicker{clock: c, waiter: w}
+}
+
+// Advance moves the clock forward by d, firing the timers and tickers that come due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	kept := c.waiters[:0]
+	for _, w := range c.waiters {
+		for !w.at.After(c.now) {
+			select {
+			case w.ch <- w.at:
+			default:
+			}
+			if w.period == 0 {
+				break
+			}
+			w.at = w.at.Add(w.period)
+		}
+		if w.at.After(c.now) {
+			kept = append(kept, w)
+		}
+	}
+	c.waiters = kept
+}
+
+// BlockUntil waits until at least n timers and tickers are pending, for example
+// until a poller has gone back to waiting for its next tick.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// addLocked registers w; c.mu must be held.
+func (c *Clock) addLocked(w *clockWaiter) {
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+}
+
+// remove unregisters w.
+func (c *Clock) remove(w *clockWaiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, waiter := range c.waiters {
+		if waiter == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// clockTicker is a ticker of a synthetic Clock.
+type clockTicker struct {
+	clock  *Clock
+	waiter *clockWaiter
+}
+
+// C returns the channel ticks are delivered on.
+func (t *clockTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+// Stop turns off the ticker.
+func (t *clockTicker) Stop() {
+	t.clock.remove(t.waiter)
+}
@@ -0,0 +1,118 @@
+package hnapitest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+func TestClockTimersAndTickers(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+
+	timer := clock.After(time.Second)
+	ticker := clock.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-timer:
+		t.Fatal("Expected the timer not to fire early")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	if got := <-timer; !got.Equal(start.Add(time.Second)) {
+		t.Errorf("Expected the timer to fire at %v, got %v", start.Add(time.Second), got)
+	}
+
+	// Ticks the receiver misses are dropped, like with time.Ticker
+	clock.Advance(3 * time.Minute)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Fatal("Expected missed ticks to be dropped")
+	default:
+	}
+
+	if got := clock.Now(); !got.Equal(start.Add(3*time.Minute + time.Second)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(3*time.Minute+time.Second))
+	}
+
+	// Only the ticker is still pending
+	clock.BlockUntil(1)
+	ticker.Stop()
+	clock.Advance(time.Hour)
+	select {
+	case <-ticker.C():
+		t.Fatal("Expected a stopped ticker not to tick")
+	default:
+	}
+}
+
+func TestClockDrivesPolling(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.QueueUpdates(
+		hnapi.Updates{Items: []int{1}},
+		hnapi.Updates{Items: []int{2}},
+	)
+
+	clock := NewClock(time.Now())
+	client := srv.Client(hnapi.WithClock(clock), hnapi.WithPollInterval(time.Hour))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+
+	if got := <-updates; got.Items[0] != 1 {
+		t.Fatalf("Expected the first poll to return item 1, got %v", got.Items)
+	}
+
+	// The second poll waits for an hour of synthetic time
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	if got := <-updates; got.Items[0] != 2 {
+		t.Fatalf("Expected the second poll to return item 2, got %v", got.Items)
+	}
+}
+
+func TestClockDrivesRetries(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 1, Type: hnapi.TypeStory})
+	srv.FailNext("item/1.json", http.StatusServiceUnavailable, 1)
+
+	clock := NewClock(time.Now())
+	client := srv.Client(hnapi.WithClock(clock), hnapi.WithBackoffInterval(time.Hour))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetItem(context.Background(), 1)
+		done <- err
+	}()
+
+	// The retry is only sent once the backoff has elapsed
+	clock.BlockUntil(1)
+	if got := srv.Requests("item/1.json"); got != 1 {
+		t.Fatalf("Expected 1 request before the backoff elapsed, got %d", got)
+	}
+	clock.Advance(time.Hour)
+
+	if err := <-done; err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if got := srv.Requests("item/1.json"); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
@@ -0,0 +1,185 @@
+package hnapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yarlson/hnapi"
+)
+
+// RecorderMode controls whether a Recorder records or replays responses.
+type RecorderMode int
+
+const (
+	// ModeAuto replays a response when its fixture exists and records it otherwise.
+	ModeAuto RecorderMode = iota
+
+	// ModeReplay only replays fixtures and fails requests that have none.
+	ModeReplay
+
+	// ModeRecord always performs the request and overwrites the fixture.
+	ModeRecord
+)
+
+// ErrFixtureNotFound is returned in ModeReplay when a request has no recorded fixture.
+var ErrFixtureNotFound = errors.New("hnapitest: fixture not found")
+
+// Recorder is an http.RoundTripper that records API responses to fixture files on
+// first use and replays them afterwards, so tests can run against real data
+// without depending on the network.
+type Recorder struct {
+	// Dir is the directory holding fixture files.
+	Dir string
+
+	// Mode selects recording or replaying. The zero value is ModeAuto.
+	Mode RecorderMode
+
+	// Transport performs live requests when recording. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// fixture is the on-disk representation of a recorded response.
+type fixture struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// WithRecorder returns a client option that routes requests through a Recorder
+// storing fixtures in dir with the given mode.
+func WithRecorder(dir string, mode RecorderMode) hnapi.Option {
+	return hnapi.WithHTTPClient(&http.Client{
+		Transport: &Recorder{Dir: dir, Mode: mode},
+	})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(r.Dir, fixtureName(req))
+
+	if r.Mode != ModeRecord {
+		f, err := readFixture(path)
+		switch {
+		case err == nil:
+			return f.response(req), nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		case r.Mode == ModeReplay:
+			return nil, fmt.Errorf("%w: %s %s", ErrFixtureNotFound, req.Method, req.URL)
+		}
+	}
+
+	return r.record(req, path)
+}
+
+// record performs the live request and writes its response to path.
+func (r *Recorder) record(req *http.Request, path string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	f := fixture{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(body),
+	}
+	if err := writeFixture(path, f); err != nil {
+		return nil, err
+	}
+
+	return f.response(req), nil
+}
+
+// response builds an HTTP response from the fixture.
+func (f fixture) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	if f.ContentType != "" {
+		header.Set("Content-Type", f.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+		StatusCode:    f.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(f.Body))),
+		ContentLength: int64(len(f.Body)),
+		Request:       req,
+	}
+}
+
+// readFixture loads a fixture file.
+func readFixture(path string) (fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fixture{}, err
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fixture{}, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// writeFixture stores a fixture file, creating its directory if needed.
+func writeFixture(path string, f fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// fixtureName derives a file name from the request method, path, and query.
+// The host is ignored so fixtures recorded against one base URL replay against another.
+func fixtureName(req *http.Request) string {
+	key := req.Method + " " + req.URL.Path
+	if req.URL.RawQuery != "" {
+		key += "?" + req.URL.RawQuery
+	}
+
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	return name + ".fixture.json"
+}
@@ -0,0 +1,71 @@
+package hnapitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/yarlson/hnapi"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	srv := NewServer()
+	srv.AddItems(&hnapi.Item{ID: 1, Type: "story", Title: "Recorded"})
+
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// The first run records from the live server
+	client := hnapi.NewClient(
+		hnapi.WithBaseURL(srv.BaseURL()),
+		WithRecorder(dir, ModeAuto),
+	)
+	item, err := client.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() while recording error = %v", err)
+	}
+	if item.Title != "Recorded" {
+		t.Errorf("Expected title Recorded, got %q", item.Title)
+	}
+
+	// Later runs replay without the server
+	srv.Close()
+
+	client = hnapi.NewClient(
+		hnapi.WithBaseURL(srv.BaseURL()),
+		WithRecorder(dir, ModeReplay),
+	)
+	item, err = client.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() while replaying error = %v", err)
+	}
+	if item.Title != "Recorded" {
+		t.Errorf("Expected replayed title Recorded, got %q", item.Title)
+	}
+}
+
+func TestRecorderReplayMissingFixture(t *testing.T) {
+	recorder := &Recorder{Dir: t.TempDir(), Mode: ModeReplay}
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/v0/item/1.json", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	if _, err := recorder.RoundTrip(req); !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("Expected ErrFixtureNotFound, got %v", err)
+	}
+}
+
+func TestFixtureName(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/v0/item/1.json?print=pretty", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	want := "GET__v0_item_1.json_print_pretty.fixture.json"
+	if got := fixtureName(req); got != want {
+		t.Errorf("fixtureName() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,295 @@
+// Package hnapitest provides an in-process fake Hacker News API server for tests.
+//
+// A Server serves items, users, story lists, maxitem, and a scripted sequence of
+// updates from in-memory fixtures that can be changed while a test runs:
+//
+//	srv := hnapitest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddItems(&hnapi.Item{ID: 1, Type: hnapi.TypeStory, Title: "Hello"})
+//	srv.SetList(hnapi.ListTop, []int{1})
+//
+//	client := srv.Client()
+//	item, err := client.GetItem(ctx, 1)
+package hnapitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Server is a fake Hacker News API backed by in-memory fixtures.
+// All methods are safe for concurrent use.
+type Server struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	items    map[int]*hnapi.Item
+	users    map[string]*hnapi.User
+	lists    map[string][]int
+	maxItem  int
+	updates  []hnapi.Updates
+	failures map[string]failure
+	requests map[string]int
+}
+
+// failure is a scheduled error response for a path.
+type failure struct {
+	status    int
+	remaining int
+}
+
+// NewServer starts a fake Hacker News API server with no fixtures.
+// The caller must call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		items:    make(map[int]*hnapi.Item),
+		users:    make(map[string]*hnapi.User),
+		lists:    make(map[string][]int),
+		failures: make(map[string]failure),
+		requests: make(map[string]int),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// BaseURL returns the API base URL of the server, suitable for hnapi.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.server.URL + "/v0/"
+}
+
+// Client returns a client configured to talk to the server. Retries use a short
+// backoff so that tests exercising failures stay fast. Additional options are
+// applied after these defaults.
+func (s *Server) Client(opts ...hnapi.Option) *hnapi.Client {
+	defaults := []hnapi.Option{
+		hnapi.WithBaseURL(s.BaseURL()),
+		hnapi.WithBackoffInterval(time.Millisecond),
+	}
+	return hnapi.NewClient(append(defaults, opts...)...)
+}
+
+// AddItems adds or replaces items. The server keeps its own copies.
+func (s *Server) AddItems(items ...*hnapi.Item) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range items {
+		copied := *item
+		s.items[item.ID] = &copied
+	}
+}
+
+// UpdateItem applies fn to the stored item with the given ID.
+// It reports whether the item exists.
+func (s *Server) UpdateItem(id int, fn func(*hnapi.Item)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[id]
+	if ok {
+		fn(item)
+	}
+	return ok
+}
+
+// RemoveItem deletes the item, so the server responds with null for it.
+func (s *Server) RemoveItem(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, id)
+}
+
+// AddUsers adds or replaces users. The server keeps its own copies.
+func (s *Server) AddUsers(users ...*hnapi.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, user := range users {
+		copied := *user
+		s.users[user.ID] = &copied
+	}
+}
+
+// UpdateUser applies fn to the stored user with the given username.
+// It reports whether the user exists.
+func (s *Server) UpdateUser(username string, fn func(*hnapi.User)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if ok {
+		fn(user)
+	}
+	return ok
+}
+
+// RemoveUser deletes the user, so the server responds with null for it.
+func (s *Server) RemoveUser(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.users, username)
+}
+
+// SetList sets the story IDs returned for a list.
+func (s *Server) SetList(list hnapi.List, ids []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lists[list.String()+"stories"] = append([]int(nil), ids...)
+}
+
+// SetMaxItem overrides the value returned by maxitem.json. By default the server
+// reports the largest item ID it holds.
+func (s *Server) SetMaxItem(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxItem = id
+}
+
+// QueueUpdates appends to the scripted sequence returned by updates.json. Each
+// request consumes the next entry; once the sequence is exhausted, empty updates
+// are returned.
+func (s *Server) QueueUpdates(updates ...hnapi.Updates) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.updates = append(s.updates, updates...)
+}
+
+// FailNext makes the next n requests to path (for example "item/1.json" or
+// "topstories.json") respond with the given HTTP status code.
+func (s *Server) FailNext(path string, status, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[path] = failure{status: status, remaining: n}
+}
+
+// Requests returns how many requests were made to path, such as "item/1.json".
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[path]
+}
+
+// serveHTTP routes API requests to the fixtures.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v0/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[path]++
+
+	if status, ok := s.takeFailure(path); ok {
+		w.WriteHeader(status)
+		return
+	}
+
+	name := strings.TrimSuffix(path, ".json")
+	switch {
+	case strings.HasPrefix(name, "item/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(name, "item/"))
+		if err != nil {
+			writeJSON(w, nil)
+			return
+		}
+		if item, ok := s.items[id]; ok {
+			writeJSON(w, item)
+			return
+		}
+		writeJSON(w, nil)
+
+	case strings.HasPrefix(name, "user/"):
+		if user, ok := s.users[strings.TrimPrefix(name, "user/")]; ok {
+			writeJSON(w, user)
+			return
+		}
+		writeJSON(w, nil)
+
+	case name == "maxitem":
+		writeJSON(w, s.currentMaxItem())
+
+	case name == "updates":
+		updates := hnapi.Updates{Items: []int{}, Profiles: []string{}}
+		if len(s.updates) > 0 {
+			updates = s.updates[0]
+			s.updates = s.updates[1:]
+		}
+		writeJSON(w, updates)
+
+	default:
+		if ids, ok := s.lists[name]; ok {
+			writeJSON(w, ids)
+			return
+		}
+		if strings.HasSuffix(name, "stories") {
+			writeJSON(w, []int{})
+			return
+		}
+		http.NotFound(w, r)
+	}
+}
+
+// takeFailure consumes a scheduled failure for path, if any. The caller must hold s.mu.
+func (s *Server) takeFailure(path string) (int, bool) {
+	f, ok := s.failures[path]
+	if !ok || f.remaining <= 0 {
+		return 0, false
+	}
+
+	f.remaining--
+	if f.remaining == 0 {
+		delete(s.failures, path)
+	} else {
+		s.failures[path] = f
+	}
+
+	return f.status, true
+}
+
+// currentMaxItem returns the configured or derived max item ID. The caller must hold s.mu.
+func (s *Server) currentMaxItem() int {
+	if s.maxItem != 0 {
+		return s.maxItem
+	}
+
+	maxID := 0
+	for id := range s.items {
+		if id > maxID {
+			maxID = id
+		}
+	}
+	return maxID
+}
+
+// writeJSON writes v as a JSON response body. A nil v is written as null,
+// which is how the real API reports missing items and users.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
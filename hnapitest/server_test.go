@@ -0,0 +1,158 @@
+package hnapitest
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+func TestServerItemsAndUsers(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 1, Type: "story", Title: "Hello", Score: 10})
+	srv.AddUsers(&hnapi.User{ID: "jl", Karma: 100})
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	item, err := client.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Title != "Hello" {
+		t.Errorf("Expected title Hello, got %q", item.Title)
+	}
+
+	// Mutate the fixture mid-test
+	if !srv.UpdateItem(1, func(item *hnapi.Item) { item.Score = 20 }) {
+		t.Fatalf("UpdateItem() reported missing item")
+	}
+	item, err = client.GetItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Score != 20 {
+		t.Errorf("Expected updated score 20, got %d", item.Score)
+	}
+
+	srv.RemoveItem(1)
+	if _, err := client.GetItem(ctx, 1); err == nil {
+		t.Errorf("Expected error for removed item")
+	}
+
+	user, err := client.GetUser(ctx, "jl")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.Karma != 100 {
+		t.Errorf("Expected karma 100, got %d", user.Karma)
+	}
+
+	if _, err := client.GetUser(ctx, "missing"); err == nil {
+		t.Errorf("Expected error for missing user")
+	}
+
+	if got := srv.Requests("item/1.json"); got != 3 {
+		t.Errorf("Expected 3 requests for item/1.json, got %d", got)
+	}
+}
+
+func TestServerListsAndMaxItem(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 5}, &hnapi.Item{ID: 9})
+	srv.SetList(hnapi.ListTop, []int{9, 5})
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	ids, err := client.GetTopStories(ctx)
+	if err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{9, 5}) {
+		t.Errorf("Expected [9 5], got %v", ids)
+	}
+
+	ids, err = client.GetJobStories(ctx)
+	if err != nil {
+		t.Fatalf("GetJobStories() error = %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("Expected empty job list, got %v", ids)
+	}
+
+	maxID, err := client.GetMaxItem(ctx)
+	if err != nil {
+		t.Fatalf("GetMaxItem() error = %v", err)
+	}
+	if maxID != 9 {
+		t.Errorf("Expected derived max item 9, got %d", maxID)
+	}
+
+	srv.SetMaxItem(42)
+	if maxID, _ := client.GetMaxItem(ctx); maxID != 42 {
+		t.Errorf("Expected max item 42, got %d", maxID)
+	}
+}
+
+func TestServerScriptedUpdates(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.QueueUpdates(
+		hnapi.Updates{Items: []int{1}, Profiles: []string{"a"}},
+		hnapi.Updates{Items: []int{2}, Profiles: []string{}},
+	)
+
+	client := srv.Client(hnapi.WithPollInterval(10 * time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatesCh, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+
+	for _, want := range []int{1, 2} {
+		select {
+		case updates := <-updatesCh:
+			if !reflect.DeepEqual(updates.Items, []int{want}) {
+				t.Errorf("Expected items [%d], got %v", want, updates.Items)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for update with item %d", want)
+		}
+	}
+}
+
+func TestServerFailNext(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 1, Type: "story"})
+	srv.FailNext("item/1.json", http.StatusServiceUnavailable, 1)
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	if _, err := client.GetItem(ctx, 1, hnapi.WithNoRetry()); err == nil {
+		t.Errorf("Expected scheduled failure")
+	}
+	if _, err := client.GetItem(ctx, 1); err != nil {
+		t.Errorf("Expected success after failure was consumed, got %v", err)
+	}
+
+	// Failures within the retry limit are retried transparently
+	srv.FailNext("item/1.json", http.StatusServiceUnavailable, 2)
+	if _, err := client.GetItem(ctx, 1); err != nil {
+		t.Errorf("Expected retries to recover from failures, got %v", err)
+	}
+}
@@ -0,0 +1,302 @@
+// Package hnapiwebhook delivers Hacker News updates to webhook endpoints.
+//
+// A Dispatcher POSTs each event as JSON to every configured endpoint, signing the
+// body with HMAC-SHA256 when the endpoint has a secret and retrying failed
+// deliveries with exponential backoff. Dispatch pumps any client stream into it:
+//
+//	d := hnapiwebhook.NewDispatcher([]hnapiwebhook.Endpoint{
+//		{URL: "https://example.com/hooks/hn", Secret: secret},
+//	})
+//
+//	stories, err := client.WatchStories(ctx, hnapi.Filter{TitleContains: []string{"golang"}})
+//	if err != nil {
+//		return err
+//	}
+//	return hnapiwebhook.Dispatch(ctx, d, "story", stories)
+//
+// Receivers check the X-HNAPI-Signature header with Verify.
+package hnapiwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// Headers set on every delivery.
+const (
+	// EventHeader carries the event name.
+	EventHeader = "X-HNAPI-Event"
+
+	// DeliveryHeader carries a unique ID of the delivery, identical across retries,
+	// so receivers can drop duplicates.
+	DeliveryHeader = "X-HNAPI-Delivery"
+
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the body,
+	// keyed with the endpoint secret. It is only set for endpoints with a secret.
+	SignatureHeader = "X-HNAPI-Signature"
+)
+
+// Defaults used by NewDispatcher.
+const (
+	DefaultMaxRetries  = 3
+	DefaultMinBackoff  = time.Second
+	DefaultMaxBackoff  = 30 * time.Second
+	DefaultHTTPTimeout = 10 * time.Second
+)
+
+// Endpoint is a webhook receiver.
+type Endpoint struct {
+	// URL is where events are POSTed.
+	URL string
+
+	// Secret, if set, is the key used to sign deliveries.
+	Secret string
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	// Event is the event name passed to Send.
+	Event string `json:"event"`
+
+	// Timestamp is when the event was sent, in Unix seconds.
+	Timestamp int64 `json:"timestamp"`
+
+	// Data is the event value, such as an item or updates.
+	Data interface{} `json:"data"`
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithHTTPClient sets the HTTP client used for deliveries.
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.httpClient = client
+	}
+}
+
+// WithMaxRetries sets how many times a failed delivery is retried.
+func WithMaxRetries(retries int) Option {
+	return func(d *Dispatcher) {
+		d.maxRetries = retries
+	}
+}
+
+// WithBackoff sets the delay before the first retry, which doubles for every
+// further retry up to limit.
+func WithBackoff(initial, limit time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.minBackoff = initial
+		d.maxBackoff = limit
+	}
+}
+
+// WithErrorHandler sets a callback invoked by Dispatch with deliveries that failed
+// after all retries.
+func WithErrorHandler(handler func(error)) Option {
+	return func(d *Dispatcher) {
+		d.errorHandler = handler
+	}
+}
+
+// Dispatcher delivers events to webhook endpoints.
+// All methods are safe for concurrent use.
+type Dispatcher struct {
+	endpoints    []Endpoint
+	httpClient   *http.Client
+	maxRetries   int
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	errorHandler func(error)
+	now          func() time.Time
+}
+
+// NewDispatcher creates a dispatcher that delivers to the given endpoints.
+func NewDispatcher(endpoints []Endpoint, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		endpoints:  append([]Endpoint(nil), endpoints...),
+		httpClient: &http.Client{Timeout: DefaultHTTPTimeout},
+		maxRetries: DefaultMaxRetries,
+		minBackoff: DefaultMinBackoff,
+		maxBackoff: DefaultMaxBackoff,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// DeliveryError reports a delivery that failed after all retries.
+type DeliveryError struct {
+	// URL is the endpoint the delivery was for.
+	URL string
+
+	// Event is the event name.
+	Event string
+
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("failed to deliver %s event to %s after %d attempts: %v", e.Event, e.URL, e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// Send delivers one event to every endpoint concurrently and waits for the
+// deliveries to finish. Failed deliveries are returned as joined *DeliveryError
+// values.
+func (d *Dispatcher) Send(ctx context.Context, event string, data interface{}) error {
+	body, err := json.Marshal(Payload{Event: event, Timestamp: d.now().Unix(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+	delivery := newDeliveryID()
+
+	errs := make([]error, len(d.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range d.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint Endpoint) {
+			defer wg.Done()
+			errs[i] = d.deliver(ctx, endpoint, event, delivery, body)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Dispatch sends every value received from events, such as the channel of
+// StartUpdates or WatchStories, as an event with the given name until the channel
+// is closed or the context is canceled. Failed deliveries are passed to the error
+// handler and do not stop dispatching.
+func Dispatch[T any](ctx context.Context, d *Dispatcher, event string, events <-chan T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case value, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := d.Send(ctx, event, value); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if d.errorHandler != nil {
+					d.errorHandler(err)
+				}
+			}
+		}
+	}
+}
+
+// DispatchUpdates sends the updates received from updates as "updates" events.
+// It is shorthand for Dispatch with the channel of StartUpdates.
+func DispatchUpdates(ctx context.Context, d *Dispatcher, updates <-chan hnapi.Updates) error {
+	return Dispatch(ctx, d, "updates", updates)
+}
+
+// deliver POSTs body to endpoint, retrying failures that may be transient.
+func (d *Dispatcher) deliver(ctx context.Context, endpoint Endpoint, event, delivery string, body []byte) error {
+	backoff := d.minBackoff
+	for attempt := 1; ; attempt++ {
+		retryable, err := d.post(ctx, endpoint, event, delivery, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt > d.maxRetries || ctx.Err() != nil {
+			return &DeliveryError{URL: endpoint.URL, Event: event, Attempts: attempt, Err: err}
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &DeliveryError{URL: endpoint.URL, Event: event, Attempts: attempt, Err: err}
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > d.maxBackoff {
+			backoff = d.maxBackoff
+		}
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying.
+func (d *Dispatcher) post(ctx context.Context, endpoint Endpoint, event, delivery string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", hnapi.DefaultUserAgent)
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, delivery)
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to execute request: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
+
+// Sign returns the SignatureHeader value for body signed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature, the SignatureHeader value of a delivery, is
+// valid for body and secret.
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, body)))
+}
+
+// newDeliveryID returns a random delivery ID.
+func newDeliveryID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
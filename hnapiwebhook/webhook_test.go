@@ -0,0 +1,165 @@
+package hnapiwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yarlson/hnapi"
+)
+
+// receiver records deliveries and fails the first failures requests with status.
+type receiver struct {
+	mu         sync.Mutex
+	deliveries []*http.Request
+	bodies     [][]byte
+	failures   int
+	status     int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = append(r.deliveries, req)
+	r.bodies = append(r.bodies, body)
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(r.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestSend(t *testing.T) {
+	signed := &receiver{}
+	plain := &receiver{}
+	signedServer := httptest.NewServer(signed)
+	defer signedServer.Close()
+	plainServer := httptest.NewServer(plain)
+	defer plainServer.Close()
+
+	d := NewDispatcher([]Endpoint{
+		{URL: signedServer.URL, Secret: "s3cret"},
+		{URL: plainServer.URL},
+	})
+	d.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	if err := d.Send(context.Background(), "story", &hnapi.Item{ID: 1, Title: "Hello"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(signed.deliveries) != 1 || len(plain.deliveries) != 1 {
+		t.Fatalf("Expected one delivery per endpoint, got %d and %d", len(signed.deliveries), len(plain.deliveries))
+	}
+
+	req, body := signed.deliveries[0], signed.bodies[0]
+	if !Verify("s3cret", body, req.Header.Get(SignatureHeader)) {
+		t.Errorf("Expected a valid signature, got %q", req.Header.Get(SignatureHeader))
+	}
+	if Verify("wrong", body, req.Header.Get(SignatureHeader)) {
+		t.Errorf("Expected the signature to fail with the wrong secret")
+	}
+	if req.Header.Get(EventHeader) != "story" || req.Header.Get(DeliveryHeader) == "" {
+		t.Errorf("Unexpected headers %v", req.Header)
+	}
+	if plain.deliveries[0].Header.Get(SignatureHeader) != "" {
+		t.Errorf("Expected no signature without a secret")
+	}
+
+	var payload struct {
+		Event     string     `json:"event"`
+		Timestamp int64      `json:"timestamp"`
+		Data      hnapi.Item `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Body is not JSON: %v", err)
+	}
+	if payload.Event != "story" || payload.Timestamp != 1700000000 || payload.Data.Title != "Hello" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		status       int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "recovers after server errors", failures: 2, status: http.StatusServiceUnavailable, wantAttempts: 3},
+		{name: "gives up after max retries", failures: 10, status: http.StatusInternalServerError, wantAttempts: 3, wantErr: true},
+		{name: "does not retry client errors", failures: 1, status: http.StatusBadRequest, wantAttempts: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &receiver{failures: tt.failures, status: tt.status}
+			server := httptest.NewServer(r)
+			defer server.Close()
+
+			d := NewDispatcher([]Endpoint{{URL: server.URL}}, WithMaxRetries(2), WithBackoff(time.Millisecond, 2*time.Millisecond))
+			err := d.Send(context.Background(), "updates", hnapi.Updates{Items: []int{1}})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var deliveryErr *DeliveryError
+			if tt.wantErr && (!errors.As(err, &deliveryErr) || deliveryErr.Attempts != tt.wantAttempts) {
+				t.Errorf("Expected a DeliveryError after %d attempts, got %v", tt.wantAttempts, err)
+			}
+			if len(r.deliveries) != tt.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.wantAttempts, len(r.deliveries))
+			}
+
+			// Retries keep the delivery ID so receivers can drop duplicates
+			for _, req := range r.deliveries {
+				if req.Header.Get(DeliveryHeader) != r.deliveries[0].Header.Get(DeliveryHeader) {
+					t.Errorf("Expected the same delivery ID across retries")
+				}
+			}
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	r := &receiver{failures: 1, status: http.StatusBadRequest}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	var handled []error
+	d := NewDispatcher([]Endpoint{{URL: server.URL}}, WithErrorHandler(func(err error) {
+		handled = append(handled, err)
+	}))
+
+	updates := make(chan hnapi.Updates, 2)
+	updates <- hnapi.Updates{Items: []int{1}}
+	updates <- hnapi.Updates{Items: []int{2}}
+	close(updates)
+
+	if err := DispatchUpdates(context.Background(), d, updates); err != nil {
+		t.Fatalf("DispatchUpdates() error = %v", err)
+	}
+
+	if len(r.deliveries) != 2 {
+		t.Errorf("Expected both updates to be delivered, got %d deliveries", len(r.deliveries))
+	}
+	if len(handled) != 1 {
+		t.Errorf("Expected the failed delivery to reach the error handler, got %v", handled)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Dispatch(ctx, d, "item", make(chan *hnapi.Item)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
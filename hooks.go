@@ -0,0 +1,62 @@
+package hnapi
+
+import (
+	"context"
+	"time"
+)
+
+// RequestStartInfo describes a request that is about to be made.
+type RequestStartInfo struct {
+	// Endpoint is the API endpoint relative to the base URL, such as "item/8863.json".
+	Endpoint string
+
+	// Attempt is the 1-based attempt number of the request.
+	Attempt int
+}
+
+// RequestEndInfo describes a finished request.
+type RequestEndInfo struct {
+	// Endpoint is the API endpoint relative to the base URL, such as "item/8863.json".
+	Endpoint string
+
+	// Attempt is the 1-based attempt number of the request.
+	Attempt int
+
+	// Duration is how long the request took, including reading and decoding the body.
+	Duration time.Duration
+
+	// StatusCode is the HTTP status code, or 0 if no response was received.
+	StatusCode int
+
+	// Err is the error the request failed with, or nil on success.
+	Err error
+}
+
+// Hooks are callbacks invoked for every request the client makes, including those
+// made by batch workers and pollers. Either callback may be nil. Callbacks are called
+// synchronously from the requesting goroutine and must be safe for concurrent use.
+type Hooks struct {
+	// OnRequestStart is called before a request is sent.
+	OnRequestStart func(ctx context.Context, info RequestStartInfo)
+
+	// OnRequestEnd is called after a request finishes, successfully or not.
+	OnRequestEnd func(ctx context.Context, info RequestEndInfo)
+}
+
+// requestStart calls every configured OnRequestStart hook.
+func (c *Client) requestStart(ctx context.Context, info RequestStartInfo) {
+	for _, hooks := range c.Config.Hooks {
+		if hooks.OnRequestStart != nil {
+			hooks.OnRequestStart(ctx, info)
+		}
+	}
+}
+
+// requestEnd calls every configured OnRequestEnd hook.
+func (c *Client) requestEnd(ctx context.Context, info RequestEndInfo) {
+	for _, hooks := range c.Config.Hooks {
+		if hooks.OnRequestEnd != nil {
+			hooks.OnRequestEnd(ctx, info)
+		}
+	}
+}
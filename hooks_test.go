@@ -0,0 +1,71 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/item/2.json") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": 1, "type": "story"}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var starts []RequestStartInfo
+	var ends []RequestEndInfo
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithHooks(Hooks{
+			OnRequestStart: func(ctx context.Context, info RequestStartInfo) {
+				mu.Lock()
+				defer mu.Unlock()
+				starts = append(starts, info)
+			},
+		}),
+		WithHooks(Hooks{
+			OnRequestEnd: func(ctx context.Context, info RequestEndInfo) {
+				mu.Lock()
+				defer mu.Unlock()
+				ends = append(ends, info)
+			},
+		}),
+	)
+
+	ctx := context.Background()
+	if _, err := client.GetItem(ctx, 1); err != nil {
+		t.Fatalf("GetItem(1) error = %v", err)
+	}
+	if _, err := client.GetItem(ctx, 2); err == nil {
+		t.Fatalf("Expected GetItem(2) to fail")
+	}
+
+	if len(starts) != 2 || len(ends) != 2 {
+		t.Fatalf("Expected 2 start and 2 end calls, got %d and %d", len(starts), len(ends))
+	}
+
+	if starts[0].Endpoint != "item/1.json" || starts[0].Attempt != 1 {
+		t.Errorf("Unexpected start info %+v", starts[0])
+	}
+
+	if ends[0].StatusCode != http.StatusOK || ends[0].Err != nil || ends[0].Duration <= 0 {
+		t.Errorf("Unexpected end info for success %+v", ends[0])
+	}
+
+	if ends[1].Endpoint != "item/2.json" || ends[1].StatusCode != http.StatusNotFound || ends[1].Err == nil {
+		t.Errorf("Unexpected end info for failure %+v", ends[1])
+	}
+}
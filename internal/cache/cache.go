@@ -0,0 +1,112 @@
+// Package cache provides the in-memory response cache shared by hnapiproxy and
+// hnapigateway. Responses expire after a TTL, the number of entries is bounded, and
+// concurrent requests for the same uncached key share one build.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache caches response bodies by key. It is safe for concurrent use.
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]entry
+	inFlight map[string]*fetch
+}
+
+// entry is a cached response body.
+type entry struct {
+	body    []byte
+	expires time.Time
+}
+
+// fetch is a response being built that concurrent requests for it wait on.
+type fetch struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// New creates a cache keeping bodies for ttl, holding at most maxEntries of them,
+// and reading the time from now. A TTL of 0 disables caching; builds are still
+// shared by concurrent requests.
+func New(ttl time.Duration, maxEntries int, now func() time.Time) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        now,
+		entries:    make(map[string]entry),
+		inFlight:   make(map[string]*fetch),
+	}
+}
+
+// Get returns the body for key, building it with build unless it is cached, and
+// whether it came from the cache. The build outlives the request that started it,
+// since others may be waiting on it. A build that fails is not cached; if it
+// returns a body together with the error, waiters receive both.
+func (c *Cache) Get(ctx context.Context, key string, build func(context.Context) ([]byte, error)) ([]byte, bool, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && c.now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.body, true, nil
+	}
+
+	f, ok := c.inFlight[key]
+	if !ok {
+		f = &fetch{done: make(chan struct{})}
+		c.inFlight[key] = f
+		go c.fetch(context.WithoutCancel(ctx), key, build, f)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, false, f.err
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+}
+
+// fetch runs build, caches its result, and releases its waiters.
+func (c *Cache) fetch(ctx context.Context, key string, build func(context.Context) ([]byte, error), f *fetch) {
+	f.body, f.err = build(ctx)
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	if f.err == nil && c.ttl > 0 {
+		c.store(key, f.body)
+	}
+	c.mu.Unlock()
+
+	close(f.done)
+}
+
+// store caches body for key, evicting expired entries when the cache is full. The
+// caller must hold c.mu.
+func (c *Cache) store(key string, body []byte) {
+	now := c.now()
+
+	if len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	// Still full of live entries: make room by dropping an arbitrary one
+	for k := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			break
+		}
+		delete(c.entries, k)
+	}
+
+	c.entries[key] = entry{body: body, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,177 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// Items returns an iterator that hydrates ids into items in order as the consumer
+// ranges over it. Up to Concurrency items are fetched ahead of the consumer, so
+// breaking out of the loop early avoids fetching the rest:
+//
+//	for item, err := range client.Items(ctx, ids) {
+//		if err != nil {
+//			log.Print(err)
+//			continue
+//		}
+//		fmt.Println(item.Title)
+//	}
+//
+// Items that fail to load are yielded as errors and iteration continues; items that
+// do not exist yield errors wrapping ErrNotFound. Pass SkipDeadAndDeleted to leave
+// out tombstoned items. If the context is canceled, its error is yielded and
+// iteration ends.
+func (c *Client) Items(ctx context.Context, ids []int, opts ...CallOption) iter.Seq2[*Item, error] {
+	return func(yield func(*Item, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+
+		skipTombstones := newCallOptions(opts).skipTombstones
+
+		i := 0
+		pending := c.fetchAhead(ctx, &wg, c.Config.Concurrency, func() (int, bool) {
+			if i == len(ids) {
+				return 0, false
+			}
+			i++
+			return ids[i-1], true
+		}, opts)
+
+		for result := range pending {
+			r := <-result
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+
+			tombstoned := isTombstone(r.Error) ||
+				(r.Error == nil && tombstoneError(r.Item) != nil)
+			if skipTombstones && tombstoned {
+				continue
+			}
+
+			if r.Error != nil {
+				if !yield(nil, fmt.Errorf("failed to get item %d: %w", r.ID, r.Error)) {
+					return
+				}
+				continue
+			}
+			if !yield(r.Item, nil) {
+				return
+			}
+		}
+
+		if ctx.Err() != nil {
+			yield(nil, ctx.Err())
+		}
+	}
+}
+
+// ListItems returns an iterator over the stories of a list, hydrated lazily as by
+// Items. The list itself is fetched when iteration starts; if that fails, its error
+// is yielded and iteration ends.
+func (c *Client) ListItems(ctx context.Context, list List, opts ...CallOption) iter.Seq2[*Item, error] {
+	return func(yield func(*Item, error) bool) {
+		ids, err := c.GetList(ctx, list)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for item, err := range c.Items(ctx, ids, opts...) {
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// TopStories returns an iterator over the top stories, hydrated lazily as by ListItems.
+func (c *Client) TopStories(ctx context.Context, opts ...CallOption) iter.Seq2[*Item, error] {
+	return c.ListItems(ctx, ListTop, opts...)
+}
+
+// NewStories returns an iterator over the newest stories, hydrated lazily as by ListItems.
+func (c *Client) NewStories(ctx context.Context, opts ...CallOption) iter.Seq2[*Item, error] {
+	return c.ListItems(ctx, ListNew, opts...)
+}
+
+// BestStories returns an iterator over the best stories, hydrated lazily as by ListItems.
+func (c *Client) BestStories(ctx context.Context, opts ...CallOption) iter.Seq2[*Item, error] {
+	return c.ListItems(ctx, ListBest, opts...)
+}
+
+// AskStories returns an iterator over the Ask HN stories, hydrated lazily as by ListItems.
+func (c *Client) AskStories(ctx context.Context, opts ...CallOption) iter.Seq2[*Item, error] {
+	return c.ListItems(ctx, ListAsk, opts...)
+}
+
+// ShowStories returns an iterator over the Show HN stories, hydrated lazily as by ListItems.
+func (c *Client) ShowStories(ctx context.Context, opts ...CallOption) iter.Seq2[*Item, error] {
+	return c.ListItems(ctx, ListShow, opts...)
+}
+
+// JobStories returns an iterator over the job stories, hydrated lazily as by ListItems.
+func (c *Client) JobStories(ctx context.Context, opts ...CallOption) iter.Seq2[*Item, error] {
+	return c.ListItems(ctx, ListJob, opts...)
+}
+
+// Updates returns an iterator over the changes reported by the updates endpoint,
+// polled or streamed as by StartUpdates. Polling errors are yielded inline and
+// iteration continues; breaking out of the loop stops polling:
+//
+//	for updates, err := range client.Updates(ctx) {
+//		if err != nil {
+//			log.Print(err)
+//			continue
+//		}
+//		fmt.Println(updates.Items)
+//	}
+//
+// As with StartUpdatesWithErrors, errors that occur while the loop body is still
+// busy with an earlier value may be dropped. The sequence ends when the context is
+// canceled or the client is closed; if the client is already closed, ErrClientClosed
+// is yielded.
+func (c *Client) Updates(ctx context.Context) iter.Seq2[Updates, error] {
+	return func(yield func(Updates, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		updatesCh, errCh, err := c.StartUpdatesWithErrors(ctx)
+		if err != nil {
+			yield(Updates{}, err)
+			return
+		}
+
+		// Wait for the poller to exit before returning
+		defer func() {
+			cancel()
+			for range updatesCh {
+			}
+		}()
+
+		for {
+			select {
+			case updates, ok := <-updatesCh:
+				if !ok {
+					return
+				}
+				if !yield(updates, nil) {
+					return
+				}
+			case err, ok := <-errCh:
+				if !ok {
+					errCh = nil
+					continue
+				}
+				if !yield(Updates{}, err) {
+					return
+				}
+			}
+		}
+	}
+}
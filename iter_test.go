@@ -0,0 +1,156 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTopStoriesIterator(t *testing.T) {
+	var itemRequests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/topstories.json" {
+			_, _ = w.Write([]byte("[1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20]"))
+			return
+		}
+		atomic.AddInt32(&itemRequests, 1)
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+		_, _ = w.Write([]byte(`{"id": ` + id + `, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(2))
+
+	var ids []int
+	for item, err := range client.TopStories(context.Background()) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, item.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Errorf("Expected the first three stories, got %v", ids)
+	}
+	// Fetching runs at most the lookahead plus the in-flight requests ahead
+	if n := atomic.LoadInt32(&itemRequests); n > 7 {
+		t.Errorf("Expected breaking early to stop fetching, got %d item requests", n)
+	}
+}
+
+func TestItemsIterator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item/1.json":
+			_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+		case "/item/2.json":
+			_, _ = w.Write([]byte(`{"id": 2, "type": "story", "dead": true}`))
+		case "/item/3.json":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte("null"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+
+	var ids []int
+	var errs []error
+	for item, err := range client.Items(context.Background(), []int{1, 2, 3, 4}, SkipDeadAndDeleted()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, item.ID)
+	}
+
+	if !reflect.DeepEqual(ids, []int{1}) {
+		t.Errorf("Expected only the live story, got %v", ids)
+	}
+	if len(errs) != 2 || !errors.Is(errs[1], ErrNotFound) {
+		t.Errorf("Expected errors for items 3 and 4, got %v", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var canceledErrs []error
+	for _, err := range client.Items(ctx, []int{1}) {
+		canceledErrs = append(canceledErrs, err)
+	}
+	if len(canceledErrs) != 1 || !errors.Is(canceledErrs[0], context.Canceled) {
+		t.Errorf("Expected a single context.Canceled error, got %v", canceledErrs)
+	}
+
+	for _, err := range client.ListItems(context.Background(), ListNew) {
+		if err == nil {
+			t.Errorf("Expected the list error")
+		}
+	}
+}
+
+func TestUpdatesIterator(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch polls.Add(1) {
+		case 1:
+			_, _ = w.Write([]byte(`{"items": [1, 2], "profiles": ["pg"]}`))
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{"items": [3], "profiles": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Millisecond), WithMaxRetries(0))
+	defer client.Close()
+
+	var items []int
+	var errs int
+	for updates, err := range client.Updates(context.Background()) {
+		if err != nil {
+			errs++
+			continue
+		}
+		items = append(items, updates.Items...)
+		if len(items) == 3 {
+			break
+		}
+	}
+
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(items, want) {
+		t.Errorf("Expected items %v, got %v", want, items)
+	}
+	if errs != 1 {
+		t.Errorf("Expected 1 inline error, got %d", errs)
+	}
+
+	// Breaking out of the loop stops polling; a request already sent may still arrive
+	stopped := polls.Load()
+	time.Sleep(20 * time.Millisecond)
+	if got := polls.Load(); got > stopped+1 {
+		t.Errorf("Expected polling to stop after the loop, got %d more polls", got-stopped)
+	}
+}
+
+func TestUpdatesIteratorClosedClient(t *testing.T) {
+	client := NewClient()
+	_ = client.Close()
+
+	for _, err := range client.Updates(context.Background()) {
+		if !errors.Is(err, ErrClientClosed) {
+			t.Errorf("Expected ErrClientClosed, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// KarmaChange reports that a user's karma changed.
+type KarmaChange struct {
+	// Username is the user whose karma changed.
+	Username string
+
+	// Time is when the change was observed.
+	Time time.Time
+
+	// Karma is the user's new karma.
+	Karma int
+
+	// Delta is the difference from the previously observed karma. It is 0 for the
+	// first observation of a user, which establishes the baseline.
+	Delta int
+}
+
+// KarmaStore persists observed karma, so deltas stay correct across restarts and the
+// history of each user can be charted. Implementations must be safe for concurrent
+// use.
+type KarmaStore interface {
+	// LastKarma returns the most recently saved karma of the user and whether there
+	// is one.
+	LastKarma(ctx context.Context, username string) (int, bool, error)
+
+	// SaveKarma records an observation.
+	SaveKarma(ctx context.Context, change KarmaChange) error
+}
+
+// MemoryKarmaStore is a KarmaStore that keeps the history of every user in memory.
+type MemoryKarmaStore struct {
+	mu      sync.Mutex
+	history map[string][]KarmaChange
+}
+
+// NewMemoryKarmaStore creates an empty in-memory karma store.
+func NewMemoryKarmaStore() *MemoryKarmaStore {
+	return &MemoryKarmaStore{history: make(map[string][]KarmaChange)}
+}
+
+// LastKarma returns the most recently saved karma of the user.
+func (s *MemoryKarmaStore) LastKarma(_ context.Context, username string) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := s.history[username]
+	if len(history) == 0 {
+		return 0, false, nil
+	}
+	return history[len(history)-1].Karma, true, nil
+}
+
+// SaveKarma appends change to the user's history.
+func (s *MemoryKarmaStore) SaveKarma(_ context.Context, change KarmaChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.history[change.Username] = append(s.history[change.Username], change)
+	return nil
+}
+
+// History returns the saved observations of a user in the order they were made.
+func (s *MemoryKarmaStore) History(username string) []KarmaChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]KarmaChange(nil), s.history[username]...)
+}
+
+// KarmaTracker follows the karma of a set of users and reports every change. The
+// first observation of each user is saved to the store as a baseline but not
+// reported; later observations are reported and saved only when the karma changed.
+type KarmaTracker struct {
+	client    *Client
+	store     KarmaStore
+	usernames []string
+	now       func() time.Time
+
+	mu   sync.Mutex
+	last map[string]int
+}
+
+// NewKarmaTracker creates a tracker for the given users that persists observations
+// in store. A nil store keeps only the latest karma of each user, in memory.
+func (c *Client) NewKarmaTracker(store KarmaStore, usernames ...string) *KarmaTracker {
+	return &KarmaTracker{
+		client:    c,
+		store:     store,
+		usernames: append([]string(nil), usernames...),
+		now:       c.clock().Now,
+		last:      make(map[string]int),
+	}
+}
+
+// Start fetches every tracked user immediately and then every PollInterval, and
+// returns a channel of karma changes. Errors are logged and passed to the
+// ErrorHandler. The returned channel is closed when the context is canceled.
+func (t *KarmaTracker) Start(ctx context.Context) (<-chan KarmaChange, error) {
+	c := t.client
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changesCh := make(chan KarmaChange, len(t.usernames))
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(changesCh)
+
+		c.pollEvery(ctx, c.Config.PollInterval, func() {
+			t.refresh(ctx, t.usernames, changesCh)
+		})
+	})
+
+	return changesCh, nil
+}
+
+// Subscribe fetches every tracked user once and then re-fetches only the users the
+// updates feed reports as changed, which takes far fewer requests than Start for
+// large user lists. It returns a channel of karma changes that is closed when the
+// context is canceled.
+func (t *KarmaTracker) Subscribe(ctx context.Context) (<-chan KarmaChange, error) {
+	c := t.client
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[string]struct{}, len(t.usernames))
+	for _, username := range t.usernames {
+		tracked[username] = struct{}{}
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	changesCh := make(chan KarmaChange, len(t.usernames))
+
+	c.goBackground(func() { c.runUpdates(ctx, updatesCh, nil) })
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(changesCh)
+
+		t.refresh(ctx, t.usernames, changesCh)
+
+		for updates := range updatesCh {
+			var changed []string
+			for _, username := range updates.Profiles {
+				if _, ok := tracked[username]; ok {
+					changed = append(changed, username)
+				}
+			}
+			if len(changed) > 0 {
+				t.refresh(ctx, changed, changesCh)
+			}
+		}
+	})
+
+	return changesCh, nil
+}
+
+// refresh fetches usernames and sends a change for every user whose karma changed.
+func (t *KarmaTracker) refresh(ctx context.Context, usernames []string, changesCh chan<- KarmaChange) {
+	users, err := t.client.GetUsersBatch(ctx, usernames, WithNoCache())
+	if err != nil {
+		t.reportError(ctx, err)
+	}
+
+	// Users arrive in completion order; report them in a stable order
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	for _, user := range users {
+		change, changed, err := t.observe(ctx, user)
+		if err != nil {
+			t.reportError(ctx, err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		select {
+		case changesCh <- change:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// observe records the user's current karma and reports whether it changed since the
+// previous observation.
+func (t *KarmaTracker) observe(ctx context.Context, user *User) (KarmaChange, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, ok := t.last[user.ID]
+	if !ok && t.store != nil {
+		var err error
+		previous, ok, err = t.store.LastKarma(ctx, user.ID)
+		if err != nil {
+			return KarmaChange{}, false, fmt.Errorf("failed to load karma of %s: %w", user.ID, err)
+		}
+	}
+
+	change := KarmaChange{Username: user.ID, Time: t.now(), Karma: user.Karma}
+	if ok {
+		if user.Karma == previous {
+			return KarmaChange{}, false, nil
+		}
+		change.Delta = user.Karma - previous
+	}
+
+	if t.store != nil {
+		if err := t.store.SaveKarma(ctx, change); err != nil {
+			return KarmaChange{}, false, fmt.Errorf("failed to save karma of %s: %w", user.ID, err)
+		}
+	}
+	t.last[user.ID] = user.Karma
+
+	return change, ok, nil
+}
+
+// reportError logs err and passes it to the ErrorHandler unless the context is done.
+func (t *KarmaTracker) reportError(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	t.client.logger().Warn("failed to track karma", "error", err)
+	t.client.handleError(err)
+}
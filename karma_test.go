@@ -0,0 +1,104 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// karmaServer serves alice with karma growing by 10 on every request after the
+// first and bob with a constant karma of 7.
+func karmaServer(t *testing.T, updates string) *httptest.Server {
+	t.Helper()
+
+	var aliceCalls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.URL.Path {
+		case "/user/alice.json":
+			n := atomic.AddInt32(&aliceCalls, 1)
+			resp = `{"id": "alice", "karma": ` + strconv.Itoa(int(90+n*10)) + `}`
+		case "/user/bob.json":
+			resp = `{"id": "bob", "karma": 7}`
+		case "/updates.json":
+			resp = updates
+		default:
+			resp = "null"
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+}
+
+func TestKarmaTrackerStart(t *testing.T) {
+	server := karmaServer(t, `{}`)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(10*time.Millisecond))
+
+	store := NewMemoryKarmaStore()
+	_ = store.SaveKarma(context.Background(), KarmaChange{Username: "bob", Karma: 5})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	changes, err := client.NewKarmaTracker(store, "alice", "bob").Start(ctx)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// Bob's stored karma makes his first observation a change; alice's first one is a baseline
+	want := []KarmaChange{
+		{Username: "bob", Karma: 7, Delta: 2},
+		{Username: "alice", Karma: 110, Delta: 10},
+		{Username: "alice", Karma: 120, Delta: 10},
+	}
+	for _, w := range want {
+		select {
+		case got := <-changes:
+			if got.Username != w.Username || got.Karma != w.Karma || got.Delta != w.Delta || got.Time.IsZero() {
+				t.Errorf("Expected change %+v, got %+v", w, got)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for %+v", w)
+		}
+	}
+	cancel()
+	for range changes {
+	}
+
+	history := store.History("alice")
+	if len(history) < 3 || history[0].Karma != 100 || history[0].Delta != 0 {
+		t.Errorf("Expected alice's baseline and changes to be saved, got %+v", history)
+	}
+	if history := store.History("bob"); len(history) != 2 {
+		t.Errorf("Expected bob's unchanged karma not to be saved again, got %+v", history)
+	}
+}
+
+func TestKarmaTrackerSubscribe(t *testing.T) {
+	server := karmaServer(t, `{"items": [], "profiles": ["carol", "alice"]}`)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	changes, err := client.NewKarmaTracker(nil, "alice", "bob").Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	select {
+	case got := <-changes:
+		if got.Username != "alice" || got.Karma != 110 || got.Delta != 10 {
+			t.Errorf("Unexpected change %+v", got)
+		}
+	case <-ctx.Done():
+		t.Fatalf("Timed out waiting for a change")
+	}
+}
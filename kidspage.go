@@ -0,0 +1,116 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KidsPage is one page of an item's direct replies.
+type KidsPage struct {
+	// Items are the replies on this page, in ranked display order.
+	Items []*Item
+
+	// Total is the number of direct replies the item has, for showing how many
+	// are left to load.
+	Total int
+
+	// NextCursor continues with the next page, or is empty after the last reply.
+	NextCursor string
+}
+
+// GetKidsPage retrieves one page of pageSize direct replies of an item, or
+// DefaultCursorPageSize if pageSize is 0, so UIs of huge threads can offer "load
+// more comments" without fetching whole subtrees. Pass an empty cursor for the first
+// page and the returned NextCursor for the following ones. The item is fetched again
+// for every page, so replies posted in the meantime are picked up.
+//
+// Cursors remember the last reply of their page, so a page continues after it even
+// when ranking has moved it; if it is gone, the page continues at the same position.
+// Replies that fail to load are left out and the first failure is returned as the
+// error together with the page. Call options apply to every fetch.
+func (c *Client) GetKidsPage(ctx context.Context, itemID int, cursor string, pageSize int, opts ...CallOption) (*KidsPage, error) {
+	offset, lastID, err := parseKidsCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultCursorPageSize
+	}
+
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetKidsPage", ItemID: itemID})
+
+	page, err := c.getKidsPage(ctx, itemID, offset, lastID, pageSize, o, itemOptions(opts))
+	end(err)
+
+	return page, err
+}
+
+// getKidsPage implements GetKidsPage for a parsed cursor.
+func (c *Client) getKidsPage(ctx context.Context, itemID, offset, lastID, pageSize int, o callOptions, opts []CallOption) (*KidsPage, error) {
+	item, err := c.GetItem(ctx, itemID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	kids := item.Kids
+
+	// Continue after the last reply of the previous page, wherever it is now
+	start := min(offset, len(kids))
+	for i, id := range kids {
+		if id == lastID {
+			start = i + 1
+			break
+		}
+	}
+
+	ids := kids[start:min(start+pageSize, len(kids))]
+	page := &KidsPage{Items: []*Item{}, Total: len(kids)}
+	if start+len(ids) < len(kids) {
+		page.NextCursor = formatKidsCursor(start+len(ids), ids[len(ids)-1])
+	}
+	if len(ids) == 0 {
+		return page, nil
+	}
+
+	results := c.fetchItems(ctx, ids, opts...)
+	if o.skipTombstones {
+		results = withoutTombstones(results)
+	}
+	collector := newItemCollector(len(results))
+	collector.add(results)
+	items, err := collector.result()
+	if items != nil {
+		page.Items = items
+	}
+	return page, err
+}
+
+// formatKidsCursor encodes the position after a page and the last reply on it.
+func formatKidsCursor(offset, lastID int) string {
+	return fmt.Sprintf("%d:%d", offset, lastID)
+}
+
+// parseKidsCursor decodes a cursor issued by GetKidsPage. An empty cursor starts
+// at the first reply.
+func parseKidsCursor(cursor string) (offset, lastID int, err error) {
+	if cursor == "" {
+		return 0, 0, nil
+	}
+
+	offsetText, idText, ok := strings.Cut(cursor, ":")
+	if ok {
+		offset, err = strconv.Atoi(offsetText)
+		if err == nil {
+			lastID, err = strconv.Atoi(idText)
+		}
+	}
+	if !ok || err != nil || offset <= 0 || lastID <= 0 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	return offset, lastID, nil
+}
@@ -0,0 +1,98 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestGetKidsPage(t *testing.T) {
+	var mu sync.Mutex
+	kids := `[2, 3, 4, 5, 6, 7, 8]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		_, _ = fmt.Sscanf(r.URL.Path, "/item/%d.json", &id)
+		if id == 1 {
+			mu.Lock()
+			defer mu.Unlock()
+			_, _ = fmt.Fprintf(w, `{"id": 1, "type": "story", "kids": %s}`, kids)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id": %d, "type": "comment", "parent": 1}`, id)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	ctx := context.Background()
+
+	pageIDs := func(page *KidsPage) []int {
+		var ids []int
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	page, err := client.GetKidsPage(ctx, 1, "", 3)
+	if err != nil {
+		t.Fatalf("GetKidsPage() error = %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(page)); got != "[2 3 4]" || page.Total != 7 || page.NextCursor == "" {
+		t.Fatalf("Expected [2 3 4] of 7 with a cursor, got %s of %d, cursor %q", got, page.Total, page.NextCursor)
+	}
+
+	// Ranking moves the last reply of the first page down; the next page follows it
+	mu.Lock()
+	kids = `[2, 3, 5, 4, 6, 7, 8]`
+	mu.Unlock()
+
+	page, err = client.GetKidsPage(ctx, 1, page.NextCursor, 3)
+	if err != nil {
+		t.Fatalf("GetKidsPage() error = %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(page)); got != "[6 7 8]" || page.NextCursor != "" {
+		t.Errorf("Expected the last page [6 7 8], got %s with cursor %q", got, page.NextCursor)
+	}
+
+	// A vanished reply continues at the same position
+	mu.Lock()
+	kids = `[2, 3, 5, 6, 7, 8]`
+	mu.Unlock()
+
+	page, err = client.GetKidsPage(ctx, 1, formatKidsCursor(3, 4), 2)
+	if err != nil {
+		t.Fatalf("GetKidsPage() error = %v", err)
+	}
+	if got := fmt.Sprint(pageIDs(page)); got != "[6 7]" || page.NextCursor == "" {
+		t.Errorf("Expected [6 7] with a cursor, got %s with cursor %q", got, page.NextCursor)
+	}
+}
+
+func TestGetKidsPageInvalidCursor(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:0/"))
+
+	for _, cursor := range []string{"abc", "3", "0:4", "3:x", "-1:4"} {
+		if _, err := client.GetKidsPage(context.Background(), 1, cursor, 10); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Cursor %q: expected ErrInvalidCursor, got %v", cursor, err)
+		}
+	}
+}
+
+func TestGetKidsPageNoKids(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	page, err := NewClient(WithBaseURL(server.URL+"/")).GetKidsPage(context.Background(), 1, "", 0)
+	if err != nil {
+		t.Fatalf("GetKidsPage() error = %v", err)
+	}
+	if len(page.Items) != 0 || page.Total != 0 || page.NextCursor != "" {
+		t.Errorf("Expected an empty last page, got %+v", page)
+	}
+}
@@ -0,0 +1,84 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned when starting background work on a client that has been closed.
+var ErrClientClosed = errors.New("hnapi: client closed")
+
+// lifecycle tracks the background goroutines started by a client so that Close can
+// cancel them and wait for them to exit.
+type lifecycle struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// newLifecycle creates a lifecycle whose context is canceled by Close.
+func newLifecycle() *lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &lifecycle{ctx: ctx, cancel: cancel}
+}
+
+// Close cancels all background work started by the client (pollers, streams,
+// watchers, and trackers), waits for their goroutines to exit, and closes idle
+// HTTP connections. Channels returned by the client are closed as their goroutines
+// exit. After Close, starting new background work fails with ErrClientClosed;
+// one-off requests such as GetItem keep working.
+func (c *Client) Close() error {
+	c.lifecycle.mu.Lock()
+	c.lifecycle.closed = true
+	c.lifecycle.mu.Unlock()
+
+	c.lifecycle.cancel()
+	c.lifecycle.wg.Wait()
+
+	if c.Config.HTTPClient != nil {
+		c.Config.HTTPClient.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+// startBackground derives a context for background work that is canceled when either
+// ctx is canceled or the client is closed. It fails if the client is already closed.
+// The caller must call cancel once the work is done, or when it fails to start, to
+// release the context.
+func (c *Client) startBackground(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	c.lifecycle.mu.Lock()
+	closed := c.lifecycle.closed
+	c.lifecycle.mu.Unlock()
+	if closed {
+		return nil, nil, ErrClientClosed
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.lifecycle.ctx, cancel)
+	context.AfterFunc(ctx, func() { stop() })
+
+	return ctx, cancel, nil
+}
+
+// goBackground runs fn in a goroutine that Close waits for.
+func (c *Client) goBackground(fn func()) {
+	c.lifecycle.mu.Lock()
+	defer c.lifecycle.mu.Unlock()
+
+	// Once closed, Close may already be waiting, so the goroutine is not tracked.
+	// Its context is canceled, so it exits promptly anyway.
+	if c.lifecycle.closed {
+		go fn()
+		return
+	}
+
+	c.lifecycle.wg.Add(1)
+	go func() {
+		defer c.lifecycle.wg.Done()
+		fn()
+	}()
+}
@@ -0,0 +1,72 @@
+package hnapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter paces the requests of one or more clients. Wait blocks until a request
+// may be sent or the context is done. *rate.Limiter from golang.org/x/time/rate
+// satisfies it, as does the TokenBucket returned by NewTokenBucket.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a Limiter that allows bursts of up to burst requests and refills
+// at rate requests per second. It is safe for concurrent use by multiple clients.
+type TokenBucket struct {
+	clock Clock
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a full token bucket refilled at rate tokens per second.
+// A burst below 1 is treated as 1. A rate of 0 or less means unlimited: Wait
+// returns immediately.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return NewTokenBucketWithClock(rate, burst, realClock{})
+}
+
+// NewTokenBucketWithClock is like NewTokenBucket but reads the time from clock, so
+// tests can drive the refills with a fake clock.
+func NewTokenBucketWithClock(rate float64, burst int, clock Clock) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{clock: clock, rate: rate, burst: float64(burst), tokens: float64(burst), last: clock.Now()}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return ctx.Err()
+	}
+	for {
+		b.mu.Lock()
+		now := b.clock.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.clock.After(delay):
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(100, 2)
+	ctx := context.Background()
+
+	// The burst is available immediately
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := b.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("Expected the burst without waiting, took %v", elapsed)
+	}
+
+	// The next token takes about 10ms to refill
+	if err := b.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("Expected to wait for a refill, took %v", elapsed)
+	}
+
+	slow := NewTokenBucket(0.001, 1)
+	_ = slow.Wait(ctx)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := slow.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTokenBucketUnlimited(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		b := NewTokenBucket(rate, 1)
+		done := make(chan error, 1)
+		go func() {
+			for i := 0; i < 100; i++ {
+				if err := b.Wait(context.Background()); err != nil {
+					done <- err
+					return
+				}
+			}
+			done <- nil
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("rate %v: Wait() error = %v", rate, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("rate %v: Expected Wait to return immediately", rate)
+		}
+	}
+}
+
+// skipClock is a Clock whose After jumps the time forward instead of waiting.
+type skipClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *skipClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *skipClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *skipClock) NewTicker(time.Duration) Ticker {
+	panic("not used")
+}
+
+func TestTokenBucketClock(t *testing.T) {
+	start := time.Unix(1175714200, 0)
+	clock := &skipClock{now: start}
+	b := NewTokenBucketWithClock(1, 1, clock)
+
+	// The second token takes a second of the clock's time, not of real time
+	for i := 0; i < 2; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	if elapsed := clock.Now().Sub(start); elapsed < time.Second {
+		t.Errorf("Expected the clock to advance by a second, advanced by %v", elapsed)
+	}
+}
+
+// countingLimiter counts the requests it lets through.
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return ctx.Err()
+}
+
+func TestSharedLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+	first := NewClient(WithBaseURL(server.URL+"/"), WithSharedLimiter(limiter))
+	second := NewClient(WithBaseURL(server.URL+"/"), WithSharedLimiter(limiter))
+
+	if _, err := first.GetItem(context.Background(), 1); err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if _, err := second.GetItemsBatch(context.Background(), []int{1, 2}); err != nil {
+		t.Fatalf("GetItemsBatch() error = %v", err)
+	}
+
+	if got := limiter.waits.Load(); got != 3 {
+		t.Errorf("Expected the limiter to pace 3 requests, got %d", got)
+	}
+}
+
+func TestSharedLimiterCanceled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The only token is spent, so the request cannot be sent before the deadline
+	limiter := NewTokenBucket(0.001, 1)
+	_ = limiter.Wait(context.Background())
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithSharedLimiter(limiter))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetItem(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected no requests, got %d", got)
+	}
+}
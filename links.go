@@ -0,0 +1,31 @@
+package hnapi
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// hnSiteURL is the base URL of the Hacker News website.
+const hnSiteURL = "https://news.ycombinator.com/"
+
+// ItemURL returns the canonical Hacker News page for the item with the given ID,
+// such as "https://news.ycombinator.com/item?id=8863".
+func ItemURL(id int) string {
+	return hnSiteURL + "item?id=" + strconv.Itoa(id)
+}
+
+// UserURL returns the canonical Hacker News profile page for the given username,
+// such as "https://news.ycombinator.com/user?id=pg".
+func UserURL(username string) string {
+	return hnSiteURL + "user?id=" + url.QueryEscape(username)
+}
+
+// HNLink returns the item's page on Hacker News.
+func (i *Item) HNLink() string {
+	return ItemURL(i.ID)
+}
+
+// HNLink returns the user's profile page on Hacker News.
+func (u *User) HNLink() string {
+	return UserURL(u.ID)
+}
@@ -0,0 +1,23 @@
+package hnapi
+
+import "testing"
+
+func TestPermalinks(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "ItemURL", got: ItemURL(8863), want: "https://news.ycombinator.com/item?id=8863"},
+		{name: "UserURL", got: UserURL("pg"), want: "https://news.ycombinator.com/user?id=pg"},
+		{name: "UserURL escapes", got: UserURL("a b&c"), want: "https://news.ycombinator.com/user?id=a+b%26c"},
+		{name: "Item.HNLink", got: (&Item{ID: 121003}).HNLink(), want: "https://news.ycombinator.com/item?id=121003"},
+		{name: "User.HNLink", got: (&User{ID: "dhouston"}).HNLink(), want: "https://news.ycombinator.com/user?id=dhouston"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
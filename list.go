@@ -0,0 +1,164 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// List identifies one of the Hacker News story lists.
+type List int
+
+const (
+	// ListTop is the top stories list.
+	ListTop List = iota
+
+	// ListNew is the newest stories list.
+	ListNew
+
+	// ListBest is the best stories list.
+	ListBest
+
+	// ListAsk is the Ask HN stories list.
+	ListAsk
+
+	// ListShow is the Show HN stories list.
+	ListShow
+
+	// ListJob is the job stories list.
+	ListJob
+)
+
+// String returns the name of the list.
+func (l List) String() string {
+	switch l {
+	case ListTop:
+		return "top"
+	case ListNew:
+		return "new"
+	case ListBest:
+		return "best"
+	case ListAsk:
+		return "ask"
+	case ListShow:
+		return "show"
+	case ListJob:
+		return "job"
+	default:
+		return fmt.Sprintf("List(%d)", int(l))
+	}
+}
+
+// endpoint returns the API endpoint of the list.
+func (l List) endpoint() (string, error) {
+	switch l {
+	case ListTop, ListNew, ListBest, ListAsk, ListShow, ListJob:
+		return l.String() + "stories.json", nil
+	default:
+		return "", fmt.Errorf("unknown list %v", l)
+	}
+}
+
+// GetList retrieves the story IDs of the given list.
+// It returns a slice of story IDs or an error if the request fails or the context is canceled.
+// Call options override client defaults for this call; WithNoCache bypasses the list cache.
+func (c *Client) GetList(ctx context.Context, list List, opts ...CallOption) ([]int, error) {
+	endpoint, err := list.endpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	o := newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+
+	return c.getStories(ctx, endpoint, o)
+}
+
+// GetListItems retrieves the first n stories of the given list, or all of them if n
+// is 0 or less, hydrated into items in list order. Stories are fetched as one batch,
+// so the error semantics and call options of GetItemsBatch apply.
+func (c *Client) GetListItems(ctx context.Context, list List, n int, opts ...CallOption) ([]*Item, error) {
+	ids, err := c.GetList(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && n < len(ids) {
+		ids = ids[:n]
+	}
+	return c.GetItemsBatch(ctx, ids, opts...)
+}
+
+// ListUpdate reports how a list changed between two polls.
+type ListUpdate struct {
+	// List is the list that changed.
+	List List
+
+	// IDs is the full current content of the list.
+	IDs []int
+
+	// Added are the IDs that appeared since the previous poll, in list order.
+	Added []int
+
+	// Removed are the IDs that disappeared since the previous poll, in their previous order.
+	Removed []int
+}
+
+// StartListUpdates polls the given list every PollInterval and returns a channel
+// that receives the IDs added and removed compared to the previous poll. The first
+// poll reports every ID as added. Polls that do not change membership of the list
+// are not reported.
+//
+// Polling errors are logged and passed to the ErrorHandler. The returned channel is
+// closed when the context is canceled.
+func (c *Client) StartListUpdates(ctx context.Context, list List) (<-chan ListUpdate, error) {
+	if _, err := list.endpoint(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updatesCh := make(chan ListUpdate, 1)
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(updatesCh)
+
+		var previous []int
+		c.pollEvery(ctx, c.Config.PollInterval, func() {
+			ids, err := c.GetList(ctx, list)
+			if err != nil {
+				if ctx.Err() == nil {
+					c.logger().Warn("failed to poll list", "list", list, "error", err)
+					c.handleError(err)
+				}
+				return
+			}
+
+			diff := Diff(previous, ids)
+			previous = ids
+			if len(diff.Added) == 0 && len(diff.Removed) == 0 {
+				return
+			}
+
+			update := ListUpdate{List: list, IDs: ids, Added: changeIDs(diff.Added), Removed: changeIDs(diff.Removed)}
+			select {
+			case updatesCh <- update:
+			case <-ctx.Done():
+			}
+		})
+	})
+
+	return updatesCh, nil
+}
+
+// changeIDs returns the IDs of changes, in order.
+func changeIDs(changes []ListChange) []int {
+	var ids []int
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	return ids
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -124,7 +125,7 @@ func TestGetStories(t *testing.T) {
 			defer server.Close()
 
 			// Create client with the test server URL
-			client := NewClient(WithBaseURL(server.URL + "/"))
+			client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
 
 			// Set up context
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -162,3 +163,134 @@ func TestGetStories(t *testing.T) {
 		})
 	}
 }
+
+func TestGetList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/askstories.json") {
+			t.Errorf("Expected request path to end with /askstories.json, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("[1, 2, 3]"))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	ids, err := client.GetList(context.Background(), ListAsk)
+	if err != nil {
+		t.Fatalf("GetList() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Errorf("GetList() = %v, want [1 2 3]", ids)
+	}
+
+	if _, err := client.GetList(context.Background(), List(42)); err == nil {
+		t.Errorf("Expected error for unknown list")
+	}
+}
+
+func TestGetListItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/topstories.json" {
+			_, _ = w.Write([]byte("[3, 1, 2]"))
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+		_, _ = w.Write([]byte(`{"id": ` + id + `, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	for _, tt := range []struct {
+		n    int
+		want []int
+	}{
+		{n: 2, want: []int{3, 1}},
+		{n: 0, want: []int{3, 1, 2}},
+		{n: 10, want: []int{3, 1, 2}},
+	} {
+		items, err := client.GetListItems(context.Background(), ListTop, tt.n)
+		if err != nil {
+			t.Fatalf("GetListItems(%d) error = %v", tt.n, err)
+		}
+
+		var ids []int
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("GetListItems(%d) = %v, want %v", tt.n, ids, tt.want)
+		}
+	}
+}
+
+func TestStartListUpdates(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/newstories.json") {
+			t.Errorf("Expected request path to end with /newstories.json, got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			resp = "[3, 2, 1]"
+		case 2:
+			// Same membership, not reported
+			resp = "[3, 2, 1]"
+		default:
+			resp = "[5, 4, 3, 2]"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(20*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatesCh, err := client.StartListUpdates(ctx, ListNew)
+	if err != nil {
+		t.Fatalf("StartListUpdates() error = %v", err)
+	}
+
+	want := []ListUpdate{
+		{List: ListNew, IDs: []int{3, 2, 1}, Added: []int{3, 2, 1}},
+		{List: ListNew, IDs: []int{5, 4, 3, 2}, Added: []int{5, 4}, Removed: []int{1}},
+	}
+
+	for _, wantUpdate := range want {
+		select {
+		case update := <-updatesCh:
+			if !reflect.DeepEqual(update, wantUpdate) {
+				t.Errorf("Expected update %+v, got %+v", wantUpdate, update)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for update %+v", wantUpdate)
+		}
+	}
+
+	cancel()
+	for range updatesCh {
+	}
+
+	if _, err := client.StartListUpdates(context.Background(), List(42)); err == nil {
+		t.Errorf("Expected error for unknown list")
+	}
+}
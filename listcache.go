@@ -0,0 +1,158 @@
+package hnapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// listCache holds recently fetched story lists, keyed by endpoint. Entries are
+// served as is while fresh; once stale they are still served for another TTL while
+// a background request refreshes them. Lists are refreshed with conditional
+// requests, so an unchanged list costs a 304 instead of a full transfer.
+type listCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*listEntry
+}
+
+// listEntry is a cached story list.
+type listEntry struct {
+	ids        []int
+	etag       string
+	fetched    time.Time
+	refreshing bool
+}
+
+// newListCache creates a list cache, or returns nil if ttl disables caching.
+func newListCache(ttl time.Duration) *listCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &listCache{ttl: ttl, entries: make(map[string]*listEntry)}
+}
+
+// lookup returns a copy of the cached list for endpoint, if it is young enough to
+// serve, and whether the caller should start a background refresh. A list is only
+// handed out for refreshing once until it is stored again. The ETag of the cached
+// list is returned even when the list is too old to serve, for revalidating it.
+func (lc *listCache) lookup(endpoint string, now time.Time) (ids []int, etag string, ok, refresh bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry := lc.entries[endpoint]
+	if entry == nil {
+		return nil, "", false, false
+	}
+
+	age := now.Sub(entry.fetched)
+	if age >= 2*lc.ttl {
+		return nil, entry.etag, false, false
+	}
+
+	if age >= lc.ttl && !entry.refreshing {
+		entry.refreshing = true
+		refresh = true
+	}
+
+	return append([]int(nil), entry.ids...), entry.etag, true, refresh
+}
+
+// store caches a copy of ids as the current list for endpoint.
+func (lc *listCache) store(endpoint string, ids []int, etag string, now time.Time) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.entries[endpoint] = &listEntry{ids: append([]int(nil), ids...), etag: etag, fetched: now}
+}
+
+// revalidate marks the cached list for endpoint as fresh after the server confirmed
+// that etag still matches, and returns a copy of it. It fails if the list has been
+// replaced in the meantime.
+func (lc *listCache) revalidate(endpoint, etag string, now time.Time) ([]int, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry := lc.entries[endpoint]
+	if entry == nil || entry.etag != etag {
+		return nil, false
+	}
+
+	entry.fetched = now
+	entry.refreshing = false
+	return append([]int(nil), entry.ids...), true
+}
+
+// refreshFailed allows another refresh of endpoint to be started.
+func (lc *listCache) refreshFailed(endpoint string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if entry := lc.entries[endpoint]; entry != nil {
+		entry.refreshing = false
+	}
+}
+
+// cachedStories serves a story list from the list cache, fetching it when it is
+// missing or too old and refreshing it in the background when it is stale.
+func (c *Client) cachedStories(ctx context.Context, endpoint string, o callOptions) ([]int, error) {
+	ids, etag, ok, refresh := c.lists.lookup(endpoint, c.clock().Now())
+	if !ok {
+		c.metrics.cacheMisses.Add(1)
+		return c.fetchCachedStories(ctx, endpoint, etag, o)
+	}
+
+	c.metrics.cacheHits.Add(1)
+	if refresh {
+		c.refreshStories(endpoint, etag)
+	}
+	return ids, nil
+}
+
+// fetchCachedStories fetches the list at endpoint into the list cache. A non-empty
+// etag revalidates the cached list instead of transferring it again if unchanged.
+func (c *Client) fetchCachedStories(ctx context.Context, endpoint, etag string, o callOptions) ([]int, error) {
+	cond := &conditional{etag: etag}
+	o.conditional = cond
+
+	ids, err := c.fetchStories(ctx, endpoint, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if cond.notModified {
+		if ids, ok := c.lists.revalidate(endpoint, etag, c.clock().Now()); ok {
+			return ids, nil
+		}
+
+		// The cached list was replaced while the request was in flight
+		return c.fetchCachedStories(ctx, endpoint, "", o)
+	}
+
+	c.lists.store(endpoint, ids, cond.newETag, c.clock().Now())
+	return ids, nil
+}
+
+// refreshStories fetches the list at endpoint in the background and stores it in
+// the list cache. Refreshes outlive the call that triggered them but stop when the
+// client is closed.
+func (c *Client) refreshStories(endpoint, etag string) {
+	ctx, cancel, err := c.startBackground(context.Background())
+	if err != nil {
+		c.lists.refreshFailed(endpoint)
+		return
+	}
+
+	c.goBackground(func() {
+		defer cancel()
+
+		if _, err := c.fetchCachedStories(ctx, endpoint, etag, callOptions{}); err != nil {
+			c.lists.refreshFailed(endpoint)
+			if ctx.Err() == nil {
+				c.logger().Warn("failed to refresh cached list", "endpoint", endpoint, "error", err)
+				c.handleError(err)
+			}
+		}
+	})
+}
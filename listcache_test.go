@@ -0,0 +1,155 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		if n == 1 {
+			_, _ = w.Write([]byte(`[1, 2, 3]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[4, 5, 6]`))
+	}))
+	defer server.Close()
+
+	ttl := 100 * time.Millisecond
+	client := NewClient(WithBaseURL(server.URL+"/"), WithListCache(ttl))
+	defer client.Close()
+
+	ctx := context.Background()
+
+	ids, err := client.GetTopStories(ctx)
+	if err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+	ids[0] = 100
+
+	// Served from the cache, unaffected by the caller modifying its copy
+	ids, err = client.GetTopStories(ctx)
+	if err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+	if ids[0] != 1 {
+		t.Errorf("Expected cached list starting with 1, got %v", ids)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected 1 request within the TTL, got %d", got)
+	}
+
+	stats := client.Stats()
+	if stats.CacheHits != 1 || stats.CacheMisses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, got %d and %d", stats.CacheHits, stats.CacheMisses)
+	}
+
+	// A stale list is served while it is refreshed in the background
+	time.Sleep(ttl)
+	ids, err = client.GetTopStories(ctx)
+	if err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+	if ids[0] != 1 {
+		t.Errorf("Expected stale list starting with 1, got %v", ids)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		ids, err = client.GetTopStories(ctx)
+		if err != nil {
+			t.Fatalf("GetTopStories() error = %v", err)
+		}
+		if ids[0] == 4 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the refreshed list, got %v", ids)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests after one refresh, got %d", got)
+	}
+
+	// Lists are cached per endpoint
+	if _, err := client.GetNewStories(ctx); err != nil {
+		t.Fatalf("GetNewStories() error = %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected a request for another list, got %d requests", got)
+	}
+
+	// WithNoCache bypasses the cache
+	if _, err := client.GetList(ctx, ListTop, WithNoCache()); err != nil {
+		t.Fatalf("GetList() error = %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Expected WithNoCache to make a request, got %d requests", got)
+	}
+
+	if !client.Capabilities().Cache {
+		t.Error("Expected Capabilities().Cache to be true")
+	}
+}
+
+func TestListCacheExpired(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	}))
+	defer server.Close()
+
+	ttl := 10 * time.Millisecond
+	client := NewClient(WithBaseURL(server.URL+"/"), WithListCache(ttl))
+	defer client.Close()
+
+	if _, err := client.GetTopStories(context.Background()); err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+
+	// Lists older than twice the TTL are fetched before returning
+	time.Sleep(2 * ttl)
+	if _, err := client.GetTopStories(context.Background()); err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+	if got := client.Stats().CacheMisses; got != 2 {
+		t.Errorf("Expected 2 cache misses, got %d", got)
+	}
+}
+
+func TestListCacheDisabled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTopStories(context.Background()); err != nil {
+			t.Fatalf("GetTopStories() error = %v", err)
+		}
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests without a cache, got %d", got)
+	}
+	if client.Capabilities().Cache {
+		t.Error("Expected Capabilities().Cache to be false")
+	}
+}
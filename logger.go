@@ -0,0 +1,28 @@
+package hnapi
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler is a slog.Handler that drops every record.
+// It backs the default logger so the client stays silent unless a logger is configured.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// newDiscardLogger returns a logger that discards all output.
+func newDiscardLogger() *slog.Logger {
+	return slog.New(discardHandler{})
+}
+
+// logger returns the configured logger, falling back to a no-op logger.
+func (c *Client) logger() *slog.Logger {
+	if c.Config.Logger == nil {
+		return newDiscardLogger()
+	}
+	return c.Config.Logger
+}
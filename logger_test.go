@@ -0,0 +1,78 @@
+package hnapi
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDefaultLoggerDiscards(t *testing.T) {
+	client := NewClient()
+
+	if client.logger().Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("Expected default logger to discard all records")
+	}
+
+	client.Config.Logger = nil
+	if client.logger() == nil {
+		t.Errorf("Expected a no-op logger when Logger is nil")
+	}
+}
+
+func TestWithLoggerReceivesPollingErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var buf syncBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(time.Hour),
+		WithLogger(logger),
+	)
+
+	if client.Config.Logger != logger {
+		t.Fatalf("Expected Logger to be the custom logger")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	updatesCh, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+	for range updatesCh {
+	}
+
+	if !strings.Contains(buf.String(), "failed to poll updates") {
+		t.Errorf("Expected polling error to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,113 @@
+package hnapi
+
+import "strings"
+
+// markdownEscaper escapes characters that Markdown would otherwise interpret.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// HTMLToMarkdown converts the HTML of an item's text or a user's about field to
+// Markdown. Paragraphs become blank-line separated blocks, italics become *emphasis*,
+// links become [text](url) using the full URL, and preformatted code becomes a
+// fenced code block. Characters with meaning in Markdown are escaped in ordinary text.
+func HTMLToMarkdown(s string) string {
+	var b strings.Builder
+	var link *htmlToken
+	var linkBody strings.Builder
+	inPre := false
+
+	paragraph := func() {
+		if b.Len() > 0 {
+			breakLines(&b, 2)
+		}
+	}
+
+	write := func(text string) {
+		switch {
+		case inPre:
+			b.WriteString(text)
+		case link != nil:
+			linkBody.WriteString(markdownEscaper.Replace(text))
+		default:
+			b.WriteString(markdownEscaper.Replace(text))
+		}
+	}
+
+	// writeMarker writes formatting syntax, which must not be escaped
+	writeMarker := func(marker string) {
+		if link != nil {
+			linkBody.WriteString(marker)
+		} else {
+			b.WriteString(marker)
+		}
+	}
+
+	for _, token := range tokenizeHTML(s) {
+		switch {
+		case token.Tag == "":
+			write(token.Text)
+		case token.Tag == "pre" && !token.Closing:
+			paragraph()
+			b.WriteString("```\n")
+			inPre = true
+		case token.Tag == "pre":
+			if inPre {
+				breakLines(&b, 1)
+				b.WriteString("```")
+				inPre = false
+			}
+			paragraph()
+		case inPre:
+			// Tags inside code blocks, such as <code>, carry no formatting
+		case token.Tag == "a" && !token.Closing:
+			t := token
+			link = &t
+			linkBody.Reset()
+		case token.Tag == "a":
+			if link != nil {
+				b.WriteString(markdownLink(linkBody.String(), link.Href))
+				link = nil
+			}
+		case token.Tag == "i", token.Tag == "em":
+			writeMarker("*")
+		case token.Tag == "code":
+			writeMarker("`")
+		case token.Tag == "p" && !token.Closing:
+			paragraph()
+		case token.Tag == "br":
+			b.WriteString("  \n")
+		}
+	}
+
+	if link != nil {
+		b.WriteString(markdownLink(linkBody.String(), link.Href))
+	}
+	if inPre {
+		breakLines(&b, 1)
+		b.WriteString("```")
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// markdownURLEscaper escapes characters that would end a Markdown link destination.
+var markdownURLEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+
+// markdownLink formats a link whose label is already escaped. The full URL is used
+// as the label when the link text is empty or a truncated copy of the URL.
+func markdownLink(label, href string) string {
+	if href == "" {
+		return label
+	}
+
+	if label == "" || label == markdownEscaper.Replace(href) || isTruncatedLink(label, markdownEscaper.Replace(href)) {
+		label = markdownEscaper.Replace(href)
+	}
+	return "[" + label + "](" + markdownURLEscaper.Replace(href) + ")"
+}
@@ -0,0 +1,60 @@
+package hnapi
+
+import "testing"
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs and entities",
+			html: "It&#x27;s here.<p>Second &amp; last.",
+			want: "It's here.\n\nSecond & last.",
+		},
+		{
+			name: "italics",
+			html: "This is <i>really</i> important.",
+			want: "This is *really* important.",
+		},
+		{
+			name: "named link",
+			html: `Read <a href="https:&#x2F;&#x2F;go.dev&#x2F;doc" rel="nofollow">the docs</a>.`,
+			want: "Read [the docs](https://go.dev/doc).",
+		},
+		{
+			name: "truncated link uses full URL",
+			html: `<a href="https://example.com/a/very/long/path">https://example.com/a/very...</a>`,
+			want: "[https://example.com/a/very/long/path](https://example.com/a/very/long/path)",
+		},
+		{
+			name: "link URL with parentheses",
+			html: `<a href="https://en.wikipedia.org/wiki/Go_(language)">Go</a>`,
+			want: "[Go](https://en.wikipedia.org/wiki/Go_%28language%29)",
+		},
+		{
+			name: "code block",
+			html: "Example:<p><pre><code>  x := a * b\n  fmt.Println(x)\n</code></pre>That's it.",
+			want: "Example:\n\n```\n  x := a * b\n  fmt.Println(x)\n```\n\nThat's it.",
+		},
+		{
+			name: "markdown characters are escaped",
+			html: "2 * 3 = 6 and snake_case [sic]",
+			want: `2 \* 3 = 6 and snake\_case \[sic\]`,
+		},
+		{
+			name: "quote convention is kept",
+			html: "&gt; quoted text<p>reply",
+			want: "> quoted text\n\nreply",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMarkdown(tt.html); got != tt.want {
+				t.Errorf("HTMLToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package hnapi
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the client's runtime statistics.
+type Stats struct {
+	// Requests is the total number of completed API requests.
+	Requests int64
+
+	// Failures is the number of requests that returned an error.
+	Failures int64
+
+	// Retries is the number of request attempts made after the first.
+	Retries int64
+
+	// AverageLatency is the mean duration of completed requests.
+	AverageLatency time.Duration
+
+	// InFlight is the number of requests currently in progress.
+	InFlight int64
+
+	// CacheHits is the number of lookups served from the cache. It stays zero
+	// unless a cache is configured.
+	CacheHits int64
+
+	// CacheMisses is the number of lookups that had to go to the API.
+	CacheMisses int64
+
+	// NotModified is the number of cached responses revalidated by a 304 Not
+	// Modified response instead of being transferred again.
+	NotModified int64
+
+	// UpdatesEmitted is the number of updates sent to subscriber channels.
+	UpdatesEmitted int64
+
+	// UpdatesDropped is the number of updates discarded by the overflow policy.
+	UpdatesDropped int64
+}
+
+// Stats returns a snapshot of the client's runtime statistics. The counters are
+// read individually, so a snapshot taken under load may be slightly inconsistent.
+func (c *Client) Stats() Stats {
+	m := c.metrics
+
+	stats := Stats{
+		Requests:       m.requests.Load(),
+		Failures:       m.failures.Load(),
+		Retries:        m.retries.Load(),
+		InFlight:       m.inFlight.Load(),
+		CacheHits:      m.cacheHits.Load(),
+		CacheMisses:    m.cacheMisses.Load(),
+		NotModified:    m.notModified.Load(),
+		UpdatesEmitted: m.updatesEmitted.Load(),
+		UpdatesDropped: m.updatesDropped.Load(),
+	}
+	if stats.Requests > 0 {
+		stats.AverageLatency = time.Duration(m.latency.Load() / stats.Requests)
+	}
+
+	return stats
+}
+
+// metrics holds the client's runtime counters.
+type metrics struct {
+	requests        atomic.Int64
+	failures        atomic.Int64
+	retries         atomic.Int64
+	itemsFetched    atomic.Int64
+	updatesReceived atomic.Int64
+	updatesEmitted  atomic.Int64
+	updatesDropped  atomic.Int64
+	cacheHits       atomic.Int64
+	cacheMisses     atomic.Int64
+	notModified     atomic.Int64
+	inFlight        atomic.Int64
+
+	// latency is the total duration of completed requests in nanoseconds
+	latency atomic.Int64
+}
+
+// publishExpvar publishes the counters as an expvar.Map under name. If a map with
+// that name already exists, for example from another client, its entries are
+// replaced; if the name is taken by a different kind of variable, nothing is published.
+func (m *metrics) publishExpvar(name string) bool {
+	vars, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		if expvar.Get(name) != nil {
+			return false
+		}
+		vars = expvar.NewMap(name)
+	}
+
+	vars.Set("requests", expvar.Func(func() any { return m.requests.Load() }))
+	vars.Set("errors", expvar.Func(func() any { return m.failures.Load() }))
+	vars.Set("retries", expvar.Func(func() any { return m.retries.Load() }))
+	vars.Set("items_fetched", expvar.Func(func() any { return m.itemsFetched.Load() }))
+	vars.Set("updates_received", expvar.Func(func() any { return m.updatesReceived.Load() }))
+
+	return true
+}
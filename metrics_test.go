@@ -0,0 +1,134 @@
+package hnapi
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithExpvar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/item/2.json") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": 1, "type": "story"}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithMaxRetries(0),
+		WithExpvar("hnapi_test_expvar"),
+	)
+
+	ctx := context.Background()
+	if _, err := client.GetItem(ctx, 1); err != nil {
+		t.Fatalf("GetItem(1) error = %v", err)
+	}
+	if _, err := client.GetItem(ctx, 2); err == nil {
+		t.Fatalf("Expected GetItem(2) to fail")
+	}
+
+	vars, ok := expvar.Get("hnapi_test_expvar").(*expvar.Map)
+	if !ok {
+		t.Fatalf("Expected counters to be published as an expvar.Map")
+	}
+
+	var counters map[string]int64
+	if err := json.Unmarshal([]byte(vars.String()), &counters); err != nil {
+		t.Fatalf("Failed to decode expvar output %q: %v", vars.String(), err)
+	}
+
+	want := map[string]int64{
+		"requests":         2,
+		"errors":           1,
+		"retries":          0,
+		"items_fetched":    1,
+		"updates_received": 0,
+	}
+	for name, value := range want {
+		if counters[name] != value {
+			t.Errorf("Expected %s = %d, got %d", name, value, counters[name])
+		}
+	}
+
+	// A second client with the same prefix takes over the published map
+	NewClient(WithExpvar("hnapi_test_expvar"))
+	if err := json.Unmarshal([]byte(vars.String()), &counters); err != nil {
+		t.Fatalf("Failed to decode expvar output: %v", err)
+	}
+	if counters["requests"] != 0 {
+		t.Errorf("Expected counters of the new client, got %v", counters)
+	}
+}
+
+func TestWithExpvarNameConflict(t *testing.T) {
+	// Registering twice panics, so reruns with -count reuse the variable
+	if expvar.Get("hnapi_test_conflict") == nil {
+		expvar.NewInt("hnapi_test_conflict")
+	}
+
+	// Must not panic when the name is taken by another variable
+	client := NewClient(WithExpvar("hnapi_test_conflict"))
+	if client == nil {
+		t.Fatal("Expected a client")
+	}
+
+	if _, ok := expvar.Get("hnapi_test_conflict").(*expvar.Int); !ok {
+		t.Errorf("Expected existing variable to be left alone")
+	}
+}
+
+func TestStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		if strings.HasSuffix(r.URL.Path, "/item/2.json") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": 1, "type": "story"}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+
+	if stats := client.Stats(); stats != (Stats{}) {
+		t.Errorf("Expected zero stats on a new client, got %+v", stats)
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetItem(ctx, 1); err != nil {
+		t.Fatalf("GetItem(1) error = %v", err)
+	}
+	if _, err := client.GetItem(ctx, 2); err == nil {
+		t.Fatalf("Expected GetItem(2) to fail")
+	}
+
+	stats := client.Stats()
+	if stats.Requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", stats.Requests)
+	}
+	if stats.Failures != 1 {
+		t.Errorf("Expected 1 failure, got %d", stats.Failures)
+	}
+	if stats.InFlight != 0 {
+		t.Errorf("Expected no requests in flight, got %d", stats.InFlight)
+	}
+	if stats.AverageLatency < 5*time.Millisecond {
+		t.Errorf("Expected average latency of at least 5ms, got %v", stats.AverageLatency)
+	}
+}
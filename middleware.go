@@ -0,0 +1,20 @@
+package hnapi
+
+import "net/http"
+
+// RoundTripFunc performs a single HTTP request, like http.RoundTripper.RoundTrip.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to add behavior around every request the client
+// makes, such as auth headers, tracing, request mutation, or custom retry policies.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// do executes req through the configured middleware chain and the HTTP client.
+// The first middleware is the outermost one.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.Config.HTTPClient.Do)
+	for i := len(c.Config.Middleware) - 1; i >= 0; i-- {
+		next = c.Config.Middleware[i](next)
+	}
+	return next(req)
+}
@@ -0,0 +1,77 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Trace"); got != "outer,inner" {
+			t.Errorf("Expected X-Trace header %q, got %q", "outer,inner", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"id": 1, "type": "story"}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				if existing := req.Header.Get("X-Trace"); existing != "" {
+					req.Header.Set("X-Trace", existing+","+name)
+				} else {
+					req.Header.Set("X-Trace", name)
+				}
+				resp, err := next(req)
+				order = append(order, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithMiddleware(tag("outer")),
+		WithMiddleware(tag("inner")),
+	)
+
+	if _, err := client.GetItem(context.Background(), 1); err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Middleware order = %v, want %v", order, want)
+	}
+}
+
+func TestMiddlewareCanShortCircuit(t *testing.T) {
+	client := NewClient(
+		WithBaseURL("http://invalid.invalid/"),
+		WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				rec := httptest.NewRecorder()
+				rec.WriteHeader(http.StatusOK)
+				_, _ = rec.WriteString(`[1, 2, 3]`)
+				return rec.Result(), nil
+			}
+		}),
+	)
+
+	ids, err := client.GetTopStories(context.Background())
+	if err != nil {
+		t.Fatalf("GetTopStories() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", ids)
+	}
+}
@@ -1,5 +1,37 @@
 package hnapi
 
+import "time"
+
+// ItemType is the type of a Hacker News item.
+type ItemType string
+
+const (
+	// TypeStory is a story, including Ask HN and Show HN posts.
+	TypeStory ItemType = "story"
+
+	// TypeComment is a comment.
+	TypeComment ItemType = "comment"
+
+	// TypeJob is a job posting.
+	TypeJob ItemType = "job"
+
+	// TypePoll is a poll.
+	TypePoll ItemType = "poll"
+
+	// TypePollOpt is an option of a poll.
+	TypePollOpt ItemType = "pollopt"
+)
+
+// IsValid reports whether t is one of the item types defined by the API.
+func (t ItemType) IsValid() bool {
+	switch t {
+	case TypeStory, TypeComment, TypeJob, TypePoll, TypePollOpt:
+		return true
+	default:
+		return false
+	}
+}
+
 // Item represents a Hacker News item, which can be a story, comment, job, poll, or pollopt.
 type Item struct {
 	// ID is the unique identifier for this item.
@@ -8,8 +40,8 @@ type Item struct {
 	// Deleted indicates if the item is deleted.
 	Deleted bool `json:"deleted,omitempty"`
 
-	// Type is the type of the item: "job", "story", "comment", "poll", or "pollopt".
-	Type string `json:"type"`
+	// Type is the type of the item: TypeJob, TypeStory, TypeComment, TypePoll, or TypePollOpt.
+	Type ItemType `json:"type"`
 
 	// By is the username of the item's author.
 	By string `json:"by,omitempty"`
@@ -48,6 +80,16 @@ type Item struct {
 	Descendants int `json:"descendants,omitempty"`
 }
 
+// CreatedAt returns when the item was created.
+func (i *Item) CreatedAt() time.Time {
+	return time.Unix(i.Time, 0)
+}
+
+// Age returns how long before now the item was created.
+func (i *Item) Age(now time.Time) time.Duration {
+	return now.Sub(i.CreatedAt())
+}
+
 // User represents a Hacker News user.
 type User struct {
 	// ID is the user's unique username.
@@ -66,6 +108,11 @@ type User struct {
 	Submitted []int `json:"submitted,omitempty"`
 }
 
+// CreatedAt returns when the user was created.
+func (u *User) CreatedAt() time.Time {
+	return time.Unix(u.Created, 0)
+}
+
 // Updates represents the changes from the /v0/updates endpoint.
 type Updates struct {
 	// Items are the IDs of changed or new items.
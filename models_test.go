@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestItemUnmarshal(t *testing.T) {
@@ -150,3 +151,43 @@ func TestUpdatesUnmarshal(t *testing.T) {
 		t.Errorf("Expected Profiles to be %v, got %v", expectedProfiles, updates.Profiles)
 	}
 }
+
+func TestItemTypeIsValid(t *testing.T) {
+	tests := []struct {
+		itemType ItemType
+		want     bool
+	}{
+		{TypeStory, true},
+		{TypeComment, true},
+		{TypeJob, true},
+		{TypePoll, true},
+		{TypePollOpt, true},
+		{"", false},
+		{"Story", false},
+		{"article", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.itemType.IsValid(); got != tt.want {
+			t.Errorf("ItemType(%q).IsValid() = %v, want %v", tt.itemType, got, tt.want)
+		}
+	}
+}
+
+func TestTimeHelpers(t *testing.T) {
+	item := &Item{ID: 8863, Time: 1175714200}
+	want := time.Date(2007, time.April, 4, 19, 16, 40, 0, time.UTC)
+
+	if got := item.CreatedAt(); !got.Equal(want) {
+		t.Errorf("Item.CreatedAt() = %v, want %v", got, want)
+	}
+
+	if got := item.Age(want.Add(90 * time.Minute)); got != 90*time.Minute {
+		t.Errorf("Item.Age() = %v, want %v", got, 90*time.Minute)
+	}
+
+	user := &User{ID: "jl", Created: 1173923446}
+	if got := user.CreatedAt(); got.Unix() != 1173923446 {
+		t.Errorf("User.CreatedAt() = %v, want Unix time 1173923446", got)
+	}
+}
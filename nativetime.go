@@ -0,0 +1,79 @@
+package hnapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NativeItem is an Item whose creation time is exposed as a time.Time, for
+// applications that never want to deal with Unix seconds. It decodes from and
+// encodes to the same JSON as Item, so it can be fetched directly:
+//
+//	item, err := hnapi.Get[hnapi.NativeItem](ctx, client, "item/8863.json")
+type NativeItem struct {
+	Item
+
+	// Time is when the item was created. It shadows Item.Time, which still holds
+	// the raw Unix seconds.
+	Time time.Time
+}
+
+// Native converts the item to a NativeItem.
+func (i *Item) Native() *NativeItem {
+	return &NativeItem{Item: *i, Time: i.CreatedAt()}
+}
+
+// UnmarshalJSON decodes an item and converts its creation time.
+func (n *NativeItem) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &n.Item); err != nil {
+		return err
+	}
+	n.Time = n.Item.CreatedAt()
+	return nil
+}
+
+// MarshalJSON encodes the item in the API's format, with Time as Unix seconds.
+func (n NativeItem) MarshalJSON() ([]byte, error) {
+	item := n.Item
+	item.Time = unixSeconds(n.Time)
+	return json.Marshal(item)
+}
+
+// NativeUser is a User whose creation time is exposed as a time.Time. It decodes
+// from and encodes to the same JSON as User.
+type NativeUser struct {
+	User
+
+	// Created is when the user was created. It shadows User.Created, which still
+	// holds the raw Unix seconds.
+	Created time.Time
+}
+
+// Native converts the user to a NativeUser.
+func (u *User) Native() *NativeUser {
+	return &NativeUser{User: *u, Created: u.CreatedAt()}
+}
+
+// UnmarshalJSON decodes a user and converts their creation time.
+func (n *NativeUser) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &n.User); err != nil {
+		return err
+	}
+	n.Created = n.User.CreatedAt()
+	return nil
+}
+
+// MarshalJSON encodes the user in the API's format, with Created as Unix seconds.
+func (n NativeUser) MarshalJSON() ([]byte, error) {
+	user := n.User
+	user.Created = unixSeconds(n.Created)
+	return json.Marshal(user)
+}
+
+// unixSeconds converts t to Unix seconds, mapping the zero time to 0.
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
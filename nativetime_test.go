@@ -0,0 +1,78 @@
+package hnapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNativeItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 8863, "type": "story", "time": 1175714200, "title": "My YC app: Dropbox"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	item, err := Get[NativeItem](context.Background(), client, "item/8863.json")
+	if err != nil {
+		t.Fatalf("Get[NativeItem]() error = %v", err)
+	}
+
+	want := time.Unix(1175714200, 0)
+	if !item.Time.Equal(want) {
+		t.Errorf("Expected Time %v, got %v", want, item.Time)
+	}
+	if item.Item.Time != 1175714200 || item.Title != "My YC app: Dropbox" {
+		t.Errorf("Expected embedded item fields to be decoded, got %+v", item.Item)
+	}
+
+	// Encoding produces the API format again
+	item.Time = item.Time.Add(time.Minute)
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded Item
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.Time != 1175714260 || decoded.ID != 8863 {
+		t.Errorf("Expected round-tripped item with time 1175714260, got %+v", decoded)
+	}
+
+	if converted := (&Item{ID: 1, Time: 1175714200}).Native(); !converted.Time.Equal(want) {
+		t.Errorf("Item.Native().Time = %v, want %v", converted.Time, want)
+	}
+}
+
+func TestNativeUser(t *testing.T) {
+	var user NativeUser
+	if err := json.Unmarshal([]byte(`{"id": "jl", "created": 1173923446, "karma": 2937}`), &user); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if user.Created.Unix() != 1173923446 || user.ID != "jl" || user.Karma != 2937 {
+		t.Errorf("Unexpected user %+v", user)
+	}
+
+	data, err := json.Marshal(user)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded User
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.Created != 1173923446 {
+		t.Errorf("Expected created 1173923446, got %d", decoded.Created)
+	}
+
+	if converted := (&User{Created: 1173923446}).Native(); converted.Created.Unix() != 1173923446 {
+		t.Errorf("User.Native().Created = %v", converted.Created)
+	}
+}
@@ -0,0 +1,74 @@
+package hnapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrOffline is returned in offline mode for requests the offline store cannot serve.
+var ErrOffline = errors.New("not available offline")
+
+// OfflineStore serves previously mirrored data in offline mode. Lookups of data
+// the store does not hold must return an error wrapping ErrNotFound. The
+// hnapiarchive package provides a SQLite implementation.
+type OfflineStore interface {
+	// Item returns the item with the given ID.
+	Item(ctx context.Context, id int) (*Item, error)
+
+	// User returns the user with the given username.
+	User(ctx context.Context, username string) (*User, error)
+
+	// LatestList returns the most recent story IDs of the list and when they were
+	// recorded.
+	LatestList(ctx context.Context, list List) ([]int, time.Time, error)
+}
+
+// offlineRequest serves a request for endpoint from the offline store, decoding
+// the result into target as if it came from the API.
+func (c *Client) offlineRequest(ctx context.Context, endpoint string, target interface{}) error {
+	value, err := c.offlineValue(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode offline data: %w", err)
+	}
+	if err := c.unmarshalJSON(data, target); err != nil {
+		return fmt.Errorf("failed to unmarshal offline data: %w", err)
+	}
+	return nil
+}
+
+// offlineValue looks up the value of endpoint in the offline store.
+func (c *Client) offlineValue(ctx context.Context, endpoint string) (interface{}, error) {
+	store := c.Config.Offline
+	name := strings.TrimSuffix(endpoint, ".json")
+
+	switch {
+	case strings.HasPrefix(name, "item/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(name, "item/"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", endpoint, ErrOffline)
+		}
+		return store.Item(ctx, id)
+
+	case strings.HasPrefix(name, "user/"):
+		return store.User(ctx, strings.TrimPrefix(name, "user/"))
+	}
+
+	for list := ListTop; list <= ListJob; list++ {
+		if listEndpoint, _ := list.endpoint(); listEndpoint == endpoint {
+			ids, _, err := store.LatestList(ctx, list)
+			return ids, err
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", endpoint, ErrOffline)
+}
@@ -0,0 +1,93 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// memoryStore is an OfflineStore backed by maps.
+type memoryStore struct {
+	items map[int]*Item
+	users map[string]*User
+	lists map[List][]int
+}
+
+func (s *memoryStore) Item(_ context.Context, id int) (*Item, error) {
+	if item, ok := s.items[id]; ok {
+		return item, nil
+	}
+	return nil, fmt.Errorf("item %d: %w", id, ErrNotFound)
+}
+
+func (s *memoryStore) User(_ context.Context, username string) (*User, error) {
+	if user, ok := s.users[username]; ok {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user %s: %w", username, ErrNotFound)
+}
+
+func (s *memoryStore) LatestList(_ context.Context, list List) ([]int, time.Time, error) {
+	if ids, ok := s.lists[list]; ok {
+		return ids, time.Unix(0, 0), nil
+	}
+	return nil, time.Time{}, fmt.Errorf("list %s: %w", list, ErrNotFound)
+}
+
+func TestOffline(t *testing.T) {
+	store := &memoryStore{
+		items: map[int]*Item{
+			1: {ID: 1, Type: TypeStory, Title: "Archived", Kids: []int{2}},
+			2: {ID: 2, Type: TypeComment, Parent: 1},
+		},
+		users: map[string]*User{"pg": {ID: "pg", Karma: 1}},
+		lists: map[List][]int{ListTop: {1}},
+	}
+
+	// Any request reaching the network fails the test
+	client := NewClient(WithOffline(store), WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			t.Errorf("Unexpected network request to %s", req.URL)
+			return nil, errors.New("network disabled")
+		}
+	}))
+	ctx := context.Background()
+
+	item, err := client.GetItem(ctx, 1)
+	if err != nil || item.Title != "Archived" {
+		t.Errorf("GetItem() = %v, %v", item, err)
+	}
+
+	user, err := client.GetUser(ctx, "pg")
+	if err != nil || user.Karma != 1 {
+		t.Errorf("GetUser() = %v, %v", user, err)
+	}
+
+	ids, err := client.GetTopStories(ctx)
+	if err != nil || !reflect.DeepEqual(ids, []int{1}) {
+		t.Errorf("GetTopStories() = %v, %v", ids, err)
+	}
+
+	tree, err := client.GetCommentTree(ctx, 1, 0)
+	if err != nil || len(tree.Children) != 1 {
+		t.Errorf("GetCommentTree() = %v, %v", tree, err)
+	}
+
+	if _, err := client.GetItem(ctx, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an item that is not archived, got %v", err)
+	}
+	if _, err := client.GetNewStories(ctx); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a list that is not archived, got %v", err)
+	}
+	if _, err := client.GetMaxItem(ctx); !errors.Is(err, ErrOffline) {
+		t.Errorf("Expected ErrOffline for maxitem, got %v", err)
+	}
+
+	if !client.Capabilities().Store {
+		t.Errorf("Expected the Store capability in offline mode")
+	}
+}
@@ -0,0 +1,78 @@
+package hnapi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// CommentOrder is the order of the replies at each level of a comment tree.
+type CommentOrder int
+
+const (
+	// CommentOrderDisplay keeps replies in the ranked order Hacker News displays
+	// them. It is the default.
+	CommentOrderDisplay CommentOrder = iota
+
+	// CommentOrderNewest puts the most recent replies first.
+	CommentOrderNewest
+
+	// CommentOrderOldest puts the earliest replies first.
+	CommentOrderOldest
+
+	// CommentOrderLargestSubtree puts the replies with the most loaded replies
+	// below them first.
+	CommentOrderLargestSubtree
+)
+
+// String returns the name of the comment order.
+func (o CommentOrder) String() string {
+	switch o {
+	case CommentOrderDisplay:
+		return "display"
+	case CommentOrderNewest:
+		return "newest"
+	case CommentOrderOldest:
+		return "oldest"
+	case CommentOrderLargestSubtree:
+		return "largest-subtree"
+	default:
+		return fmt.Sprintf("CommentOrder(%d)", int(o))
+	}
+}
+
+// SortTree reorders the replies at every level of tree in place. Replies that
+// compare equal keep their display order. CommentOrderDisplay leaves the tree as it
+// is, so sorting a tree fetched in display order back into it requires fetching it
+// again.
+func SortTree(tree *CommentNode, order CommentOrder) {
+	if order == CommentOrderDisplay {
+		return
+	}
+	sortSubtree(tree, order)
+}
+
+// sortSubtree sorts the replies below node and returns the number of them.
+func sortSubtree(node *CommentNode, order CommentOrder) int {
+	sizes := make(map[*CommentNode]int, len(node.Children))
+	total := 0
+	for _, child := range node.Children {
+		sizes[child] = sortSubtree(child, order)
+		total += 1 + sizes[child]
+	}
+
+	slices.SortStableFunc(node.Children, func(a, b *CommentNode) int {
+		switch order {
+		case CommentOrderNewest:
+			return cmp.Compare(b.Item.Time, a.Item.Time)
+		case CommentOrderOldest:
+			return cmp.Compare(a.Item.Time, b.Item.Time)
+		case CommentOrderLargestSubtree:
+			return cmp.Compare(sizes[b], sizes[a])
+		default:
+			return 0
+		}
+	})
+
+	return total
+}
@@ -0,0 +1,105 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// orderTree returns a tree whose replies are in display order 2, 3, 4: reply 2 is
+// the oldest and has one reply, reply 3 the newest and has two.
+func orderTree() *CommentNode {
+	node := func(id int, time int64, children ...*CommentNode) *CommentNode {
+		return &CommentNode{Item: &Item{ID: id, Time: time}, Children: children}
+	}
+	return node(1, 100,
+		node(2, 200, node(5, 600)),
+		node(3, 400, node(6, 500), node(7, 700, node(8, 800))),
+		node(4, 300),
+	)
+}
+
+// childIDs returns the IDs of the replies of node.
+func childIDs(node *CommentNode) string {
+	var ids []int
+	for _, child := range node.Children {
+		ids = append(ids, child.Item.ID)
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestSortTree(t *testing.T) {
+	tests := []struct {
+		order      CommentOrder
+		wantTop    string
+		wantNested string
+	}{
+		{order: CommentOrderDisplay, wantTop: "[2 3 4]", wantNested: "[6 7]"},
+		{order: CommentOrderNewest, wantTop: "[3 4 2]", wantNested: "[7 6]"},
+		{order: CommentOrderOldest, wantTop: "[2 4 3]", wantNested: "[6 7]"},
+		{order: CommentOrderLargestSubtree, wantTop: "[3 2 4]", wantNested: "[7 6]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order.String(), func(t *testing.T) {
+			tree := orderTree()
+			SortTree(tree, tt.order)
+
+			if got := childIDs(tree); got != tt.wantTop {
+				t.Errorf("Expected replies %s, got %s", tt.wantTop, got)
+			}
+
+			// The same order applies below the top level
+			var nested *CommentNode
+			for _, child := range tree.Children {
+				if child.Item.ID == 3 {
+					nested = child
+				}
+			}
+			if got := childIDs(nested); got != tt.wantNested {
+				t.Errorf("Expected nested replies %s, got %s", tt.wantNested, got)
+			}
+		})
+	}
+}
+
+func TestGetCommentTreeWithCommentOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/item/1.json":
+			body = `{"id": 1, "type": "story", "kids": [2, 3, 4]}`
+		case "/item/2.json":
+			body = `{"id": 2, "type": "comment", "time": 200}`
+		case "/item/3.json":
+			body = `{"id": 3, "type": "comment", "time": 400}`
+		case "/item/4.json":
+			body = `{"id": 4, "type": "comment", "time": 300}`
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	tree, err := client.GetCommentTree(context.Background(), 1, 0, WithCommentOrder(CommentOrderNewest))
+	if err != nil {
+		t.Fatalf("GetCommentTree() error = %v", err)
+	}
+	if got := childIDs(tree); got != "[3 4 2]" {
+		t.Errorf("Expected newest first [3 4 2], got %s", got)
+	}
+
+	// The flattened thread follows the sorted tree
+	flat := FlattenTree(tree)
+	if flat[0].Item.ID != 3 {
+		t.Errorf("Expected the flattened thread to start with 3, got %d", flat[0].Item.ID)
+	}
+}
+
+func TestCommentOrderString(t *testing.T) {
+	if got := CommentOrder(9).String(); got != "CommentOrder(9)" {
+		t.Errorf("Expected CommentOrder(9), got %s", got)
+	}
+}
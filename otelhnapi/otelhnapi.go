@@ -0,0 +1,105 @@
+// Package otelhnapi provides OpenTelemetry tracing for the hnapi client.
+//
+// It is a separate module, github.com/yarlson/hnapi/otelhnapi, so the OpenTelemetry
+// dependency is only pulled into builds that use it:
+//
+//	client := hnapi.NewClient(otelhnapi.WithTracing(nil))
+//
+// Every GetItem, GetUser, list, batch, and poll operation creates a span carrying the
+// endpoint, item ID or username, attempt count, and status code. The per-item fetches
+// of batch operations are child spans of the batch span.
+package otelhnapi
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yarlson/hnapi"
+)
+
+// instrumentationName identifies this package as the source of the spans.
+const instrumentationName = "github.com/yarlson/hnapi/otelhnapi"
+
+// Attribute keys set on spans.
+const (
+	EndpointKey   = attribute.Key("hnapi.endpoint")
+	ItemIDKey     = attribute.Key("hnapi.item_id")
+	UsernameKey   = attribute.Key("hnapi.username")
+	BatchSizeKey  = attribute.Key("hnapi.batch_size")
+	AttemptKey    = attribute.Key("hnapi.attempt")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// WithTracing returns a client option that traces operations with spans from the
+// given tracer provider. A nil provider uses the global one.
+func WithTracing(provider trace.TracerProvider) hnapi.Option {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	t := &tracer{
+		tracer: provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(hnapi.Version)),
+	}
+
+	withTracer := hnapi.WithTracer(t)
+	withHooks := hnapi.WithHooks(hnapi.Hooks{OnRequestEnd: recordRequest})
+
+	return func(c *hnapi.Config) {
+		withTracer(c)
+		withHooks(c)
+	}
+}
+
+// tracer implements hnapi.Tracer with OpenTelemetry spans.
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// StartOperation starts a span for the operation.
+func (t *tracer) StartOperation(ctx context.Context, op hnapi.Operation) (context.Context, func(err error)) {
+	attrs := make([]attribute.KeyValue, 0, 4)
+	if op.Endpoint != "" {
+		attrs = append(attrs, EndpointKey.String(op.Endpoint))
+	}
+	if op.ItemID != 0 {
+		attrs = append(attrs, ItemIDKey.Int(op.ItemID))
+	}
+	if op.Username != "" {
+		attrs = append(attrs, UsernameKey.String(op.Username))
+	}
+	if op.BatchSize != 0 {
+		attrs = append(attrs, BatchSizeKey.Int(op.BatchSize))
+	}
+
+	ctx, span := t.tracer.Start(ctx, "hnapi."+op.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// recordRequest annotates the current operation span with the outcome of a request.
+// The attempt and status attributes reflect the latest attempt.
+func recordRequest(ctx context.Context, info hnapi.RequestEndInfo) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{AttemptKey.Int(info.Attempt)}
+	if info.StatusCode != 0 {
+		attrs = append(attrs, StatusCodeKey.Int(info.StatusCode))
+	}
+	span.SetAttributes(attrs...)
+}
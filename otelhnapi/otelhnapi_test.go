@@ -0,0 +1,85 @@
+package otelhnapi
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/yarlson/hnapi"
+	"github.com/yarlson/hnapi/hnapitest"
+)
+
+func TestWithTracing(t *testing.T) {
+	srv := hnapitest.NewServer()
+	defer srv.Close()
+
+	srv.AddItems(&hnapi.Item{ID: 1, Type: "story"}, &hnapi.Item{ID: 2, Type: "story"})
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := srv.Client(WithTracing(provider))
+	ctx := context.Background()
+
+	if _, err := client.GetItemsBatch(ctx, []int{1, 2}); err != nil {
+		t.Fatalf("GetItemsBatch() error = %v", err)
+	}
+	if _, err := client.GetUser(ctx, "missing"); err == nil {
+		t.Fatalf("Expected GetUser() to fail")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("Expected 4 spans, got %d", len(spans))
+	}
+
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range spans {
+		byName[span.Name()] = append(byName[span.Name()], span)
+	}
+
+	batch := byName["hnapi.GetItemsBatch"]
+	if len(batch) != 1 {
+		t.Fatalf("Expected 1 batch span, got %d", len(batch))
+	}
+	if !hasAttribute(batch[0].Attributes(), BatchSizeKey.Int(2)) {
+		t.Errorf("Expected batch size attribute, got %v", batch[0].Attributes())
+	}
+
+	items := byName["hnapi.GetItem"]
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 item spans, got %d", len(items))
+	}
+	for _, span := range items {
+		if span.Parent().SpanID() != batch[0].SpanContext().SpanID() {
+			t.Errorf("Expected item span to be a child of the batch span")
+		}
+		if !hasAttribute(span.Attributes(), AttemptKey.Int(1)) || !hasAttribute(span.Attributes(), StatusCodeKey.Int(200)) {
+			t.Errorf("Expected attempt and status attributes, got %v", span.Attributes())
+		}
+	}
+
+	users := byName["hnapi.GetUser"]
+	if len(users) != 1 {
+		t.Fatalf("Expected 1 user span, got %d", len(users))
+	}
+	if users[0].Status().Code != codes.Error {
+		t.Errorf("Expected error status on failed user span, got %v", users[0].Status())
+	}
+	if !hasAttribute(users[0].Attributes(), UsernameKey.String("missing")) {
+		t.Errorf("Expected username attribute, got %v", users[0].Attributes())
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
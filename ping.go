@@ -0,0 +1,37 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Ping checks that the API is reachable by requesting maxitem.json, one of its
+// smallest responses, without retries. It is meant for readiness probes of
+// services embedding the client. In offline mode there is nothing to reach and
+// Ping always succeeds.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.PingLatency(ctx)
+	return err
+}
+
+// PingLatency behaves like Ping and also returns the round-trip time of the
+// request, including the wait for a transport slot or rate limiter, if configured.
+func (c *Client) PingLatency(ctx context.Context) (time.Duration, error) {
+	if c.Config.Offline != nil {
+		return 0, nil
+	}
+
+	ctx, end := c.startOperation(ctx, Operation{Name: "Ping", Endpoint: "maxitem.json"})
+
+	start := time.Now()
+	var maxID int
+	err := c.makeRequest(ctx, "maxitem.json", &maxID, callOptions{noRetry: true, priority: PriorityInteractive})
+	latency := time.Since(start)
+	end(err)
+	if err != nil {
+		return latency, fmt.Errorf("failed to ping API: %w", err)
+	}
+
+	return latency, nil
+}
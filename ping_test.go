@@ -0,0 +1,65 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{name: "reachable", status: http.StatusOK, wantRequests: 1},
+		{name: "failing is not retried", status: http.StatusServiceUnavailable, wantErr: true, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if r.URL.Path != "/maxitem.json" {
+					t.Errorf("Expected a request to /maxitem.json, got %s", r.URL.Path)
+				}
+				time.Sleep(time.Millisecond)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`8863`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(3), WithBackoffInterval(time.Millisecond))
+
+			latency, err := client.PingLatency(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PingLatency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if latency < time.Millisecond {
+				t.Errorf("Expected the latency to cover the request, got %v", latency)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, got)
+			}
+
+			if err := client.Ping(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPingUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithBackoffInterval(time.Millisecond))
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Expected an error for an unreachable API")
+	}
+}
@@ -0,0 +1,72 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// PollOption is one option of a poll with its share of the votes.
+type PollOption struct {
+	// ID is the pollopt item ID.
+	ID int
+
+	// Text is the option text in HTML.
+	Text string
+
+	// Score is the number of votes for the option.
+	Score int
+
+	// Percent is the option's share of all votes, from 0 to 100.
+	Percent float64
+}
+
+// PollResults is a poll together with its options.
+type PollResults struct {
+	// Poll is the poll item.
+	Poll *Item
+
+	// Options are the poll's options in display order.
+	Options []PollOption
+
+	// TotalVotes is the sum of the options' scores.
+	TotalVotes int
+}
+
+// GetPollResults retrieves a poll and all of its options, fetched concurrently, and
+// aggregates their scores. It returns an error wrapping ErrWrongItemType if the item
+// is not a poll, and fails if any option cannot be loaded.
+func (c *Client) GetPollResults(ctx context.Context, id int) (*PollResults, error) {
+	poll, err := c.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !poll.IsPoll() {
+		return nil, fmt.Errorf("item %d is a %q, not a %q: %w", id, poll.Type, TypePoll, ErrWrongItemType)
+	}
+
+	results := &PollResults{
+		Poll:    poll,
+		Options: make([]PollOption, 0, len(poll.Parts)),
+	}
+
+	for _, result := range c.fetchItems(ctx, poll.Parts) {
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to get option %d of poll %d: %w", result.ID, id, result.Error)
+		}
+
+		results.Options = append(results.Options, PollOption{
+			ID:    result.Item.ID,
+			Text:  result.Item.Text,
+			Score: result.Item.Score,
+		})
+		results.TotalVotes += result.Item.Score
+	}
+
+	if results.TotalVotes > 0 {
+		for i := range results.Options {
+			results.Options[i].Percent = float64(results.Options[i].Score) / float64(results.TotalVotes) * 100
+		}
+	}
+
+	return results, nil
+}
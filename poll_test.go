@@ -0,0 +1,65 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPollResults(t *testing.T) {
+	responses := map[string]string{
+		"/item/126809.json": `{"id": 126809, "type": "poll", "title": "Poll", "parts": [126810, 126811, 126812]}`,
+		"/item/126810.json": `{"id": 126810, "type": "pollopt", "poll": 126809, "text": "Yes", "score": 30}`,
+		"/item/126811.json": `{"id": 126811, "type": "pollopt", "poll": 126809, "text": "No", "score": 10}`,
+		"/item/126812.json": `{"id": 126812, "type": "pollopt", "poll": 126809, "text": "Maybe", "score": 0}`,
+		"/item/1.json":      `{"id": 1, "type": "story"}`,
+		"/item/2.json":      `{"id": 2, "type": "poll", "parts": [3]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+	ctx := context.Background()
+
+	results, err := client.GetPollResults(ctx, 126809)
+	if err != nil {
+		t.Fatalf("GetPollResults() error = %v", err)
+	}
+
+	if results.Poll.ID != 126809 || results.TotalVotes != 40 {
+		t.Errorf("Expected poll 126809 with 40 votes, got %d with %d", results.Poll.ID, results.TotalVotes)
+	}
+
+	want := []PollOption{
+		{ID: 126810, Text: "Yes", Score: 30, Percent: 75},
+		{ID: 126811, Text: "No", Score: 10, Percent: 25},
+		{ID: 126812, Text: "Maybe", Score: 0, Percent: 0},
+	}
+	if len(results.Options) != len(want) {
+		t.Fatalf("Expected %d options, got %d", len(want), len(results.Options))
+	}
+	for i, option := range results.Options {
+		if option != want[i] {
+			t.Errorf("Option %d = %+v, want %+v", i, option, want[i])
+		}
+	}
+
+	if _, err := client.GetPollResults(ctx, 1); !errors.Is(err, ErrWrongItemType) {
+		t.Errorf("Expected ErrWrongItemType for a story, got %v", err)
+	}
+
+	if _, err := client.GetPollResults(ctx, 2); err == nil {
+		t.Errorf("Expected error when an option fails to load")
+	}
+}
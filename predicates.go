@@ -0,0 +1,70 @@
+package hnapi
+
+import "strings"
+
+// IsStory reports whether the item is a story, including Ask HN and Show HN posts.
+func (i *Item) IsStory() bool {
+	return i != nil && i.Type == TypeStory
+}
+
+// IsComment reports whether the item is a comment.
+func (i *Item) IsComment() bool {
+	return i != nil && i.Type == TypeComment
+}
+
+// IsJob reports whether the item is a job posting.
+func (i *Item) IsJob() bool {
+	return i != nil && i.Type == TypeJob
+}
+
+// IsPoll reports whether the item is a poll.
+func (i *Item) IsPoll() bool {
+	return i != nil && i.Type == TypePoll
+}
+
+// IsPollOpt reports whether the item is an option of a poll.
+func (i *Item) IsPollOpt() bool {
+	return i != nil && i.Type == TypePollOpt
+}
+
+// IsAsk reports whether the item is an Ask HN post: a story without a URL whose
+// title starts with "Ask HN".
+func (i *Item) IsAsk() bool {
+	return i.IsStory() && i.URL == "" && strings.HasPrefix(i.Title, "Ask HN")
+}
+
+// IsShow reports whether the item is a Show HN post, which may or may not link to
+// a URL.
+func (i *Item) IsShow() bool {
+	return i.IsStory() && strings.HasPrefix(i.Title, "Show HN")
+}
+
+// IsLive reports whether the item is neither deleted nor dead.
+func (i *Item) IsLive() bool {
+	return i != nil && !i.Deleted && !i.Dead
+}
+
+// HasURL reports whether the item links to an external URL. Text posts such as
+// Ask HN stories have a title and text but no URL.
+func (i *Item) HasURL() bool {
+	return i != nil && i.URL != ""
+}
+
+// HasText reports whether the item has a body text, as comments and text posts do.
+func (i *Item) HasText() bool {
+	return i != nil && i.Text != ""
+}
+
+// CommentCount returns the number of comments on the item. Stories and polls report
+// their total comment count, including nested replies; for comments, which carry no
+// total, it is the number of direct replies. Other items have no comments.
+func (i *Item) CommentCount() int {
+	switch {
+	case i.IsStory(), i.IsPoll():
+		return i.Descendants
+	case i.IsComment():
+		return len(i.Kids)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,101 @@
+package hnapi
+
+import "testing"
+
+func TestItemPredicates(t *testing.T) {
+	tests := []struct {
+		name         string
+		item         *Item
+		story        bool
+		comment      bool
+		job          bool
+		poll         bool
+		ask          bool
+		show         bool
+		hasURL       bool
+		commentCount int
+	}{
+		{
+			name:         "LinkStory",
+			item:         &Item{Type: TypeStory, Title: "A link", URL: "https://example.com", Descendants: 12, Kids: []int{1, 2}},
+			story:        true,
+			hasURL:       true,
+			commentCount: 12,
+		},
+		{
+			name:         "AskHN",
+			item:         &Item{Type: TypeStory, Title: "Ask HN: Why?", Text: "<p>Curious</p>", Descendants: 3},
+			story:        true,
+			ask:          true,
+			commentCount: 3,
+		},
+		{
+			name:   "ShowHN",
+			item:   &Item{Type: TypeStory, Title: "Show HN: My project", URL: "https://example.com"},
+			story:  true,
+			show:   true,
+			hasURL: true,
+		},
+		{
+			name:         "Comment",
+			item:         &Item{Type: TypeComment, Text: "Reply", Kids: []int{5, 6}},
+			comment:      true,
+			commentCount: 2,
+		},
+		{
+			name:   "Job",
+			item:   &Item{Type: TypeJob, Title: "Hiring", URL: "https://example.com/jobs", Descendants: 4},
+			job:    true,
+			hasURL: true,
+		},
+		{
+			name:         "Poll",
+			item:         &Item{Type: TypePoll, Title: "Ask HN: Poll", Descendants: 7},
+			poll:         true,
+			commentCount: 7,
+		},
+		{
+			name: "Nil",
+			item: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			checks := []struct {
+				name string
+				got  bool
+				want bool
+			}{
+				{"IsStory", item.IsStory(), tt.story},
+				{"IsComment", item.IsComment(), tt.comment},
+				{"IsJob", item.IsJob(), tt.job},
+				{"IsPoll", item.IsPoll(), tt.poll},
+				{"IsAsk", item.IsAsk(), tt.ask},
+				{"IsShow", item.IsShow(), tt.show},
+				{"HasURL", item.HasURL(), tt.hasURL},
+			}
+			for _, check := range checks {
+				if check.got != check.want {
+					t.Errorf("%s() = %v, want %v", check.name, check.got, check.want)
+				}
+			}
+			if got := item.CommentCount(); got != tt.commentCount {
+				t.Errorf("CommentCount() = %d, want %d", got, tt.commentCount)
+			}
+		})
+	}
+}
+
+func TestItemIsLive(t *testing.T) {
+	if !(&Item{}).IsLive() {
+		t.Errorf("Expected a regular item to be live")
+	}
+	if (&Item{Deleted: true}).IsLive() || (&Item{Dead: true}).IsLive() || (*Item)(nil).IsLive() {
+		t.Errorf("Expected deleted, dead, and nil items not to be live")
+	}
+	if !(&Item{Text: "x"}).HasText() || (&Item{}).HasText() {
+		t.Errorf("Unexpected HasText results")
+	}
+}
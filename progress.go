@@ -0,0 +1,65 @@
+package hnapi
+
+import "sync"
+
+// progressReporter counts completed fetches and passes them to a WithProgress
+// callback. Calls are serialized, so the callback need not be safe for concurrent
+// use. A nil reporter ignores all calls.
+type progressReporter struct {
+	mu    sync.Mutex
+	fn    func(done, total int)
+	done  int
+	total int
+}
+
+// newProgressReporter returns a reporter for fn with an initial total, or nil if
+// fn is nil.
+func newProgressReporter(fn func(done, total int), total int) *progressReporter {
+	if fn == nil {
+		return nil
+	}
+	return &progressReporter{fn: fn, total: total}
+}
+
+// step records n completed fetches and reports the new count.
+func (p *progressReporter) step(n int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done += n
+	if p.total < p.done {
+		p.total = p.done
+	}
+	p.fn(p.done, p.total)
+}
+
+// grow raises the total to at least total, for operations that discover more
+// work as they go. It does not report by itself.
+func (p *progressReporter) grow(total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.total < total {
+		p.total = total
+	}
+}
+
+// finish reports the final count as the total if an estimate overshot it.
+func (p *progressReporter) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.total != p.done {
+		p.total = p.done
+		p.fn(p.done, p.total)
+	}
+}
@@ -0,0 +1,101 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// progressRecorder records the calls of a WithProgress callback.
+type progressRecorder struct {
+	calls [][2]int
+}
+
+func (r *progressRecorder) record(done, total int) {
+	r.calls = append(r.calls, [2]int{done, total})
+}
+
+// check verifies that done increases by one per step up to want and never exceeds total.
+func (r *progressRecorder) check(t *testing.T, want int) {
+	t.Helper()
+
+	if len(r.calls) == 0 {
+		t.Fatalf("Expected progress calls")
+	}
+	for i, call := range r.calls {
+		if call[0] > call[1] {
+			t.Errorf("Call %d: done %d exceeds total %d", i, call[0], call[1])
+		}
+		if i > 0 && call[0] < r.calls[i-1][0] {
+			t.Errorf("Call %d: done decreased from %d to %d", i, r.calls[i-1][0], call[0])
+		}
+	}
+	if last := r.calls[len(r.calls)-1]; last != [2]int{want, want} {
+		t.Errorf("Expected the last call to be (%d, %d), got %v", want, want, last)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	items := map[string]string{
+		"1": `{"id": 1, "type": "story", "kids": [2, 3], "descendants": 5}`,
+		"2": `{"id": 2, "type": "comment", "kids": [4]}`,
+		"3": `{"id": 3, "type": "comment"}`,
+		"4": `{"id": 4, "type": "comment"}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+		if item, ok := items[id]; ok {
+			_, _ = w.Write([]byte(item))
+			return
+		}
+		_, _ = w.Write([]byte("null"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	ctx := context.Background()
+
+	t.Run("Batch", func(t *testing.T) {
+		var rec progressRecorder
+		if _, err := client.GetItemsBatch(ctx, []int{1, 2, 3}, WithProgress(rec.record)); err != nil {
+			t.Fatalf("GetItemsBatch() error = %v", err)
+		}
+		rec.check(t, 3)
+		if rec.calls[0][1] != 3 {
+			t.Errorf("Expected the total to be known up front, got %v", rec.calls[0])
+		}
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		// The story claims 5 descendants but only 3 exist; the final call corrects the total
+		var rec progressRecorder
+		if _, err := client.GetCommentTree(ctx, 1, 0, WithProgress(rec.record)); err != nil {
+			t.Fatalf("GetCommentTree() error = %v", err)
+		}
+		rec.check(t, 4)
+		if rec.calls[0] != [2]int{1, 6} {
+			t.Errorf("Expected the first call to use the comment count estimate, got %v", rec.calls[0])
+		}
+	})
+
+	t.Run("Walk", func(t *testing.T) {
+		var rec progressRecorder
+		err := client.WalkItems(ctx, 1, 6, func(*Item) error { return nil }, WithProgress(rec.record))
+		if err != nil {
+			t.Fatalf("WalkItems() error = %v", err)
+		}
+		rec.check(t, 6)
+	})
+
+	t.Run("Crawl", func(t *testing.T) {
+		var rec progressRecorder
+		err := client.Crawl(ctx, CrawlConfig{StartID: 2, EndID: 4}, func(*Item) error { return nil }, WithProgress(rec.record))
+		if err != nil {
+			t.Fatalf("Crawl() error = %v", err)
+		}
+		rec.check(t, 3)
+	})
+}
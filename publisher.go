@@ -0,0 +1,105 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Publisher sends payloads to a topic of a message bus. The hnapinats and
+// hnapikafka packages provide implementations for NATS and Kafka.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface.
+type PublisherFunc func(ctx context.Context, topic string, payload []byte) error
+
+// Publish calls f(ctx, topic, payload).
+func (f PublisherFunc) Publish(ctx context.Context, topic string, payload []byte) error {
+	return f(ctx, topic, payload)
+}
+
+// PublishTopics names the topics StartPublishing publishes to. Streams with an
+// empty topic are not started.
+type PublishTopics struct {
+	// Items receives changed items, hydrated as by StartItemUpdates.
+	Items string
+
+	// Profiles receives changed users, hydrated as by StartProfileUpdates.
+	Profiles string
+
+	// NewItems receives newly created items from StartFirehose.
+	NewItems string
+}
+
+// StartPublishing starts the update streams that have a topic and publishes every
+// item and user they deliver as JSON, encoded with the configured codec. It runs
+// in the background until the context is canceled or the client is closed.
+//
+// Failed publishes are logged and passed to the ErrorHandler; the bridge keeps
+// going with the next event.
+func (c *Client) StartPublishing(ctx context.Context, publisher Publisher, topics PublishTopics) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Streams started before a later one fails must not keep running
+	started := false
+	defer func() {
+		if !started {
+			cancel()
+		}
+	}()
+
+	if topics.Items != "" {
+		items, err := c.StartItemUpdates(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start publishing: %w", err)
+		}
+		c.goBackground(func() { _ = PublishStream(ctx, c, publisher, topics.Items, items) })
+	}
+
+	if topics.Profiles != "" {
+		users, err := c.StartProfileUpdates(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start publishing: %w", err)
+		}
+		c.goBackground(func() { _ = PublishStream(ctx, c, publisher, topics.Profiles, users) })
+	}
+
+	if topics.NewItems != "" {
+		items, err := c.StartFirehose(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start publishing: %w", err)
+		}
+		c.goBackground(func() { _ = PublishStream(ctx, c, publisher, topics.NewItems, items) })
+	}
+
+	started = true
+	return nil
+}
+
+// PublishStream publishes every value received from events to topic as JSON,
+// encoded with the client's codec, until the channel is closed or the context is
+// canceled. Failed publishes are logged and passed to the client's ErrorHandler
+// without stopping the stream.
+func PublishStream[T any](ctx context.Context, c *Client, publisher Publisher, topic string, events <-chan T) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			payload, err := c.marshalJSON(event)
+			if err == nil {
+				err = publisher.Publish(ctx, topic, payload)
+			}
+			if err != nil && ctx.Err() == nil {
+				err = fmt.Errorf("failed to publish to %s: %w", topic, err)
+				c.logger().Warn("failed to publish event", "topic", topic, "error", err)
+				c.handleError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingPublisher collects published payloads per topic.
+type recordingPublisher struct {
+	mu       sync.Mutex
+	payloads map[string][]string
+	signal   chan struct{}
+}
+
+func newRecordingPublisher() *recordingPublisher {
+	return &recordingPublisher{payloads: make(map[string][]string), signal: make(chan struct{}, 100)}
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, payload []byte) error {
+	p.mu.Lock()
+	p.payloads[topic] = append(p.payloads[topic], string(payload))
+	p.mu.Unlock()
+
+	p.signal <- struct{}{}
+	return nil
+}
+
+func TestPublishStream(t *testing.T) {
+	var handled []error
+	client := NewClient(WithErrorHandler(func(err error) { handled = append(handled, err) }))
+
+	var published []string
+	publisher := PublisherFunc(func(_ context.Context, topic string, payload []byte) error {
+		if strings.Contains(string(payload), `"id":2`) {
+			return errors.New("broker unavailable")
+		}
+		published = append(published, topic+" "+string(payload))
+		return nil
+	})
+
+	items := make(chan *Item, 3)
+	items <- &Item{ID: 1, Type: TypeStory}
+	items <- &Item{ID: 2, Type: TypeStory}
+	items <- &Item{ID: 3, Type: TypeStory}
+	close(items)
+
+	if err := PublishStream(context.Background(), client, publisher, "hn.items", items); err != nil {
+		t.Fatalf("PublishStream() error = %v", err)
+	}
+
+	want := []string{`hn.items {"id":1,"type":"story","time":0}`, `hn.items {"id":3,"type":"story","time":0}`}
+	if strings.Join(published, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Published %q, want %q", published, want)
+	}
+	if len(handled) != 1 {
+		t.Errorf("Expected the failed publish to reach the error handler, got %v", handled)
+	}
+}
+
+func TestStartPublishing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/updates.json":
+			_, _ = w.Write([]byte(`{"items": [1], "profiles": ["pg"]}`))
+		case "/item/1.json":
+			_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+		case "/user/pg.json":
+			_, _ = w.Write([]byte(`{"id": "pg", "karma": 1}`))
+		default:
+			_, _ = w.Write([]byte(`null`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Hour))
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	publisher := newRecordingPublisher()
+	if err := client.StartPublishing(ctx, publisher, PublishTopics{Items: "items", Profiles: "profiles"}); err != nil {
+		t.Fatalf("StartPublishing() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-publisher.signal:
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for published events")
+		}
+	}
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	if len(publisher.payloads["items"]) != 1 || !strings.Contains(publisher.payloads["items"][0], `"id":1`) {
+		t.Errorf("Unexpected item payloads %q", publisher.payloads["items"])
+	}
+	if len(publisher.payloads["profiles"]) != 1 || !strings.Contains(publisher.payloads["profiles"][0], `"id":"pg"`) {
+		t.Errorf("Unexpected profile payloads %q", publisher.payloads["profiles"])
+	}
+}
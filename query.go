@@ -0,0 +1,92 @@
+package hnapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// Query holds Firebase REST query parameters. The Hacker News API is served by
+// Firebase, which can order and limit a response on the server.
+type Query struct {
+	// OrderBy is the key the response is ordered by before limiting, such as
+	// "$key" or "$value". Firebase requires an order for limits, so it defaults to
+	// "$key", which keeps lists in their ranking order.
+	OrderBy string
+
+	// LimitToFirst keeps only the first n entries. Zero means no limit.
+	LimitToFirst int
+
+	// LimitToLast keeps only the last n entries. Zero means no limit.
+	LimitToLast int
+}
+
+// encode returns the query in URL form. Firebase expects orderBy as a JSON string.
+func (q *Query) encode() string {
+	values := url.Values{}
+
+	orderBy := q.OrderBy
+	if orderBy == "" && (q.LimitToFirst > 0 || q.LimitToLast > 0) {
+		orderBy = "$key"
+	}
+	if orderBy != "" {
+		values.Set("orderBy", strconv.Quote(orderBy))
+	}
+	if q.LimitToFirst > 0 {
+		values.Set("limitToFirst", strconv.Itoa(q.LimitToFirst))
+	}
+	if q.LimitToLast > 0 {
+		values.Set("limitToLast", strconv.Itoa(q.LimitToLast))
+	}
+
+	return values.Encode()
+}
+
+// limit applies the query's limits to ids.
+func (q *Query) limit(ids []int) []int {
+	if q.LimitToFirst > 0 && len(ids) > q.LimitToFirst {
+		ids = ids[:q.LimitToFirst]
+	}
+	if q.LimitToLast > 0 && len(ids) > q.LimitToLast {
+		ids = ids[len(ids)-q.LimitToLast:]
+	}
+	return ids
+}
+
+// storyList decodes a story list. Firebase returns a limited list as an array when
+// it starts at index 0, and otherwise as an object keyed by index.
+type storyList []int
+
+// UnmarshalJSON decodes an array of IDs, or an object of IDs keyed by their index.
+func (l *storyList) UnmarshalJSON(data []byte) error {
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err == nil {
+		*l = ids
+		return nil
+	}
+
+	var byIndex map[string]int
+	if err := json.Unmarshal(data, &byIndex); err != nil {
+		return fmt.Errorf("story list is neither an array nor an object: %w", err)
+	}
+
+	type entry struct{ index, id int }
+	entries := make([]entry, 0, len(byIndex))
+	for key, id := range byIndex {
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			return fmt.Errorf("invalid story list index %q", key)
+		}
+		entries = append(entries, entry{index, id})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].index < entries[j].index })
+
+	ids = make([]int, len(entries))
+	for i, e := range entries {
+		ids[i] = e.id
+	}
+	*l = ids
+	return nil
+}
@@ -0,0 +1,93 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueryEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{name: "empty", query: Query{}, want: ""},
+		{name: "limit defaults order", query: Query{LimitToFirst: 10}, want: `limitToFirst=10&orderBy=%22%24key%22`},
+		{name: "explicit order", query: Query{OrderBy: "$value", LimitToLast: 5}, want: `limitToLast=5&orderBy=%22%24value%22`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.encode(); got != tt.want {
+				t.Errorf("encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetListWithQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     Query
+		response  string
+		wantQuery map[string]string
+		want      []int
+	}{
+		{
+			name:      "limit to first",
+			query:     Query{LimitToFirst: 2},
+			response:  `[10, 20]`,
+			wantQuery: map[string]string{"orderBy": `"$key"`, "limitToFirst": "2"},
+			want:      []int{10, 20},
+		},
+		{
+			name:      "limit to last returns an object",
+			query:     Query{LimitToLast: 2},
+			response:  `{"4": 50, "3": 40}`,
+			wantQuery: map[string]string{"orderBy": `"$key"`, "limitToLast": "2"},
+			want:      []int{40, 50},
+		},
+		{
+			name:      "limits applied when the server ignores them",
+			query:     Query{LimitToFirst: 2},
+			response:  `[10, 20, 30, 40]`,
+			wantQuery: map[string]string{"limitToFirst": "2"},
+			want:      []int{10, 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, want := range tt.wantQuery {
+					if got := r.URL.Query().Get(key); got != want {
+						t.Errorf("Expected %s=%s, got %q", key, want, got)
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL+"/"), WithListCache(time.Minute))
+			defer client.Close()
+
+			ids, err := client.GetList(context.Background(), ListTop, WithQuery(tt.query))
+			if err != nil {
+				t.Fatalf("GetList() error = %v", err)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("GetList() = %v, want %v", ids, tt.want)
+			}
+
+			// Filtered lists must not be served to unfiltered calls
+			if stats := client.Stats(); stats.CacheHits+stats.CacheMisses != 0 {
+				t.Errorf("Expected a query to bypass the list cache, got %+v", stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// RankEventType describes how a story's position on the front page changed.
+type RankEventType int
+
+const (
+	// RankEntered means the story entered the top N.
+	RankEntered RankEventType = iota
+
+	// RankLeft means the story dropped out of the top N.
+	RankLeft
+
+	// RankChanged means the story moved to a different position within the top N.
+	RankChanged
+)
+
+// String returns the name of the rank event type.
+func (t RankEventType) String() string {
+	switch t {
+	case RankEntered:
+		return "entered"
+	case RankLeft:
+		return "left"
+	case RankChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("RankEventType(%d)", int(t))
+	}
+}
+
+// RankEvent reports a change in a story's position among the top stories.
+// Ranks are 1-based; a rank of 0 means the story is outside the top N.
+type RankEvent struct {
+	// Type is the kind of change.
+	Type RankEventType
+
+	// ID is the story ID.
+	ID int
+
+	// OldRank is the previous position, or 0 if the story just entered.
+	OldRank int
+
+	// NewRank is the current position, or 0 if the story just left.
+	NewRank int
+}
+
+// StartRankTracking snapshots the top stories every PollInterval and returns a
+// channel of events for stories that enter the top n, leave it, or change rank.
+// The first snapshot is reported as RankEntered events for every story in it. A
+// story listed more than once is ranked at its first position, as in Diff.
+//
+// Polling errors are logged and passed to the ErrorHandler. The returned channel is
+// closed when the context is canceled.
+func (c *Client) StartRankTracking(ctx context.Context, n int) (<-chan RankEvent, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid rank tracking size %d: must be positive", n)
+	}
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsCh := make(chan RankEvent, n)
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(eventsCh)
+
+		var previous []int
+		c.pollEvery(ctx, c.Config.PollInterval, func() {
+			ids, err := c.GetTopStories(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					c.logger().Warn("failed to poll top stories", "error", err)
+					c.handleError(err)
+				}
+				return
+			}
+
+			if len(ids) > n {
+				ids = ids[:n]
+			}
+
+			for _, event := range rankEvents(previous, ids) {
+				select {
+				case eventsCh <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			previous = ids
+		})
+	})
+
+	return eventsCh, nil
+}
+
+// rankEvents compares two ranked snapshots and returns the events that turn previous
+// into current. Events for stories in current come first, in rank order, followed by
+// events for stories that left. Duplicate IDs count at their first position.
+func rankEvents(previous, current []int) []RankEvent {
+	diff := Diff(previous, current)
+
+	var events []RankEvent
+	for _, change := range diff.Added {
+		events = append(events, RankEvent{Type: RankEntered, ID: change.ID, NewRank: change.NewIndex + 1})
+	}
+	for _, change := range diff.Moved {
+		events = append(events, RankEvent{Type: RankChanged, ID: change.ID, OldRank: change.OldIndex + 1, NewRank: change.NewIndex + 1})
+	}
+	slices.SortFunc(events, func(a, b RankEvent) int { return a.NewRank - b.NewRank })
+
+	for _, change := range diff.Removed {
+		events = append(events, RankEvent{Type: RankLeft, ID: change.ID, OldRank: change.OldIndex + 1})
+	}
+
+	return events
+}
@@ -0,0 +1,101 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRankEvents(t *testing.T) {
+	previous := []int{1, 2, 3}
+	current := []int{2, 1, 4}
+
+	want := []RankEvent{
+		{Type: RankChanged, ID: 2, OldRank: 2, NewRank: 1},
+		{Type: RankChanged, ID: 1, OldRank: 1, NewRank: 2},
+		{Type: RankEntered, ID: 4, NewRank: 3},
+		{Type: RankLeft, ID: 3, OldRank: 3},
+	}
+
+	if got := rankEvents(previous, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("rankEvents() = %+v, want %+v", got, want)
+	}
+
+	if got := rankEvents(current, current); len(got) != 0 {
+		t.Errorf("Expected no events for identical snapshots, got %+v", got)
+	}
+
+	// A duplicated story is ranked at its first position
+	want = []RankEvent{
+		{Type: RankChanged, ID: 1, OldRank: 2, NewRank: 1},
+		{Type: RankChanged, ID: 5, OldRank: 1, NewRank: 2},
+	}
+	if got := rankEvents([]int{5, 1, 5}, []int{1, 5}); !reflect.DeepEqual(got, want) {
+		t.Errorf("rankEvents() with duplicates = %+v, want %+v", got, want)
+	}
+}
+
+func TestStartRankTracking(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		resp := "[10, 20, 30, 40]"
+		if atomic.AddInt32(&calls, 1) > 1 {
+			resp = "[20, 10, 40, 30]"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(20*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	eventsCh, err := client.StartRankTracking(ctx, 3)
+	if err != nil {
+		t.Fatalf("StartRankTracking() error = %v", err)
+	}
+
+	want := []RankEvent{
+		{Type: RankEntered, ID: 10, NewRank: 1},
+		{Type: RankEntered, ID: 20, NewRank: 2},
+		{Type: RankEntered, ID: 30, NewRank: 3},
+		{Type: RankChanged, ID: 20, OldRank: 2, NewRank: 1},
+		{Type: RankChanged, ID: 10, OldRank: 1, NewRank: 2},
+		{Type: RankEntered, ID: 40, NewRank: 3},
+		{Type: RankLeft, ID: 30, OldRank: 3},
+	}
+
+	for _, wantEvent := range want {
+		select {
+		case event := <-eventsCh:
+			if event != wantEvent {
+				t.Errorf("Expected event %+v, got %+v", wantEvent, event)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for event %+v", wantEvent)
+		}
+	}
+
+	cancel()
+	for range eventsCh {
+	}
+
+	if _, err := client.StartRankTracking(context.Background(), 0); err == nil {
+		t.Errorf("Expected error for non-positive size")
+	}
+}
@@ -0,0 +1,174 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// backfillBatchSize is the number of item IDs per Updates value when
+// StartResumableUpdates catches up on items created while it was not running.
+const backfillBatchSize = 500
+
+// StartResumableUpdates behaves like StartUpdates, but remembers the highest item ID
+// it has delivered in store under key. When started again with the same store and
+// key, for example after a deploy, it first delivers the IDs of every item created
+// since then, from the checkpoint up to the current maxitem, in batches of
+// Updates.Items, and only then resumes polling. The first run with an empty
+// checkpoint starts at the current maxitem.
+//
+// Delivery is at least once: IDs delivered just before a restart may be delivered
+// again. Changes to older items made while no poller was running are not recovered,
+// since the API does not keep a history of updates. Backfilled batches are never
+// dropped by the overflow policy, and the checkpoint does not advance past items of
+// polled updates the policy discarded. Failures to save the checkpoint are logged and
+// passed to the ErrorHandler; polling continues.
+func (c *Client) StartResumableUpdates(ctx context.Context, store CheckpointStore, key string) (<-chan Updates, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := store.LoadCheckpoint(ctx, key)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to load updates checkpoint: %w", err)
+	}
+
+	r := &resumableUpdates{client: c, store: store, key: key, checkpoint: checkpoint}
+	if checkpoint == 0 {
+		maxID, err := c.GetMaxItem(ctx)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to start resumable updates: %w", err)
+		}
+		r.save(ctx, maxID)
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	c.goBackground(func() {
+		defer cancel()
+		defer close(updatesCh)
+		r.run(ctx, updatesCh)
+	})
+
+	return updatesCh, nil
+}
+
+// resumableUpdates is the state of a StartResumableUpdates stream.
+type resumableUpdates struct {
+	client     *Client
+	store      CheckpointStore
+	key        string
+	checkpoint int
+
+	// lost is the lowest item ID discarded by the overflow policy, or 0
+	lost int
+}
+
+// run backfills missed items and then forwards polled updates until the context
+// is canceled.
+func (r *resumableUpdates) run(ctx context.Context, updatesCh chan Updates) {
+	c := r.client
+
+	if !r.backfill(ctx, updatesCh) {
+		return
+	}
+
+	source := c.newUpdatesChannel()
+	c.goBackground(func() { c.runUpdates(ctx, source, nil) })
+
+	for updates := range source {
+		sent, err := offerUpdates(ctx, c.Config.UpdatesOverflowPolicy, updatesCh, updates, func(dropped Updates) {
+			r.drop(ctx, dropped)
+		})
+		if err != nil {
+			return
+		}
+		if sent && len(updates.Items) > 0 {
+			r.save(ctx, slices.Max(updates.Items))
+		}
+	}
+}
+
+// drop keeps the checkpoint below the items of an update the overflow policy
+// discarded, so they are backfilled by the next start. An evicted update may
+// already have moved the checkpoint, which is then moved back.
+func (r *resumableUpdates) drop(ctx context.Context, updates Updates) {
+	r.client.metrics.updatesDropped.Add(1)
+	if len(updates.Items) == 0 {
+		return
+	}
+
+	lowest := slices.Min(updates.Items)
+	if r.lost == 0 || lowest < r.lost {
+		r.lost = lowest
+	}
+	if r.checkpoint >= lowest {
+		r.write(ctx, lowest-1)
+	}
+}
+
+// backfill delivers the IDs between the checkpoint and maxitem, checking maxitem
+// again until no new items appeared while catching up. It reports whether the
+// stream should continue.
+func (r *resumableUpdates) backfill(ctx context.Context, updatesCh chan Updates) bool {
+	c := r.client
+
+	for {
+		maxID, err := c.GetMaxItem(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			// Missed items are picked up by the next start instead
+			c.logger().Warn("failed to backfill updates", "error", err)
+			c.handleError(fmt.Errorf("failed to backfill updates: %w", err))
+			return true
+		}
+		if maxID <= r.checkpoint {
+			return true
+		}
+
+		for from := r.checkpoint + 1; from <= maxID; from += backfillBatchSize {
+			to := min(from+backfillBatchSize-1, maxID)
+
+			ids := make([]int, 0, to-from+1)
+			for id := from; id <= to; id++ {
+				ids = append(ids, id)
+			}
+
+			select {
+			case updatesCh <- Updates{Items: ids, Profiles: []string{}}:
+				c.metrics.updatesEmitted.Add(1)
+			case <-ctx.Done():
+				return false
+			}
+			r.save(ctx, to)
+		}
+	}
+}
+
+// save records id as the checkpoint if it is higher than the current one. It never
+// moves past an item lost to the overflow policy.
+func (r *resumableUpdates) save(ctx context.Context, id int) {
+	if r.lost > 0 {
+		id = min(id, r.lost-1)
+	}
+	if id <= r.checkpoint {
+		return
+	}
+	r.write(ctx, id)
+}
+
+// write saves id as the checkpoint.
+func (r *resumableUpdates) write(ctx context.Context, id int) {
+	// The last checkpoint must land even while shutting down
+	if err := r.store.SaveCheckpoint(context.WithoutCancel(ctx), r.key, id); err != nil {
+		c := r.client
+		c.logger().Warn("failed to save updates checkpoint", "key", r.key, "error", err)
+		c.handleError(fmt.Errorf("failed to save updates checkpoint: %w", err))
+		return
+	}
+	r.checkpoint = id
+}
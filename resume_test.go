@@ -0,0 +1,205 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newResumeServer serves maxitem and a single updates response with newItem.
+func newResumeServer(maxItem *atomic.Int32, newItem int) *httptest.Server {
+	var polled atomic.Bool
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/maxitem.json":
+			_, _ = w.Write([]byte(strconv.Itoa(int(maxItem.Load()))))
+		case "/updates.json":
+			if polled.CompareAndSwap(false, true) {
+				_, _ = w.Write([]byte(`{"items": [` + strconv.Itoa(newItem) + `], "profiles": []}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items": [], "profiles": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestStartResumableUpdates(t *testing.T) {
+	var maxItem atomic.Int32
+	maxItem.Store(12)
+	server := newResumeServer(&maxItem, 13)
+	defer server.Close()
+
+	store := NewMemoryCheckpointStore()
+	_ = store.SaveCheckpoint(context.Background(), "updates", 10)
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Hour))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StartResumableUpdates(ctx, store, "updates")
+	if err != nil {
+		t.Fatalf("StartResumableUpdates() error = %v", err)
+	}
+
+	// Items created since the checkpoint are backfilled before polling resumes
+	if got := <-updates; !reflect.DeepEqual(got.Items, []int{11, 12}) {
+		t.Errorf("Expected backfilled items [11 12], got %v", got.Items)
+	}
+	if got := <-updates; !reflect.DeepEqual(got.Items, []int{13}) {
+		t.Errorf("Expected polled item [13], got %v", got.Items)
+	}
+
+	// The checkpoint follows the delivered items
+	deadline := time.Now().Add(time.Second)
+	for {
+		checkpoint, _ := store.LoadCheckpoint(context.Background(), "updates")
+		if checkpoint == 13 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected checkpoint 13, got %d", checkpoint)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStartResumableUpdatesFirstRun(t *testing.T) {
+	var maxItem atomic.Int32
+	maxItem.Store(500)
+	server := newResumeServer(&maxItem, 501)
+	defer server.Close()
+
+	store := NewMemoryCheckpointStore()
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Hour))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StartResumableUpdates(ctx, store, "updates")
+	if err != nil {
+		t.Fatalf("StartResumableUpdates() error = %v", err)
+	}
+
+	// Without a checkpoint there is nothing to backfill
+	if checkpoint, _ := store.LoadCheckpoint(ctx, "updates"); checkpoint != 500 {
+		t.Errorf("Expected the first run to save maxitem 500, got %d", checkpoint)
+	}
+	if got := <-updates; !reflect.DeepEqual(got.Items, []int{501}) {
+		t.Errorf("Expected polled item [501], got %v", got.Items)
+	}
+}
+
+func TestStartResumableUpdatesBatches(t *testing.T) {
+	var maxItem atomic.Int32
+	maxItem.Store(int32(backfillBatchSize + 20))
+	server := newResumeServer(&maxItem, 0)
+	defer server.Close()
+
+	store := NewMemoryCheckpointStore()
+	_ = store.SaveCheckpoint(context.Background(), "updates", 10)
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(time.Hour))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := client.StartResumableUpdates(ctx, store, "updates")
+	if err != nil {
+		t.Fatalf("StartResumableUpdates() error = %v", err)
+	}
+
+	first := <-updates
+	if len(first.Items) != backfillBatchSize || first.Items[0] != 11 {
+		t.Errorf("Expected a full first batch starting at 11, got %d items", len(first.Items))
+	}
+
+	// The checkpoint advances with every batch
+	second := <-updates
+	if len(second.Items) != 10 || second.Items[0] != backfillBatchSize+11 {
+		t.Errorf("Expected the second batch to hold the last 10 items, got %v", second.Items)
+	}
+	if checkpoint, _ := store.LoadCheckpoint(ctx, "updates"); checkpoint < 10+backfillBatchSize {
+		t.Errorf("Expected at least checkpoint %d after the first batch, got %d", 10+backfillBatchSize, checkpoint)
+	}
+}
+
+func TestStartResumableUpdatesOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy OverflowPolicy
+		want   int
+	}{
+		// The backfilled batch stays buffered, so only polled items are lost
+		{name: "drop newest", policy: OverflowDropNewest, want: 12},
+		// The backfilled batch is evicted, so the checkpoint moves back before it
+		{name: "drop oldest", policy: OverflowDropOldest, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/maxitem.json":
+					_, _ = w.Write([]byte("12"))
+				case "/updates.json":
+					// Every poll reports a new item after the checkpoint
+					n := polls.Add(1)
+					_, _ = w.Write([]byte(`{"items": [` + strconv.Itoa(12+int(n)) + `], "profiles": []}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			store := NewMemoryCheckpointStore()
+			_ = store.SaveCheckpoint(context.Background(), "updates", 10)
+
+			client := NewClient(
+				WithBaseURL(server.URL+"/"),
+				WithPollInterval(5*time.Millisecond),
+				WithUpdatesBufferSize(1),
+				WithUpdatesOverflowPolicy(tt.policy),
+			)
+			defer client.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			updates, err := client.StartResumableUpdates(ctx, store, "updates")
+			if err != nil {
+				t.Fatalf("StartResumableUpdates() error = %v", err)
+			}
+
+			// Fall behind until the policy has discarded updates
+			deadline := time.Now().Add(2 * time.Second)
+			for client.Stats().UpdatesDropped < 2 {
+				if time.Now().After(deadline) {
+					t.Fatal("Timed out waiting for dropped updates")
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			// Updates delivered after the loss must not move the checkpoint past it
+			for range 3 {
+				<-updates
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			if checkpoint, _ := store.LoadCheckpoint(context.Background(), "updates"); checkpoint != tt.want {
+				t.Errorf("Expected checkpoint %d, got %d", tt.want, checkpoint)
+			}
+		})
+	}
+}
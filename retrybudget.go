@@ -0,0 +1,131 @@
+package hnapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RetryBudget caps the retries of a client relative to its requests, so a failing
+// upstream is not hit with MaxRetries times the normal load. Once the budget is
+// spent, failed requests return a *RetryBudgetError instead of being retried.
+type RetryBudget struct {
+	// Ratio is the largest number of retries per request within the window, such
+	// as 0.2 to allow retries of at most 20% of requests.
+	Ratio float64
+
+	// MinRetries are allowed within the window regardless of Ratio, so requests
+	// are still retried when traffic is low.
+	MinRetries int
+
+	// Window is the period requests and retries are counted over. Zero disables
+	// the budget.
+	Window time.Duration
+}
+
+// RetryBudgetError is returned when a request failed with a retryable error but
+// was not retried because the client's retry budget is exhausted.
+type RetryBudgetError struct {
+	// Endpoint is the endpoint of the failed request.
+	Endpoint string
+
+	// Attempts is the number of attempts made before giving up.
+	Attempts int
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryBudgetError) Error() string {
+	return fmt.Sprintf("retry budget exhausted after %d attempts of %s: %v", e.Attempts, e.Endpoint, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryBudgetError) Unwrap() error {
+	return e.Err
+}
+
+// budgetBuckets is the number of buckets the budget window is divided into. Counts
+// expire one bucket at a time, so the window slides in steps of Window/budgetBuckets.
+const budgetBuckets = 10
+
+// retryBudget counts requests and retries over a sliding window.
+type retryBudget struct {
+	RetryBudget
+
+	mu      sync.Mutex
+	buckets [budgetBuckets]budgetBucket
+}
+
+// budgetBucket holds the counts of one slice of the window.
+type budgetBucket struct {
+	slot     int64
+	requests int
+	retries  int
+}
+
+// newRetryBudget creates a retry budget, or returns nil if the settings disable it.
+func newRetryBudget(settings RetryBudget) *retryBudget {
+	if settings.Window <= 0 {
+		return nil
+	}
+	return &retryBudget{RetryBudget: settings}
+}
+
+// bucket returns the bucket for now, clearing it if it holds counts from an
+// earlier pass over the window. b.mu must be held.
+func (b *retryBudget) bucket(now time.Time) (*budgetBucket, int64) {
+	width := int64(b.Window) / budgetBuckets
+	if width <= 0 {
+		width = 1
+	}
+
+	slot := now.UnixNano() / width
+	bucket := &b.buckets[slot%budgetBuckets]
+	if bucket.slot != slot {
+		*bucket = budgetBucket{slot: slot}
+	}
+	return bucket, slot
+}
+
+// request records the first attempt of a request.
+func (b *retryBudget) request(now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket, _ := b.bucket(now)
+	bucket.requests++
+}
+
+// withdraw records a retry if the budget allows one and reports whether it did.
+func (b *retryBudget) withdraw(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current, slot := b.bucket(now)
+
+	var requests, retries int
+	for i := range b.buckets {
+		if bucket := &b.buckets[i]; bucket.slot > slot-budgetBuckets {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+
+	allowed := max(float64(b.MinRetries), b.Ratio*float64(requests))
+	if float64(retries) >= allowed {
+		return false
+	}
+
+	current.retries++
+	return true
+}
@@ -0,0 +1,99 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryBudgetWithdraw(t *testing.T) {
+	budget := newRetryBudget(RetryBudget{Ratio: 0.2, MinRetries: 1, Window: 10 * time.Second})
+	now := time.Unix(1000, 0)
+
+	// The minimum allows a retry before any requests are counted
+	if !budget.withdraw(now) {
+		t.Fatal("Expected the minimum to allow a retry")
+	}
+	if budget.withdraw(now) {
+		t.Fatal("Expected the budget to be exhausted")
+	}
+
+	// 20 requests earn 4 retries, one of which is already spent
+	for i := 0; i < 20; i++ {
+		budget.request(now)
+	}
+	for i := 0; i < 3; i++ {
+		if !budget.withdraw(now) {
+			t.Fatalf("Expected retry %d to be allowed", i+2)
+		}
+	}
+	if budget.withdraw(now) {
+		t.Fatal("Expected the ratio to cap retries")
+	}
+
+	// Counts expire once they leave the window
+	later := now.Add(11 * time.Second)
+	if !budget.withdraw(later) {
+		t.Fatal("Expected the budget to recover after the window")
+	}
+	if budget.withdraw(later) {
+		t.Fatal("Expected old requests to no longer earn retries")
+	}
+}
+
+func TestRetryBudgetDisabled(t *testing.T) {
+	budget := newRetryBudget(RetryBudget{Ratio: 0.1})
+	if budget != nil {
+		t.Fatal("Expected a zero window to disable the budget")
+	}
+	budget.request(time.Now())
+	if !budget.withdraw(time.Now()) {
+		t.Error("Expected a disabled budget to allow every retry")
+	}
+}
+
+func TestRetryBudgetError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithMaxRetries(3),
+		WithBackoffInterval(time.Millisecond),
+		WithRetryBudget(RetryBudget{MinRetries: 1, Window: time.Minute}),
+	)
+
+	tests := []struct {
+		wantAttempts int
+		wantRequests int32
+	}{
+		{wantAttempts: 2, wantRequests: 2},
+		{wantAttempts: 1, wantRequests: 3},
+	}
+
+	for _, tt := range tests {
+		_, err := client.GetItem(context.Background(), 1)
+
+		var budgetErr *RetryBudgetError
+		if !errors.As(err, &budgetErr) {
+			t.Fatalf("Expected a *RetryBudgetError, got %v", err)
+		}
+		if budgetErr.Attempts != tt.wantAttempts {
+			t.Errorf("Expected %d attempts, got %d", tt.wantAttempts, budgetErr.Attempts)
+		}
+		if budgetErr.Endpoint != "item/1.json" {
+			t.Errorf("Expected endpoint item/1.json, got %q", budgetErr.Endpoint)
+		}
+		if got := requests.Load(); got != tt.wantRequests {
+			t.Errorf("Expected %d requests, got %d", tt.wantRequests, got)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Priority orders requests competing for the transport slots of a client with
+// MaxInFlight set. Requests of a higher priority are started first; requests of the
+// same priority are started in the order they arrive.
+type Priority int
+
+const (
+	// PriorityBulk is for background work such as crawls and large batches. It is
+	// the default for GetItemsBatch, GetUsersBatch, GetCommentTree replies,
+	// WalkItems, and the item iterators.
+	PriorityBulk Priority = iota - 1
+
+	// PriorityNormal is the default for single calls such as GetItem.
+	PriorityNormal
+
+	// PriorityInteractive is for requests a user is waiting on.
+	PriorityInteractive
+)
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityBulk:
+		return "bulk"
+	case PriorityNormal:
+		return "normal"
+	case PriorityInteractive:
+		return "interactive"
+	default:
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+}
+
+// numPriorities is the number of priority levels the scheduler queues separately.
+const numPriorities = int(PriorityInteractive-PriorityBulk) + 1
+
+// level returns the queue index of p, clamping unknown priorities to the nearest level.
+func (p Priority) level() int {
+	switch {
+	case p < PriorityBulk:
+		return 0
+	case p > PriorityInteractive:
+		return numPriorities - 1
+	default:
+		return int(p - PriorityBulk)
+	}
+}
+
+// scheduler hands out a fixed number of transport slots, serving waiting requests
+// by priority and then in arrival order. Requests are not interrupted once started,
+// so a high priority request waits at most for the next slot to free up.
+type scheduler struct {
+	mu      sync.Mutex
+	free    int
+	waiting [numPriorities][]*slotWaiter
+}
+
+// slotWaiter is a request waiting for a slot.
+type slotWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// newScheduler creates a scheduler with the given number of slots, or returns nil
+// if slots disables scheduling.
+func newScheduler(slots int) *scheduler {
+	if slots <= 0 {
+		return nil
+	}
+	return &scheduler{free: slots}
+}
+
+// acquire waits for a slot for a request of priority p. It fails if the context is
+// canceled first. Every successful acquire must be followed by release.
+func (s *scheduler) acquire(ctx context.Context, p Priority) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	if s.free > 0 {
+		s.free--
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := &slotWaiter{ready: make(chan struct{})}
+	level := p.level()
+	s.waiting[level] = append(s.waiting[level], w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The slot may have been handed over just as the context was canceled
+	if w.granted {
+		s.releaseLocked()
+		return ctx.Err()
+	}
+
+	queue := s.waiting[level]
+	for i, queued := range queue {
+		if queued == w {
+			s.waiting[level] = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	return ctx.Err()
+}
+
+// release returns a slot, handing it to the highest priority waiting request.
+func (s *scheduler) release() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLocked()
+}
+
+// releaseLocked implements release; s.mu must be held.
+func (s *scheduler) releaseLocked() {
+	for level := numPriorities - 1; level >= 0; level-- {
+		queue := s.waiting[level]
+		if len(queue) == 0 {
+			continue
+		}
+
+		w := queue[0]
+		queue[0] = nil
+		s.waiting[level] = queue[1:]
+
+		w.granted = true
+		close(w.ready)
+		return
+	}
+
+	s.free++
+}
+
+// bulkOptions makes PriorityBulk the default priority of opts; an explicit
+// WithPriority in opts still takes precedence.
+func bulkOptions(opts []CallOption) []CallOption {
+	return append([]CallOption{WithPriority(PriorityBulk)}, opts...)
+}
@@ -0,0 +1,167 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerPriorityOrder(t *testing.T) {
+	s := newScheduler(1)
+	ctx := context.Background()
+
+	if err := s.acquire(ctx, PriorityNormal); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+
+	// Queue waiters one at a time so their arrival order is deterministic
+	for i, p := range []Priority{PriorityBulk, PriorityNormal, PriorityBulk, PriorityInteractive} {
+		wg.Add(1)
+		go func(p Priority) {
+			defer wg.Done()
+			if err := s.acquire(ctx, p); err != nil {
+				t.Errorf("acquire() error = %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			s.release()
+		}(p)
+		waitForWaiters(t, s, i+1)
+	}
+
+	s.release()
+	wg.Wait()
+
+	want := []Priority{PriorityInteractive, PriorityNormal, PriorityBulk, PriorityBulk}
+	if len(order) != len(want) {
+		t.Fatalf("Expected %d acquisitions, got %v", len(want), order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestSchedulerCanceledWaiter(t *testing.T) {
+	s := newScheduler(1)
+	if err := s.acquire(context.Background(), PriorityNormal); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.acquire(ctx, PriorityInteractive); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// The canceled waiter must not swallow the released slot
+	s.release()
+	if err := s.acquire(context.Background(), PriorityBulk); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+}
+
+func TestMaxInFlightPriority(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		first := len(paths) == 1
+		mu.Unlock()
+
+		if first {
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxInFlight(1))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	// Occupy the only slot
+	go func() {
+		defer wg.Done()
+		_, _ = client.GetItem(ctx, 1)
+	}()
+	waitForRequests(t, &mu, &paths, 1)
+
+	// A batch queues first, then a single interactive call jumps ahead of it
+	go func() {
+		defer wg.Done()
+		_, _ = client.GetItemsBatch(ctx, []int{2, 3})
+	}()
+	waitForWaiters(t, client.scheduler, 2)
+
+	go func() {
+		defer wg.Done()
+		_, _ = client.GetItem(ctx, 4, WithPriority(PriorityInteractive))
+	}()
+	waitForWaiters(t, client.scheduler, 3)
+
+	close(release)
+	wg.Wait()
+
+	if got := paths[1]; !strings.HasSuffix(got, "/item/4.json") {
+		t.Errorf("Expected the interactive request to run next, got order %v", paths)
+	}
+}
+
+// waitForWaiters waits until n requests are queued for a slot.
+func waitForWaiters(t *testing.T, s *scheduler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		queued := 0
+		for _, queue := range s.waiting {
+			queued += len(queue)
+		}
+		s.mu.Unlock()
+
+		if queued >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d waiting requests, got %d", n, queued)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForRequests waits until the server has seen n requests.
+func waitForRequests(t *testing.T, mu *sync.Mutex, paths *[]string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		got := len(*paths)
+		mu.Unlock()
+
+		if got >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d requests, got %d", n, got)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
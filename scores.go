@@ -0,0 +1,215 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScoreSample is a story's score and comment count at one point in time.
+type ScoreSample struct {
+	// ID is the story ID.
+	ID int
+
+	// Time is when the sample was taken.
+	Time time.Time
+
+	// Score is the story's score.
+	Score int
+
+	// Descendants is the story's total comment count.
+	Descendants int
+}
+
+// SampleSink stores score samples, for example in a time-series database.
+// Implementations must be safe for concurrent use.
+type SampleSink interface {
+	WriteSamples(ctx context.Context, samples []ScoreSample) error
+}
+
+// SampleSinkFunc adapts a function to the SampleSink interface.
+type SampleSinkFunc func(ctx context.Context, samples []ScoreSample) error
+
+// WriteSamples calls f(ctx, samples).
+func (f SampleSinkFunc) WriteSamples(ctx context.Context, samples []ScoreSample) error {
+	return f(ctx, samples)
+}
+
+// MemorySampleSink is a SampleSink that keeps every sample in memory, grouped by
+// story.
+type MemorySampleSink struct {
+	mu      sync.Mutex
+	samples map[int][]ScoreSample
+}
+
+// NewMemorySampleSink creates an empty in-memory sample sink.
+func NewMemorySampleSink() *MemorySampleSink {
+	return &MemorySampleSink{samples: make(map[int][]ScoreSample)}
+}
+
+// WriteSamples appends samples to the history of their stories.
+func (s *MemorySampleSink) WriteSamples(_ context.Context, samples []ScoreSample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sample := range samples {
+		s.samples[sample.ID] = append(s.samples[sample.ID], sample)
+	}
+	return nil
+}
+
+// History returns the samples of a story in the order they were written.
+func (s *MemorySampleSink) History(id int) []ScoreSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ScoreSample(nil), s.samples[id]...)
+}
+
+// ScoreTracker periodically re-fetches a set of stories and records their score and
+// comment count in a SampleSink, producing the time series needed to chart how a
+// story trended. Stories can be added and removed while the tracker runs.
+//
+// Stories that are deleted or dead are dropped from the tracked set. Stories that
+// are not found, such as IDs the API does not serve yet, stay tracked and are tried
+// again on the next tick.
+type ScoreTracker struct {
+	client   *Client
+	sink     SampleSink
+	interval time.Duration
+	now      func() time.Time
+
+	mu  sync.Mutex
+	ids map[int]struct{}
+}
+
+// NewScoreTracker creates a tracker that samples the given stories every interval
+// and writes the samples to sink. An interval of 0 uses the client's PollInterval.
+func (c *Client) NewScoreTracker(sink SampleSink, interval time.Duration, ids ...int) *ScoreTracker {
+	if interval <= 0 {
+		interval = c.Config.PollInterval
+	}
+
+	t := &ScoreTracker{
+		client:   c,
+		sink:     sink,
+		interval: interval,
+		now:      c.clock().Now,
+		ids:      make(map[int]struct{}),
+	}
+	t.Add(ids...)
+	return t
+}
+
+// Add starts tracking the given stories.
+func (t *ScoreTracker) Add(ids ...int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, id := range ids {
+		t.ids[id] = struct{}{}
+	}
+}
+
+// Remove stops tracking the given stories.
+func (t *ScoreTracker) Remove(ids ...int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, id := range ids {
+		delete(t.ids, id)
+	}
+}
+
+// IDs returns the tracked story IDs in ascending order.
+func (t *ScoreTracker) IDs() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids := make([]int, 0, len(t.ids))
+	for id := range t.ids {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Start samples the tracked stories immediately and then every interval, in the
+// background, until the context is canceled or the client is closed. Errors are
+// logged and passed to the ErrorHandler; sampling continues with the next round.
+func (t *ScoreTracker) Start(ctx context.Context) error {
+	c := t.client
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.goBackground(func() {
+		defer cancel()
+		c.pollEvery(ctx, t.interval, func() {
+			if err := t.Sample(ctx); err != nil && ctx.Err() == nil {
+				c.logger().Warn("failed to sample scores", "error", err)
+				c.handleError(err)
+			}
+		})
+	})
+
+	return nil
+}
+
+// Sample fetches the tracked stories once and writes their samples to the sink.
+// Stories that failed to load are skipped and the first failure is returned.
+func (t *ScoreTracker) Sample(ctx context.Context) error {
+	ids := t.IDs()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, end := t.client.startOperation(ctx, Operation{Name: "SampleScores", BatchSize: len(ids)})
+	err := t.sample(ctx, ids)
+	end(err)
+
+	return err
+}
+
+// sample implements Sample for a non-empty set of IDs.
+func (t *ScoreTracker) sample(ctx context.Context, ids []int) error {
+	now := t.now()
+	samples := make([]ScoreSample, 0, len(ids))
+	var firstErr error
+
+	for _, result := range t.client.fetchItems(ctx, ids, WithNoCache()) {
+		switch {
+		case isTombstone(result.Error):
+			t.Remove(result.ID)
+		case errors.Is(result.Error, ErrNotFound):
+			// Not a failure; the story may appear by the next tick
+			continue
+		case result.Error != nil:
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to get item %d: %w", result.ID, result.Error)
+			}
+		case !result.Item.IsLive():
+			t.Remove(result.ID)
+		default:
+			samples = append(samples, ScoreSample{
+				ID:          result.ID,
+				Time:        now,
+				Score:       result.Item.Score,
+				Descendants: result.Item.Descendants,
+			})
+		}
+	}
+
+	if len(samples) > 0 {
+		if err := t.sink.WriteSamples(ctx, samples); err != nil {
+			return fmt.Errorf("failed to write samples: %w", err)
+		}
+	}
+
+	return firstErr
+}
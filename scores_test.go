@@ -0,0 +1,109 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScoreTracker(t *testing.T) {
+	var calls, missingCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item/1.json":
+			n := atomic.AddInt32(&calls, 1)
+			_, _ = w.Write([]byte(`{"id": 1, "type": "story", "score": ` + strconv.Itoa(int(n*10)) + `, "descendants": ` + strconv.Itoa(int(n)) + `}`))
+		case "/item/2.json":
+			// Not found at first, then served
+			if atomic.AddInt32(&missingCalls, 1) == 1 {
+				_, _ = w.Write([]byte("null"))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": 2, "type": "story", "score": 1}`))
+		case "/item/3.json":
+			_, _ = w.Write([]byte(`{"id": 3, "type": "story", "deleted": true}`))
+		default:
+			_, _ = w.Write([]byte("null"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	sink := NewMemorySampleSink()
+	tracker := client.NewScoreTracker(sink, 10*time.Millisecond, 1, 2, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := tracker.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	for len(sink.History(1)) < 2 || len(sink.History(2)) == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for samples")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	cancel()
+	client.Close()
+
+	history := sink.History(1)
+	if history[0].Score != 10 || history[0].Descendants != 1 || history[1].Score != 20 || history[1].Descendants != 2 {
+		t.Errorf("Unexpected history %+v", history)
+	}
+	if !history[1].Time.After(history[0].Time) {
+		t.Errorf("Expected increasing sample times, got %v and %v", history[0].Time, history[1].Time)
+	}
+
+	if ids := tracker.IDs(); !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("Expected only the deleted story to be dropped, got %v", ids)
+	}
+	if len(sink.History(3)) != 0 {
+		t.Errorf("Expected no samples for the dropped story")
+	}
+}
+
+func TestScoreTrackerSampleErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/item/2.json" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "type": "story", "score": 5}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+
+	errSink := errors.New("sink unavailable")
+	var written []ScoreSample
+	sink := SampleSinkFunc(func(_ context.Context, samples []ScoreSample) error {
+		written = append(written, samples...)
+		return nil
+	})
+
+	tracker := client.NewScoreTracker(sink, time.Minute, 1, 2)
+	if err := tracker.Sample(context.Background()); err == nil {
+		t.Errorf("Expected an error for the failed story")
+	}
+	if len(written) != 1 || written[0].ID != 1 || written[0].Score != 5 {
+		t.Errorf("Expected a sample for the loaded story, got %+v", written)
+	}
+	if ids := tracker.IDs(); !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("Expected failed stories to stay tracked, got %v", ids)
+	}
+
+	failing := client.NewScoreTracker(SampleSinkFunc(func(context.Context, []ScoreSample) error { return errSink }), time.Minute, 1)
+	if err := failing.Sample(context.Background()); !errors.Is(err, errSink) {
+		t.Errorf("Expected the sink error, got %v", err)
+	}
+}
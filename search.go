@@ -0,0 +1,63 @@
+package hnapi
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ThreadQuery selects the comments of a thread by their text. A comment matches
+// if any of the configured predicates matches; a ThreadQuery with no predicates
+// matches every comment.
+type ThreadQuery struct {
+	// Contains matches comments whose plain text contains the string, compared
+	// case-insensitively.
+	Contains string
+
+	// Regexp matches comments whose plain text matches the expression.
+	Regexp *regexp.Regexp
+}
+
+// ThreadMatch is a comment found by SearchTree.
+type ThreadMatch struct {
+	// Item is the matching comment.
+	Item *Item
+
+	// Path is the IDs of the comment's ancestors, from the root of the tree down
+	// to its parent, so the match can be shown with its context.
+	Path []int
+}
+
+// Match reports whether the comment's text is accepted by the query. Text is
+// compared as plain text, converted with HTMLToText.
+func (q ThreadQuery) Match(item *Item) bool {
+	if q.Contains == "" && q.Regexp == nil {
+		return true
+	}
+
+	text := item.PlainText()
+	if q.Contains != "" && strings.Contains(strings.ToLower(text), strings.ToLower(q.Contains)) {
+		return true
+	}
+	return q.Regexp != nil && q.Regexp.MatchString(text)
+}
+
+// SearchTree walks a comment tree and returns the comments matching the query, in
+// the order of the tree. The root of the tree is not searched.
+func SearchTree(tree *CommentNode, query ThreadQuery) []ThreadMatch {
+	matches := []ThreadMatch{}
+
+	var walk func(node *CommentNode, path []int)
+	walk = func(node *CommentNode, path []int) {
+		path = append(path, node.Item.ID)
+		for _, child := range node.Children {
+			if query.Match(child.Item) {
+				matches = append(matches, ThreadMatch{Item: child.Item, Path: slices.Clone(path)})
+			}
+			walk(child, path)
+		}
+	}
+	walk(tree, nil)
+
+	return matches
+}
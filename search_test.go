@@ -0,0 +1,45 @@
+package hnapi
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestSearchTree(t *testing.T) {
+	node := func(id int, text string, children ...*CommentNode) *CommentNode {
+		return &CommentNode{Item: &Item{ID: id, Text: text}, Children: children}
+	}
+	tree := node(1, "Go is great",
+		node(2, "I prefer <i>Rust</i>",
+			node(4, "Go&#x27;s GC is fine"),
+			node(5, "Both are fine")),
+		node(3, "Deleted"),
+		node(6, "", node(7, "GO generics")),
+	)
+
+	tests := []struct {
+		name  string
+		query ThreadQuery
+		want  string
+	}{
+		{name: "substring ignores case", query: ThreadQuery{Contains: "go"}, want: "[4:[1 2] 7:[1 6]]"},
+		{name: "plain text", query: ThreadQuery{Contains: "go's gc"}, want: "[4:[1 2]]"},
+		{name: "regexp", query: ThreadQuery{Regexp: regexp.MustCompile(`\bfine$`)}, want: "[4:[1 2] 5:[1 2]]"},
+		{name: "either predicate", query: ThreadQuery{Contains: "rust", Regexp: regexp.MustCompile(`^Both`)}, want: "[2:[1] 5:[1 2]]"},
+		{name: "no match", query: ThreadQuery{Contains: "python"}, want: "[]"},
+		{name: "empty query", query: ThreadQuery{}, want: "[2:[1] 4:[1 2] 5:[1 2] 3:[1] 6:[1] 7:[1 6]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, match := range SearchTree(tree, tt.query) {
+				got = append(got, fmt.Sprintf("%d:%v", match.Item.ID, match.Path))
+			}
+			if s := fmt.Sprint(got); s != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package hnapi
+
+import "context"
+
+// HNService is the set of Hacker News operations provided by Client.
+// Code that depends on HNService rather than *Client can swap in mocks or
+// decorators (caching, metrics) without wrapping the concrete struct.
+type HNService interface {
+	// GetItem retrieves a single item by its ID.
+	GetItem(ctx context.Context, id int, opts ...CallOption) (*Item, error)
+
+	// GetUser retrieves a user by username.
+	GetUser(ctx context.Context, username string, opts ...CallOption) (*User, error)
+
+	// GetItemsBatch retrieves multiple items concurrently by their IDs.
+	GetItemsBatch(ctx context.Context, ids []int, opts ...CallOption) ([]*Item, error)
+
+	// GetTopStories retrieves the current top story IDs.
+	GetTopStories(ctx context.Context) ([]int, error)
+
+	// GetNewStories retrieves the newest story IDs.
+	GetNewStories(ctx context.Context) ([]int, error)
+
+	// GetBestStories retrieves the best story IDs.
+	GetBestStories(ctx context.Context) ([]int, error)
+
+	// GetAskStories retrieves the Ask HN story IDs.
+	GetAskStories(ctx context.Context) ([]int, error)
+
+	// GetShowStories retrieves the Show HN story IDs.
+	GetShowStories(ctx context.Context) ([]int, error)
+
+	// GetJobStories retrieves the job story IDs.
+	GetJobStories(ctx context.Context) ([]int, error)
+
+	// StartUpdates begins streaming changes from the updates endpoint.
+	StartUpdates(ctx context.Context) (<-chan Updates, error)
+}
+
+// Ensure Client implements HNService.
+var _ HNService = (*Client)(nil)
@@ -0,0 +1,42 @@
+package hnapi
+
+import (
+	"context"
+	"testing"
+)
+
+// countingService is a decorator that counts GetItem calls on an underlying HNService.
+type countingService struct {
+	HNService
+	getItemCalls int
+}
+
+func (s *countingService) GetItem(ctx context.Context, id int, opts ...CallOption) (*Item, error) {
+	s.getItemCalls++
+	return s.HNService.GetItem(ctx, id, opts...)
+}
+
+// stubService returns canned items without touching the network.
+type stubService struct {
+	HNService
+}
+
+func (stubService) GetItem(_ context.Context, id int, _ ...CallOption) (*Item, error) {
+	return &Item{ID: id, Type: "story"}, nil
+}
+
+func TestHNServiceDecorator(t *testing.T) {
+	var svc HNService = &countingService{HNService: stubService{}}
+
+	item, err := svc.GetItem(context.Background(), 8863)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.ID != 8863 {
+		t.Errorf("Expected item 8863, got %d", item.ID)
+	}
+
+	if calls := svc.(*countingService).getItemCalls; calls != 1 {
+		t.Errorf("Expected 1 GetItem call, got %d", calls)
+	}
+}
@@ -0,0 +1,237 @@
+package hnapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// UpdatesMode selects the transport used to receive updates.
+type UpdatesMode int
+
+const (
+	// UpdatesModePoll fetches the updates endpoint every PollInterval.
+	UpdatesModePoll UpdatesMode = iota
+
+	// UpdatesModeStream holds a Server-Sent Events connection to the updates
+	// endpoint and receives changes as they happen. If the stream cannot be
+	// established, the client falls back to polling until it can reconnect.
+	UpdatesModeStream
+)
+
+// String returns the name of the updates mode.
+func (m UpdatesMode) String() string {
+	switch m {
+	case UpdatesModePoll:
+		return "poll"
+	case UpdatesModeStream:
+		return "stream"
+	default:
+		return fmt.Sprintf("UpdatesMode(%d)", int(m))
+	}
+}
+
+// errStreamCanceled is returned when the server cancels the event stream.
+var errStreamCanceled = errors.New("event stream canceled by server")
+
+// sseEvent is a single Server-Sent Event.
+type sseEvent struct {
+	Name string
+	Data string
+}
+
+// firebaseEvent is the payload of Firebase "put" and "patch" events.
+type firebaseEvent struct {
+	Path string          `json:"path"`
+	Data json.RawMessage `json:"data"`
+}
+
+// runStreamingUpdates streams the updates endpoint over Server-Sent Events until the
+// context is canceled. A stream that drops after connecting is re-established after
+// BackoffInterval. When the stream cannot be established at all, the updates endpoint
+// is polled once and the stream is retried after PollInterval.
+func (c *Client) runStreamingUpdates(ctx context.Context, updatesCh chan Updates, dedup *updatesDeduper, report func(error)) {
+	for {
+		c.requestStart(ctx, RequestStartInfo{Endpoint: "updates.json", Attempt: 1})
+		start := time.Now()
+
+		connected, err := c.streamUpdates(ctx, updatesCh, dedup)
+
+		end := RequestEndInfo{Endpoint: "updates.json", Attempt: 1, Duration: time.Since(start), Err: err}
+		if connected {
+			end.StatusCode = http.StatusOK
+		}
+		c.requestEnd(ctx, end)
+
+		if ctx.Err() != nil {
+			return
+		}
+		report(fmt.Errorf("updates stream interrupted: %w", err))
+
+		wait := c.Config.BackoffInterval
+		if !connected {
+			// Fall back to polling while the stream is unavailable
+			if err := c.pollUpdates(ctx, updatesCh, dedup); err != nil {
+				report(err)
+			}
+			wait = c.Config.PollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock().After(wait):
+		}
+	}
+}
+
+// streamUpdates opens one event stream to the updates endpoint and forwards changes
+// until the stream ends. It reports whether the stream was established and always
+// returns a non-nil error.
+func (c *Client) streamUpdates(ctx context.Context, updatesCh chan Updates, dedup *updatesDeduper) (bool, error) {
+	req, err := c.newRequest(ctx, c.failover.current(c.Config), "updates.json")
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/event-stream") {
+		return false, fmt.Errorf("unexpected content type: %q", contentType)
+	}
+
+	var state Updates
+	return true, readSSE(resp.Body, func(event sseEvent) error {
+		switch event.Name {
+		case "put", "patch":
+			if err := c.applyUpdatesEvent(ctx, &state, event); err != nil {
+				return err
+			}
+
+			updates := dedup.filter(state, c.clock().Now())
+			if len(updates.Items) > 0 || len(updates.Profiles) > 0 {
+				c.metrics.updatesReceived.Add(1)
+				return c.sendUpdates(ctx, updatesCh, updates)
+			}
+			return nil
+		case "cancel", "auth_revoked":
+			return errStreamCanceled
+		default:
+			// keep-alive and unknown events carry no data
+			return nil
+		}
+	})
+}
+
+// applyUpdatesEvent applies a Firebase put or patch event to the current updates state.
+// Changes below the top-level fields are resolved by re-fetching the whole endpoint.
+func (c *Client) applyUpdatesEvent(ctx context.Context, state *Updates, event sseEvent) error {
+	var payload firebaseEvent
+	if err := c.unmarshalJSON([]byte(event.Data), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal %s event: %w", event.Name, err)
+	}
+
+	switch {
+	case payload.Path == "/" && event.Name == "put":
+		var updates Updates
+		if err := c.unmarshalNullable(payload.Data, &updates); err != nil {
+			return err
+		}
+		*state = updates
+	case payload.Path == "/":
+		// A patch only replaces the fields it contains
+		if err := c.unmarshalNullable(payload.Data, state); err != nil {
+			return err
+		}
+	case payload.Path == "/items":
+		state.Items = nil
+		if err := c.unmarshalNullable(payload.Data, &state.Items); err != nil {
+			return err
+		}
+	case payload.Path == "/profiles":
+		state.Profiles = nil
+		if err := c.unmarshalNullable(payload.Data, &state.Profiles); err != nil {
+			return err
+		}
+	default:
+		var updates Updates
+		if err := c.makeRequest(ctx, "updates.json", &updates, callOptions{}); err != nil {
+			return fmt.Errorf("failed to get updates: %w", err)
+		}
+		*state = updates
+	}
+
+	return nil
+}
+
+// unmarshalNullable unmarshals data into target, treating a JSON null as a no-op.
+func (c *Client) unmarshalNullable(data json.RawMessage, target interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if err := c.unmarshalJSON(data, target); err != nil {
+		return fmt.Errorf("failed to unmarshal event data: %w", err)
+	}
+	return nil
+}
+
+// readSSE parses a Server-Sent Events stream and calls handle for every event.
+// It returns when the stream ends, reading fails, or handle returns an error.
+func readSSE(r io.Reader, handle func(sseEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var event sseEvent
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the event
+		if line == "" {
+			if event.Name != "" || len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if err := handle(event); err != nil {
+					return err
+				}
+			}
+			event = sseEvent{}
+			data = data[:0]
+			continue
+		}
+
+		// Lines starting with a colon are comments
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read event stream: %w", err)
+	}
+	return io.EOF
+}
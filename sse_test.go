@@ -0,0 +1,172 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadSSE(t *testing.T) {
+	stream := ": comment\n" +
+		"event: put\n" +
+		"data: {\"path\": \"/\",\n" +
+		"data: \"data\": null}\n" +
+		"\n" +
+		"event: keep-alive\n" +
+		"data: null\n" +
+		"\n"
+
+	var events []sseEvent
+	err := readSSE(strings.NewReader(stream), func(event sseEvent) error {
+		events = append(events, event)
+		return nil
+	})
+
+	if err != io.EOF {
+		t.Errorf("Expected io.EOF at end of stream, got %v", err)
+	}
+
+	want := []sseEvent{
+		{Name: "put", Data: "{\"path\": \"/\",\n\"data\": null}"},
+		{Name: "keep-alive", Data: "null"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("readSSE() events = %#v, want %#v", events, want)
+	}
+}
+
+func TestApplyUpdatesEvent(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	var state Updates
+	events := []sseEvent{
+		{Name: "put", Data: `{"path": "/", "data": {"items": [1, 2], "profiles": ["a"]}}`},
+		{Name: "patch", Data: `{"path": "/", "data": {"items": [3]}}`},
+		{Name: "put", Data: `{"path": "/profiles", "data": ["b", "c"]}`},
+	}
+	for _, event := range events {
+		if err := client.applyUpdatesEvent(ctx, &state, event); err != nil {
+			t.Fatalf("applyUpdatesEvent(%s) error = %v", event.Data, err)
+		}
+	}
+
+	want := Updates{Items: []int{3}, Profiles: []string{"b", "c"}}
+	if !reflect.DeepEqual(state, want) {
+		t.Errorf("Expected state %+v, got %+v", want, state)
+	}
+
+	if err := client.applyUpdatesEvent(ctx, &state, sseEvent{Name: "put", Data: "{invalid"}); err == nil {
+		t.Errorf("Expected error for invalid event data")
+	}
+}
+
+func TestStreamingUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected Accept: text/event-stream, got %q", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, "event: put\ndata: {\"path\": \"/\", \"data\": {\"items\": [1], \"profiles\": []}}\n\n")
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, "event: put\ndata: {\"path\": \"/items\", \"data\": [2, 3]}\n\n")
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithUpdatesMode(UpdatesModeStream),
+	)
+
+	if !client.Capabilities().SSE {
+		t.Errorf("Expected SSE capability when streaming is configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatesCh, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+
+	for _, want := range [][]int{{1}, {2, 3}} {
+		select {
+		case updates := <-updatesCh:
+			if !reflect.DeepEqual(updates.Items, want) {
+				t.Errorf("Expected items %v, got %v", want, updates.Items)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for streamed items %v", want)
+		}
+	}
+
+	cancel()
+	for range updatesCh {
+	}
+}
+
+func TestStreamingUpdatesFallsBackToPolling(t *testing.T) {
+	// The server does not support event streams and always answers with JSON
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"items": [42], "profiles": []}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	errCh := make(chan error, 10)
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithUpdatesMode(UpdatesModeStream),
+		WithPollInterval(time.Hour),
+		WithErrorHandler(func(err error) {
+			errCh <- err
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatesCh, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+
+	select {
+	case updates := <-updatesCh:
+		if !reflect.DeepEqual(updates.Items, []int{42}) {
+			t.Errorf("Expected polled items [42], got %v", updates.Items)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for fallback poll")
+	}
+
+	select {
+	case err := <-errCh:
+		if !strings.Contains(err.Error(), "unexpected content type") {
+			t.Errorf("Expected content type error, got %v", err)
+		}
+	default:
+		t.Errorf("Expected the stream failure to be reported")
+	}
+
+	cancel()
+	for range updatesCh {
+	}
+}
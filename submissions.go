@@ -0,0 +1,189 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultSubmissionsLimit is the page size GetUserSubmissions uses when the query
+// has no Limit.
+const DefaultSubmissionsLimit = 30
+
+// ErrInvalidCursor is returned by GetUserSubmissions and GetKidsPage for a cursor
+// they did not issue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SubmissionsQuery selects and pages through a user's submissions.
+type SubmissionsQuery struct {
+	// Types keeps only items of the given types, such as TypeStory or TypeComment.
+	// Empty keeps all types.
+	Types []ItemType
+
+	// Since keeps only items created at or after this time. Zero keeps all items.
+	Since time.Time
+
+	// Limit is the maximum number of items per page. It defaults to
+	// DefaultSubmissionsLimit.
+	Limit int
+
+	// Cursor continues after the page that returned it as NextCursor. Empty starts
+	// with the most recent submission.
+	Cursor string
+}
+
+// SubmissionsPage is one page of a user's submissions.
+type SubmissionsPage struct {
+	// Items are the matching submissions, most recent first.
+	Items []*Item
+
+	// NextCursor continues with the next page, or is empty if there are no more
+	// submissions to examine.
+	NextCursor string
+}
+
+// GetUserSubmissions retrieves one page of a user's submissions, hydrated into items
+// and filtered by the query. Submissions are examined most recent first in batches,
+// so a page only fetches as many items as it needs, even for users with tens of
+// thousands of submissions. Deleted and dead items are left out.
+//
+// Cursors are based on item IDs, so pages stay consistent while the user keeps
+// submitting. Because submissions are ordered by creation time, paging stops at the
+// first item older than Since.
+func (c *Client) GetUserSubmissions(ctx context.Context, username string, query SubmissionsQuery, opts ...CallOption) (*SubmissionsPage, error) {
+	before := 0
+	if query.Cursor != "" {
+		id, err := strconv.Atoi(query.Cursor)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, query.Cursor)
+		}
+		before = id
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultSubmissionsLimit
+	}
+
+	ctx, end := c.startOperation(ctx, Operation{Name: "GetUserSubmissions", Username: username})
+
+	page, err := c.getUserSubmissions(ctx, username, query, before, limit, opts)
+	end(err)
+
+	return page, err
+}
+
+// getUserSubmissions implements GetUserSubmissions for a parsed cursor and limit.
+func (c *Client) getUserSubmissions(ctx context.Context, username string, query SubmissionsQuery, before, limit int, opts []CallOption) (*SubmissionsPage, error) {
+	user, err := c.GetUser(ctx, username, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.filterSubmissions(ctx, user.Submitted, query, before, limit, opts)
+}
+
+// filterSubmissions hydrates submission IDs, most recent first, until it has a page
+// of limit items matching the query. Submissions with IDs from before on are skipped.
+func (c *Client) filterSubmissions(ctx context.Context, ids []int, query SubmissionsQuery, before, limit int, opts []CallOption) (*SubmissionsPage, error) {
+	// Submitted is most recent first; skip what earlier pages covered
+	if before > 0 {
+		start := len(ids)
+		for i, id := range ids {
+			if id < before {
+				start = i
+				break
+			}
+		}
+		ids = ids[start:]
+	}
+
+	page := &SubmissionsPage{Items: []*Item{}}
+
+	// Fetch at least a full round of concurrent requests per batch
+	batchSize := limit
+	if batchSize < c.Config.Concurrency {
+		batchSize = c.Config.Concurrency
+	}
+
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		ids = ids[len(batch):]
+
+		results := withoutTombstones(c.fetchItems(ctx, batch, opts...))
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		for _, result := range results {
+			switch {
+			case errors.Is(result.Error, ErrNotFound):
+				continue
+			case result.Error != nil:
+				return nil, fmt.Errorf("failed to get item %d: %w", result.ID, result.Error)
+			}
+
+			item := result.Item
+			if !query.Since.IsZero() && item.CreatedAt().Before(query.Since) {
+				// Everything after this submission is older still
+				return page, nil
+			}
+			if !query.matchesType(item) {
+				continue
+			}
+
+			page.Items = append(page.Items, item)
+			if len(page.Items) == limit {
+				if len(ids) > 0 || item.ID != batch[len(batch)-1] {
+					page.NextCursor = strconv.Itoa(item.ID)
+				}
+				return page, nil
+			}
+		}
+	}
+
+	return page, nil
+}
+
+// SubmittedStories hydrates the most recent stories among a user's submissions, up
+// to limit, or DefaultSubmissionsLimit if limit is 0. Submissions are examined in
+// batches, most recent first, so profile pages of prolific users only fetch as many
+// items as they show. Deleted and dead stories are left out.
+func (c *Client) SubmittedStories(ctx context.Context, user *User, limit int, opts ...CallOption) ([]*Item, error) {
+	return c.submittedOfType(ctx, "SubmittedStories", user, TypeStory, limit, opts)
+}
+
+// SubmittedComments hydrates the most recent comments among a user's submissions,
+// like SubmittedStories.
+func (c *Client) SubmittedComments(ctx context.Context, user *User, limit int, opts ...CallOption) ([]*Item, error) {
+	return c.submittedOfType(ctx, "SubmittedComments", user, TypeComment, limit, opts)
+}
+
+// submittedOfType implements SubmittedStories and SubmittedComments.
+func (c *Client) submittedOfType(ctx context.Context, name string, user *User, itemType ItemType, limit int, opts []CallOption) ([]*Item, error) {
+	if limit <= 0 {
+		limit = DefaultSubmissionsLimit
+	}
+
+	ctx, end := c.startOperation(ctx, Operation{Name: name, Username: user.ID})
+
+	query := SubmissionsQuery{Types: []ItemType{itemType}}
+	page, err := c.filterSubmissions(ctx, user.Submitted, query, 0, limit, opts)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions of %s: %w", user.ID, err)
+	}
+
+	return page.Items, nil
+}
+
+// matchesType reports whether the item has one of the query's types.
+func (q SubmissionsQuery) matchesType(item *Item) bool {
+	return len(q.Types) == 0 || slices.Contains(q.Types, item.Type)
+}
@@ -0,0 +1,161 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetUserSubmissions(t *testing.T) {
+	items := map[string]string{
+		"10": `{"id": 10, "type": "comment", "time": 1000}`,
+		"9":  `{"id": 9, "type": "story", "time": 900}`,
+		"8":  `{"id": 8, "type": "story", "time": 800, "deleted": true}`,
+		"7":  `{"id": 7, "type": "comment", "time": 700}`,
+		"6":  `{"id": 6, "type": "story", "time": 600}`,
+		"5":  `{"id": 5, "type": "poll", "time": 500}`,
+		"4":  `{"id": 4, "type": "story", "time": 400}`,
+	}
+
+	var itemRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user/alice.json" {
+			_, _ = w.Write([]byte(`{"id": "alice", "submitted": [10, 9, 8, 7, 6, 5, 4, 3]}`))
+			return
+		}
+		atomic.AddInt32(&itemRequests, 1)
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+		if item, ok := items[id]; ok {
+			_, _ = w.Write([]byte(item))
+			return
+		}
+		_, _ = w.Write([]byte("null"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(2))
+	ctx := context.Background()
+
+	pageIDs := func(page *SubmissionsPage) []int {
+		var ids []int
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	// Page through stories two at a time
+	var got [][]int
+	query := SubmissionsQuery{Types: []ItemType{TypeStory}, Limit: 2}
+	for {
+		page, err := client.GetUserSubmissions(ctx, "alice", query)
+		if err != nil {
+			t.Fatalf("GetUserSubmissions() error = %v", err)
+		}
+		got = append(got, pageIDs(page))
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if want := [][]int{{9, 6}, {4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Story pages = %v, want %v", got, want)
+	}
+
+	// The first page of everything only fetches what it needs
+	atomic.StoreInt32(&itemRequests, 0)
+	page, err := client.GetUserSubmissions(ctx, "alice", SubmissionsQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetUserSubmissions() error = %v", err)
+	}
+	if ids := pageIDs(page); !reflect.DeepEqual(ids, []int{10, 9}) || page.NextCursor != "9" {
+		t.Errorf("First page = %v, cursor %q", ids, page.NextCursor)
+	}
+	if n := atomic.LoadInt32(&itemRequests); n != 2 {
+		t.Errorf("Expected 2 item requests, got %d", n)
+	}
+
+	// Since stops at the first older submission
+	page, err = client.GetUserSubmissions(ctx, "alice", SubmissionsQuery{Since: time.Unix(600, 0), Limit: 10})
+	if err != nil {
+		t.Fatalf("GetUserSubmissions() error = %v", err)
+	}
+	if ids := pageIDs(page); !reflect.DeepEqual(ids, []int{10, 9, 7, 6}) || page.NextCursor != "" {
+		t.Errorf("Since page = %v, cursor %q", ids, page.NextCursor)
+	}
+
+	if _, err := client.GetUserSubmissions(ctx, "alice", SubmissionsQuery{Cursor: "abc"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := client.GetUserSubmissions(ctx, "nobody", SubmissionsQuery{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing user, got %v", err)
+	}
+}
+
+func TestSubmittedStoriesAndComments(t *testing.T) {
+	items := map[string]string{
+		"/item/10.json": `{"id": 10, "type": "comment"}`,
+		"/item/9.json":  `{"id": 9, "type": "story"}`,
+		"/item/8.json":  `{"id": 8, "type": "story", "dead": true}`,
+		"/item/7.json":  `{"id": 7, "type": "comment"}`,
+		"/item/6.json":  `{"id": 6, "type": "story"}`,
+		"/item/5.json":  `{"id": 5, "type": "comment"}`,
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, ok := items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(2))
+	user := &User{ID: "pg", Submitted: []int{10, 9, 8, 7, 6, 5}}
+	ctx := context.Background()
+
+	ids := func(items []*Item) []int {
+		var ids []int
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	stories, err := client.SubmittedStories(ctx, user, 0)
+	if err != nil {
+		t.Fatalf("SubmittedStories() error = %v", err)
+	}
+	if got := ids(stories); !reflect.DeepEqual(got, []int{9, 6}) {
+		t.Errorf("Expected stories [9 6], got %v", got)
+	}
+
+	// Only as many submissions are fetched as the limit needs
+	requests.Store(0)
+	comments, err := client.SubmittedComments(ctx, user, 1)
+	if err != nil {
+		t.Fatalf("SubmittedComments() error = %v", err)
+	}
+	if got := ids(comments); !reflect.DeepEqual(got, []int{10}) {
+		t.Errorf("Expected comments [10], got %v", got)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected one batch of 2 requests, got %d", got)
+	}
+
+	// Failures are reported
+	user.Submitted = append(user.Submitted, 4)
+	if _, err := client.SubmittedStories(ctx, user, 10); err == nil || !strings.Contains(err.Error(), "item 4") {
+		t.Errorf("Expected the failure of item 4, got %v", err)
+	}
+}
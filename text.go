@@ -0,0 +1,180 @@
+package hnapi
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlToken is a piece of the limited HTML used in item text and user about fields:
+// either unescaped text or a start or end tag.
+type htmlToken struct {
+	// Text is the unescaped text for text tokens.
+	Text string
+
+	// Tag is the lowercased tag name for tag tokens, or empty for text.
+	Tag string
+
+	// Closing reports whether the tag is an end tag.
+	Closing bool
+
+	// Href is the unescaped href attribute of an "a" start tag.
+	Href string
+}
+
+// tokenizeHTML splits HN's comment HTML into tokens. It only understands what the
+// site produces: paragraphs, links, italics, and preformatted code. Malformed markup
+// is treated as text.
+func tokenizeHTML(s string) []htmlToken {
+	var tokens []htmlToken
+
+	for len(s) > 0 {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			tokens = append(tokens, htmlToken{Text: html.UnescapeString(s)})
+			break
+		}
+		end := strings.IndexByte(s[start:], '>')
+		if end < 0 {
+			tokens = append(tokens, htmlToken{Text: html.UnescapeString(s)})
+			break
+		}
+		end += start
+
+		if start > 0 {
+			tokens = append(tokens, htmlToken{Text: html.UnescapeString(s[:start])})
+		}
+
+		tokens = append(tokens, parseTag(s[start+1:end]))
+		s = s[end+1:]
+	}
+
+	return tokens
+}
+
+// parseTag parses the inside of a tag, such as `a href="https://example.com"`.
+func parseTag(inner string) htmlToken {
+	var token htmlToken
+
+	inner = strings.TrimSpace(inner)
+	if strings.HasPrefix(inner, "/") {
+		token.Closing = true
+		inner = inner[1:]
+	}
+	inner = strings.TrimSuffix(inner, "/")
+
+	name, attrs, _ := strings.Cut(inner, " ")
+	token.Tag = strings.ToLower(name)
+
+	if token.Tag == "a" && !token.Closing {
+		token.Href = attrValue(attrs, "href")
+	}
+
+	return token
+}
+
+// attrValue returns the unescaped value of a double-quoted attribute, or an empty
+// string if it is not present.
+func attrValue(attrs, name string) string {
+	prefix := name + `="`
+	for {
+		i := strings.Index(attrs, prefix)
+		if i < 0 {
+			return ""
+		}
+		// Only match whole attribute names
+		if i > 0 && attrs[i-1] != ' ' {
+			attrs = attrs[i+len(prefix):]
+			continue
+		}
+
+		value := attrs[i+len(prefix):]
+		if end := strings.IndexByte(value, '"'); end >= 0 {
+			value = value[:end]
+		}
+		return html.UnescapeString(value)
+	}
+}
+
+// linkText returns how a link should read in plain text. HN shortens long link
+// texts with "...", so the full href is preferred when the text is a truncated URL.
+func linkText(text, href string) string {
+	switch {
+	case href == "":
+		return text
+	case text == "" || text == href:
+		return href
+	case isTruncatedLink(text, href):
+		return href
+	default:
+		return text + " (" + href + ")"
+	}
+}
+
+// isTruncatedLink reports whether text is href shortened with a trailing "...".
+func isTruncatedLink(text, href string) bool {
+	return strings.HasSuffix(text, "...") && strings.HasPrefix(href, strings.TrimSuffix(text, "..."))
+}
+
+// HTMLToText converts the HTML of an item's text or a user's about field to plain
+// text. Entities are unescaped, paragraphs are separated by blank lines, links are
+// written out with their URLs, and preformatted code keeps its layout.
+func HTMLToText(s string) string {
+	var b strings.Builder
+	var link *htmlToken
+	var linkBody strings.Builder
+
+	paragraph := func() {
+		if b.Len() > 0 {
+			breakLines(&b, 2)
+		}
+	}
+
+	for _, token := range tokenizeHTML(s) {
+		switch {
+		case token.Tag == "":
+			if link != nil {
+				linkBody.WriteString(token.Text)
+			} else {
+				b.WriteString(token.Text)
+			}
+		case token.Tag == "a" && !token.Closing:
+			t := token
+			link = &t
+			linkBody.Reset()
+		case token.Tag == "a":
+			if link != nil {
+				b.WriteString(linkText(linkBody.String(), link.Href))
+				link = nil
+			}
+		case token.Tag == "p" && !token.Closing, token.Tag == "pre":
+			paragraph()
+		case token.Tag == "br":
+			b.WriteString("\n")
+		}
+	}
+
+	// An unterminated link still contributes its text
+	if link != nil {
+		b.WriteString(linkText(linkBody.String(), link.Href))
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// breakLines appends newlines to b until it ends with at least n of them.
+func breakLines(b *strings.Builder, n int) {
+	s := b.String()
+	for trailing := len(s) - len(strings.TrimRight(s, "\n")); trailing < n; trailing++ {
+		b.WriteByte('\n')
+	}
+}
+
+// PlainText returns the item's text converted to plain text with HTMLToText.
+func (i *Item) PlainText() string {
+	return HTMLToText(i.Text)
+}
+
+// PlainAbout returns the user's about field converted to plain text with HTMLToText.
+func (u *User) PlainAbout() string {
+	return HTMLToText(u.About)
+}
@@ -0,0 +1,72 @@
+package hnapi
+
+import "testing"
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "entities",
+			html: "It&#x27;s &quot;fine&quot; &amp; 1 &lt; 2 &#x2F; 3",
+			want: `It's "fine" & 1 < 2 / 3`,
+		},
+		{
+			name: "paragraphs",
+			html: "First paragraph.<p>Second paragraph.<p>Third.",
+			want: "First paragraph.\n\nSecond paragraph.\n\nThird.",
+		},
+		{
+			name: "italics are dropped",
+			html: "This is <i>important</i>.",
+			want: "This is important.",
+		},
+		{
+			name: "truncated link uses href",
+			html: `See <a href="https:&#x2F;&#x2F;example.com&#x2F;a&#x2F;very&#x2F;long&#x2F;path" rel="nofollow">https:&#x2F;&#x2F;example.com&#x2F;a&#x2F;very...</a> for more`,
+			want: "See https://example.com/a/very/long/path for more",
+		},
+		{
+			name: "named link keeps text and href",
+			html: `Read <a href="https://go.dev/doc">the docs</a>.`,
+			want: "Read the docs (https://go.dev/doc).",
+		},
+		{
+			name: "code block keeps layout",
+			html: "Try this:<p><pre><code>  if err != nil {\n    return err\n  }\n</code></pre>Done.",
+			want: "Try this:\n\n  if err != nil {\n    return err\n  }\n\nDone.",
+		},
+		{
+			name: "malformed markup is text",
+			html: "a < b and c",
+			want: "a < b and c",
+		},
+		{
+			name: "empty",
+			html: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToText(tt.html); got != tt.want {
+				t.Errorf("HTMLToText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainTextMethods(t *testing.T) {
+	item := &Item{Text: "Hello<p>World &amp; all"}
+	if got := item.PlainText(); got != "Hello\n\nWorld & all" {
+		t.Errorf("Item.PlainText() = %q", got)
+	}
+
+	user := &User{About: "I build <i>things</i>."}
+	if got := user.PlainAbout(); got != "I build things." {
+		t.Errorf("User.PlainAbout() = %q", got)
+	}
+}
@@ -0,0 +1,40 @@
+package hnapi
+
+import "context"
+
+// Operation describes a client operation being traced.
+type Operation struct {
+	// Name is the client method, such as "GetItem", "GetItemsBatch", or "PollUpdates".
+	// Requests made with GetInto or Get are named "Get".
+	Name string
+
+	// Endpoint is the API endpoint for single-request operations, such as "item/8863.json".
+	Endpoint string
+
+	// ItemID is the item ID for item operations, or 0.
+	ItemID int
+
+	// Username is the username for user operations, or empty.
+	Username string
+
+	// BatchSize is the number of IDs requested by batch operations, or 0.
+	BatchSize int
+}
+
+// Tracer instruments client operations. StartOperation is called when an operation
+// begins and returns the context to run the operation with and a function that must
+// be called with the operation's result. Operations started with the returned context,
+// such as the per-item fetches of a batch, are nested inside it.
+//
+// The otelhnapi package provides an OpenTelemetry implementation.
+type Tracer interface {
+	StartOperation(ctx context.Context, op Operation) (context.Context, func(err error))
+}
+
+// startOperation starts tracing an operation with the configured tracer, if any.
+func (c *Client) startOperation(ctx context.Context, op Operation) (context.Context, func(err error)) {
+	if c.Config.Tracer == nil {
+		return ctx, func(error) {}
+	}
+	return c.Config.Tracer.StartOperation(ctx, op)
+}
@@ -0,0 +1,55 @@
+package hnapi
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultIdleConnTimeout is how long idle connections of the default HTTP client
+// are kept open.
+const DefaultIdleConnTimeout = 90 * time.Second
+
+// newDefaultHTTPClient returns the HTTP client used when none is configured. It has
+// its own connection pool, sized so that every concurrent batch worker can keep an
+// idle connection to the API host, unless MaxIdleConnsPerHost says otherwise.
+//
+// The client sets no overall Timeout, since it would cut off streaming updates;
+// instead the transport bounds connecting, the TLS handshake, and waiting for
+// response headers.
+func newDefaultHTTPClient(config *Config) *http.Client {
+	idlePerHost := config.MaxIdleConnsPerHost
+	if idlePerHost <= 0 {
+		idlePerHost = max(config.Concurrency, 1)
+	}
+
+	idleTimeout := config.IdleConnTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleConnTimeout
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          idlePerHost * 2,
+		MaxIdleConnsPerHost:   idlePerHost,
+		IdleConnTimeout:       idleTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// samePool reports whether a and b configure the default HTTP client's connection
+// pool identically.
+func samePool(a, b *Config) bool {
+	return a.MaxIdleConnsPerHost == b.MaxIdleConnsPerHost && a.IdleConnTimeout == b.IdleConnTimeout
+}
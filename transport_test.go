@@ -0,0 +1,76 @@
+package hnapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDefaultHTTPClient(t *testing.T) {
+	client := NewClient(WithConcurrency(25))
+
+	httpClient := client.Config.HTTPClient
+	if httpClient == nil || httpClient == http.DefaultClient {
+		t.Fatalf("Expected a dedicated default HTTP client, got %v", httpClient)
+	}
+
+	if httpClient.Timeout != 0 {
+		t.Errorf("Expected no overall timeout so streams are not cut off, got %v", httpClient.Timeout)
+	}
+
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", httpClient.Transport)
+	}
+
+	if transport.MaxIdleConnsPerHost != 25 {
+		t.Errorf("Expected MaxIdleConnsPerHost to be 25, got %d", transport.MaxIdleConnsPerHost)
+	}
+
+	if !transport.ForceAttemptHTTP2 {
+		t.Errorf("Expected HTTP/2 to be enabled")
+	}
+
+	if transport == http.DefaultTransport {
+		t.Errorf("Expected a transport separate from http.DefaultTransport")
+	}
+
+	// Each client gets its own connection pool
+	if other := NewClient().Config.HTTPClient; other == httpClient {
+		t.Errorf("Expected clients not to share the default HTTP client")
+	}
+}
+
+func TestConnectionPoolOptions(t *testing.T) {
+	client := NewClient(WithConcurrency(5), WithMaxIdleConnsPerHost(64), WithIdleConnTimeout(time.Minute))
+
+	transport := client.Config.HTTPClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("Expected MaxIdleConnsPerHost to be 64, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxIdleConns != 128 {
+		t.Errorf("Expected MaxIdleConns to be 128, got %d", transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("Expected IdleConnTimeout to be 1m, got %v", transport.IdleConnTimeout)
+	}
+
+	// Copies share the pool until they tune it differently
+	if same := client.With(WithConcurrency(50)); same.Config.HTTPClient != client.Config.HTTPClient {
+		t.Errorf("Expected a copy with the same pool settings to share the HTTP client")
+	}
+	tuned := client.With(WithMaxIdleConnsPerHost(100))
+	if tuned.Config.HTTPClient == client.Config.HTTPClient {
+		t.Fatalf("Expected a retuned copy to get its own HTTP client")
+	}
+	if got := tuned.Config.HTTPClient.Transport.(*http.Transport).MaxIdleConnsPerHost; got != 100 {
+		t.Errorf("Expected the copy's MaxIdleConnsPerHost to be 100, got %d", got)
+	}
+
+	// A custom HTTP client is never replaced
+	custom := &http.Client{}
+	withCustom := NewClient(WithHTTPClient(custom))
+	if withCustom.With(WithIdleConnTimeout(time.Second)).Config.HTTPClient != custom {
+		t.Errorf("Expected the custom HTTP client to be kept")
+	}
+}
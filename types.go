@@ -0,0 +1,216 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrWrongItemType is returned by the typed getters, such as GetStory, when the
+// item exists but is of a different type.
+var ErrWrongItemType = errors.New("item has a different type")
+
+// Story is a story item, including Ask HN and Show HN posts. The typed item structs
+// carry only the fields that apply to their type; each field has the same meaning
+// as the Item field of the same name.
+type Story struct {
+	ID          int
+	By          string
+	Time        int64
+	Title       string
+	URL         string
+	Text        string
+	Score       int
+	Descendants int
+	Kids        []int
+	Deleted     bool
+	Dead        bool
+}
+
+// Comment is a comment on a story, poll, or another comment.
+type Comment struct {
+	ID      int
+	By      string
+	Time    int64
+	Text    string
+	Parent  int
+	Kids    []int
+	Deleted bool
+	Dead    bool
+}
+
+// Job is a job posting.
+type Job struct {
+	ID      int
+	By      string
+	Time    int64
+	Title   string
+	URL     string
+	Text    string
+	Score   int
+	Deleted bool
+	Dead    bool
+}
+
+// Poll is a poll; its options are PollOpt items listed in Parts.
+type Poll struct {
+	ID          int
+	By          string
+	Time        int64
+	Title       string
+	Text        string
+	Score       int
+	Descendants int
+	Kids        []int
+	Parts       []int
+	Deleted     bool
+	Dead        bool
+}
+
+// PollOpt is an option of a poll.
+type PollOpt struct {
+	ID      int
+	By      string
+	Time    int64
+	Text    string
+	Poll    int
+	Score   int
+	Deleted bool
+	Dead    bool
+}
+
+// AsStory converts the item to a Story. It reports false if the item is not a story.
+func (i *Item) AsStory() (*Story, bool) {
+	if i == nil || i.Type != TypeStory {
+		return nil, false
+	}
+	return &Story{
+		ID:          i.ID,
+		By:          i.By,
+		Time:        i.Time,
+		Title:       i.Title,
+		URL:         i.URL,
+		Text:        i.Text,
+		Score:       i.Score,
+		Descendants: i.Descendants,
+		Kids:        i.Kids,
+		Deleted:     i.Deleted,
+		Dead:        i.Dead,
+	}, true
+}
+
+// AsComment converts the item to a Comment. It reports false if the item is not a comment.
+func (i *Item) AsComment() (*Comment, bool) {
+	if i == nil || i.Type != TypeComment {
+		return nil, false
+	}
+	return &Comment{
+		ID:      i.ID,
+		By:      i.By,
+		Time:    i.Time,
+		Text:    i.Text,
+		Parent:  i.Parent,
+		Kids:    i.Kids,
+		Deleted: i.Deleted,
+		Dead:    i.Dead,
+	}, true
+}
+
+// AsJob converts the item to a Job. It reports false if the item is not a job.
+func (i *Item) AsJob() (*Job, bool) {
+	if i == nil || i.Type != TypeJob {
+		return nil, false
+	}
+	return &Job{
+		ID:      i.ID,
+		By:      i.By,
+		Time:    i.Time,
+		Title:   i.Title,
+		URL:     i.URL,
+		Text:    i.Text,
+		Score:   i.Score,
+		Deleted: i.Deleted,
+		Dead:    i.Dead,
+	}, true
+}
+
+// AsPoll converts the item to a Poll. It reports false if the item is not a poll.
+func (i *Item) AsPoll() (*Poll, bool) {
+	if i == nil || i.Type != TypePoll {
+		return nil, false
+	}
+	return &Poll{
+		ID:          i.ID,
+		By:          i.By,
+		Time:        i.Time,
+		Title:       i.Title,
+		Text:        i.Text,
+		Score:       i.Score,
+		Descendants: i.Descendants,
+		Kids:        i.Kids,
+		Parts:       i.Parts,
+		Deleted:     i.Deleted,
+		Dead:        i.Dead,
+	}, true
+}
+
+// AsPollOpt converts the item to a PollOpt. It reports false if the item is not a poll option.
+func (i *Item) AsPollOpt() (*PollOpt, bool) {
+	if i == nil || i.Type != TypePollOpt {
+		return nil, false
+	}
+	return &PollOpt{
+		ID:      i.ID,
+		By:      i.By,
+		Time:    i.Time,
+		Text:    i.Text,
+		Poll:    i.Poll,
+		Score:   i.Score,
+		Deleted: i.Deleted,
+		Dead:    i.Dead,
+	}, true
+}
+
+// GetStory retrieves an item and returns it as a Story. It returns an error
+// wrapping ErrWrongItemType if the item is not a story.
+func (c *Client) GetStory(ctx context.Context, id int) (*Story, error) {
+	return getTyped(ctx, c, id, TypeStory, (*Item).AsStory)
+}
+
+// GetComment retrieves an item and returns it as a Comment. It returns an error
+// wrapping ErrWrongItemType if the item is not a comment.
+func (c *Client) GetComment(ctx context.Context, id int) (*Comment, error) {
+	return getTyped(ctx, c, id, TypeComment, (*Item).AsComment)
+}
+
+// GetJob retrieves an item and returns it as a Job. It returns an error wrapping
+// ErrWrongItemType if the item is not a job.
+func (c *Client) GetJob(ctx context.Context, id int) (*Job, error) {
+	return getTyped(ctx, c, id, TypeJob, (*Item).AsJob)
+}
+
+// GetPoll retrieves an item and returns it as a Poll. It returns an error wrapping
+// ErrWrongItemType if the item is not a poll.
+func (c *Client) GetPoll(ctx context.Context, id int) (*Poll, error) {
+	return getTyped(ctx, c, id, TypePoll, (*Item).AsPoll)
+}
+
+// GetPollOpt retrieves an item and returns it as a PollOpt. It returns an error
+// wrapping ErrWrongItemType if the item is not a poll option.
+func (c *Client) GetPollOpt(ctx context.Context, id int) (*PollOpt, error) {
+	return getTyped(ctx, c, id, TypePollOpt, (*Item).AsPollOpt)
+}
+
+// getTyped fetches an item and converts it with as.
+func getTyped[T any](ctx context.Context, c *Client, id int, typ ItemType, as func(*Item) (*T, bool)) (*T, error) {
+	item, err := c.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	typed, ok := as(item)
+	if !ok {
+		return nil, fmt.Errorf("item %d is a %q, not a %q: %w", id, item.Type, typ, ErrWrongItemType)
+	}
+	return typed, nil
+}
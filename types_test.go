@@ -0,0 +1,113 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestItemConversions(t *testing.T) {
+	tests := []struct {
+		name string
+		item *Item
+		want string
+	}{
+		{name: "story", item: &Item{ID: 1, Type: "story", Title: "Story", URL: "https://example.com", Kids: []int{2}}, want: "story"},
+		{name: "comment", item: &Item{ID: 2, Type: "comment", Parent: 1, Text: "Comment"}, want: "comment"},
+		{name: "job", item: &Item{ID: 3, Type: "job", Title: "Job"}, want: "job"},
+		{name: "poll", item: &Item{ID: 4, Type: "poll", Parts: []int{5}}, want: "poll"},
+		{name: "pollopt", item: &Item{ID: 5, Type: "pollopt", Poll: 4, Score: 7}, want: "pollopt"},
+		{name: "nil item", item: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			story, isStory := tt.item.AsStory()
+			comment, isComment := tt.item.AsComment()
+			job, isJob := tt.item.AsJob()
+			poll, isPoll := tt.item.AsPoll()
+			pollOpt, isPollOpt := tt.item.AsPollOpt()
+
+			got := map[string]bool{
+				"story":   isStory,
+				"comment": isComment,
+				"job":     isJob,
+				"poll":    isPoll,
+				"pollopt": isPollOpt,
+			}
+			for typ, ok := range got {
+				if ok != (typ == tt.want) {
+					t.Errorf("As%s() ok = %v for item type %q", typ, ok, tt.want)
+				}
+			}
+
+			switch tt.want {
+			case "story":
+				if story.Title != tt.item.Title || story.URL != tt.item.URL || len(story.Kids) != 1 {
+					t.Errorf("Story fields not copied: %+v", story)
+				}
+			case "comment":
+				if comment.Parent != tt.item.Parent || comment.Text != tt.item.Text {
+					t.Errorf("Comment fields not copied: %+v", comment)
+				}
+			case "job":
+				if job.Title != tt.item.Title {
+					t.Errorf("Job fields not copied: %+v", job)
+				}
+			case "poll":
+				if len(poll.Parts) != 1 || poll.Parts[0] != 5 {
+					t.Errorf("Poll fields not copied: %+v", poll)
+				}
+			case "pollopt":
+				if pollOpt.Poll != 4 || pollOpt.Score != 7 {
+					t.Errorf("PollOpt fields not copied: %+v", pollOpt)
+				}
+			}
+		})
+	}
+}
+
+func TestTypedGetters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item/1.json":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 1, "type": "story", "title": "Hello"}`))
+		case "/item/2.json":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": 2, "type": "comment", "parent": 1}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`null`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	ctx := context.Background()
+
+	story, err := client.GetStory(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetStory() error = %v", err)
+	}
+	if story.Title != "Hello" {
+		t.Errorf("Expected title Hello, got %q", story.Title)
+	}
+
+	comment, err := client.GetComment(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetComment() error = %v", err)
+	}
+	if comment.Parent != 1 {
+		t.Errorf("Expected parent 1, got %d", comment.Parent)
+	}
+
+	if _, err := client.GetJob(ctx, 1); !errors.Is(err, ErrWrongItemType) {
+		t.Errorf("Expected ErrWrongItemType, got %v", err)
+	}
+	if _, err := client.GetPoll(ctx, 3); err == nil || errors.Is(err, ErrWrongItemType) {
+		t.Errorf("Expected a fetch error for a missing item, got %v", err)
+	}
+}
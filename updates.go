@@ -2,74 +2,363 @@ package hnapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"time"
 )
 
+// OverflowPolicy determines how the updates poller behaves when the consumer
+// falls behind and the updates channel buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the poller until the consumer receives from the channel.
+	// No updates are lost, but polling pauses while the consumer is slow.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropOldest discards the oldest buffered update to make room for the new one.
+	OverflowDropOldest
+
+	// OverflowDropNewest discards the new update, keeping the buffered ones intact.
+	OverflowDropNewest
+)
+
+// String returns the name of the overflow policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
 // StartUpdates begins polling the updates endpoint and returns a channel of Updates.
 // It uses the client's PollInterval configuration to determine the polling frequency.
 // The polling will continue until the provided context is canceled.
 //
+// The channel capacity is set by the UpdatesBufferSize configuration, and the
+// UpdatesOverflowPolicy configuration decides whether a slow consumer blocks the
+// poller or causes updates to be dropped.
+//
 // The returned channel will be closed when the context is canceled or if an unrecoverable
 // error occurs.
 func (c *Client) StartUpdates(ctx context.Context) (<-chan Updates, error) {
-	// Create a buffered channel to send updates through
-	// We use a buffer of 1 to ensure that a slow consumer doesn't block the polling
-	updatesCh := make(chan Updates, 1)
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updatesCh := c.newUpdatesChannel()
+
+	// Start a goroutine for polling
+	c.goBackground(func() {
+		defer cancel()
+		c.runUpdates(ctx, updatesCh, nil)
+	})
+
+	return updatesCh, nil
+}
+
+// StartUpdatesWithErrors behaves like StartUpdates but also returns a channel of
+// polling errors, so applications can observe and react to persistent failures.
+//
+// Polling continues after an error. The error channel is buffered; if the consumer
+// does not keep up, errors are dropped rather than blocking the poller. Errors caused
+// by the context being canceled are not reported. Both channels are closed when
+// polling stops.
+func (c *Client) StartUpdatesWithErrors(ctx context.Context) (<-chan Updates, <-chan error, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	errCh := make(chan error, 1)
 
 	// Start a goroutine for polling
-	go func() {
-		defer close(updatesCh)
+	c.goBackground(func() {
+		defer cancel()
+		defer close(errCh)
+		c.runUpdates(ctx, updatesCh, errCh)
+	})
+
+	return updatesCh, errCh, nil
+}
+
+// newUpdatesChannel creates the updates channel sized by the UpdatesBufferSize configuration.
+func (c *Client) newUpdatesChannel() chan Updates {
+	bufferSize := c.Config.UpdatesBufferSize
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	return make(chan Updates, bufferSize)
+}
 
-		// Create a ticker with the configured poll interval
-		ticker := time.NewTicker(c.Config.PollInterval)
-		defer ticker.Stop()
+// runUpdates polls or streams the updates endpoint, depending on the UpdatesMode
+// configuration, until the context is canceled, then closes updatesCh.
+// Polling errors are logged through the configured logger, passed to the ErrorHandler and,
+// if errCh is not nil, delivered to it without blocking.
+func (c *Client) runUpdates(ctx context.Context, updatesCh chan Updates, errCh chan<- error) {
+	defer close(updatesCh)
 
-		// Poll immediately on start, then wait for ticker
-		if err := c.pollUpdates(ctx, updatesCh); err != nil {
-			// Log the error but continue polling
-			log.Printf("Error polling updates: %v", err)
+	dedup := newUpdatesDeduper(c.Config.UpdatesDedupWindow)
+
+	report := func(err error) {
+		// Log the error but continue polling
+		c.logger().Warn("failed to poll updates", "error", err)
+
+		// Errors caused by shutting down are not worth reporting
+		if ctx.Err() != nil {
+			return
 		}
 
-		// Main polling loop
-		for {
+		c.handleError(err)
+		if errCh != nil {
 			select {
-			case <-ctx.Done():
-				// Context was canceled, stop polling
-				return
-			case <-ticker.C:
-				// Time to poll again
-				if err := c.pollUpdates(ctx, updatesCh); err != nil {
-					// Log the error but continue polling
-					log.Printf("Error polling updates: %v", err)
-				}
+			case errCh <- err:
+			default:
+				// The consumer is not keeping up with errors, drop this one
 			}
 		}
-	}()
+	}
 
-	return updatesCh, nil
+	// Offline, polling reports ErrOffline instead of opening a stream
+	if c.Config.UpdatesMode == UpdatesModeStream && c.Config.Offline == nil {
+		c.runStreamingUpdates(ctx, updatesCh, dedup, report)
+		return
+	}
+
+	c.pollEvery(ctx, c.Config.PollInterval, func() {
+		if err := c.pollUpdates(ctx, updatesCh, dedup); err != nil {
+			report(err)
+		}
+	})
+}
+
+// pollEvery calls poll immediately and then once per interval until the context is canceled.
+func (c *Client) pollEvery(ctx context.Context, interval time.Duration, poll func()) {
+	// Create a ticker with the configured poll interval
+	ticker := c.clock().NewTicker(interval)
+	defer ticker.Stop()
+
+	// Poll immediately on start, then wait for ticker
+	poll()
+
+	// Main polling loop
+	for {
+		select {
+		case <-ctx.Done():
+			// Context was canceled, stop polling
+			return
+		case <-ticker.C():
+			// Time to poll again
+			poll()
+		}
+	}
 }
 
 // pollUpdates fetches the latest updates from the API and sends them to the updates channel.
-func (c *Client) pollUpdates(ctx context.Context, updatesCh chan<- Updates) error {
+// If dedup is not nil, IDs emitted recently are removed before sending.
+func (c *Client) pollUpdates(ctx context.Context, updatesCh chan Updates, dedup *updatesDeduper) error {
+	ctx, end := c.startOperation(ctx, Operation{Name: "PollUpdates", Endpoint: "updates.json"})
+
 	// Fetch updates from the API
 	var updates Updates
-	if err := c.makeRequest(ctx, "updates.json", &updates); err != nil {
+	err := c.makeRequest(ctx, "updates.json", &updates, callOptions{})
+	end(err)
+	if err != nil {
 		return fmt.Errorf("failed to get updates: %w", err)
 	}
 
+	// Drop IDs that were already emitted within the dedup window
+	updates = dedup.filter(updates, c.clock().Now())
+
 	// Only send updates if there are any
 	if len(updates.Items) > 0 || len(updates.Profiles) > 0 {
+		c.metrics.updatesReceived.Add(1)
+		return c.sendUpdates(ctx, updatesCh, updates)
+	}
+
+	return nil
+}
+
+// sendUpdates delivers updates to the channel according to the configured overflow
+// policy and records the outcome in the client's metrics.
+func (c *Client) sendUpdates(ctx context.Context, updatesCh chan Updates, updates Updates) error {
+	sent, err := offerUpdates(ctx, c.Config.UpdatesOverflowPolicy, updatesCh, updates, func(Updates) {
+		c.metrics.updatesDropped.Add(1)
+	})
+	if sent {
+		c.metrics.updatesEmitted.Add(1)
+	}
+	return err
+}
+
+// offerUpdates delivers updates to the channel according to policy. It reports
+// whether updates was sent, and calls dropped, if not nil, for every update the
+// policy discards: updates itself, or older updates evicted to make room for it.
+func offerUpdates(ctx context.Context, policy OverflowPolicy, updatesCh chan Updates, updates Updates, dropped func(Updates)) (bool, error) {
+	drop := func(u Updates) {
+		if dropped != nil {
+			dropped(u)
+		}
+	}
+
+	switch policy {
+	case OverflowDropNewest:
+		select {
+		case updatesCh <- updates:
+			return true, nil
+		default:
+			// The buffer is full, so the new update is discarded
+			drop(updates)
+			return false, nil
+		}
+
+	case OverflowDropOldest:
+		for {
+			select {
+			case updatesCh <- updates:
+				return true, nil
+			default:
+			}
+
+			// An unbuffered channel can never hold an update, so there is nothing to evict
+			if cap(updatesCh) == 0 {
+				drop(updates)
+				return false, nil
+			}
+
+			// The buffer is full, so evict the oldest update and try again
+			select {
+			case oldest := <-updatesCh:
+				drop(oldest)
+			case <-ctx.Done():
+				return false, ctx.Err()
+			default:
+			}
+		}
+
+	default:
 		// Try to send updates, but respect context cancellation
 		select {
 		case updatesCh <- updates:
-			// Successfully sent updates
+			return true, nil
 		case <-ctx.Done():
-			// Context was canceled
-			return ctx.Err()
+			return false, ctx.Err()
 		}
 	}
+}
 
-	return nil
+// Hydrate fetches the items and users referenced by the updates with
+// GetItemsBatch and GetUsersBatch, so the client's Concurrency configuration
+// applies. They changed, so the response cache is bypassed. Items and users that fail to load are left out; the failures of both
+// batches are joined into the error, which is returned with what did load.
+func (u Updates) Hydrate(ctx context.Context, client *Client) (items []*Item, users []*User, err error) {
+	items, itemsErr := client.GetItemsBatch(ctx, u.Items, WithNoCache())
+	if items == nil {
+		items = []*Item{}
+	}
+
+	users, usersErr := client.GetUsersBatch(ctx, u.Profiles, WithNoCache())
+	if users == nil {
+		users = []*User{}
+	}
+
+	return items, users, errors.Join(itemsErr, usersErr)
+}
+
+// StartItemUpdates begins polling the updates endpoint and returns a channel of
+// fully fetched items that changed. Changed item IDs are hydrated with
+// GetItemsBatch, so the client's Concurrency configuration applies.
+//
+// Items that fail to load are skipped; their errors are logged and passed to the
+// ErrorHandler. The returned channel is closed when the context is canceled.
+func (c *Client) StartItemUpdates(ctx context.Context) (<-chan *Item, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	itemsCh := make(chan *Item, cap(updatesCh))
+
+	c.goBackground(func() { c.runUpdates(ctx, updatesCh, nil) })
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(itemsCh)
+
+		for updates := range updatesCh {
+			if len(updates.Items) == 0 {
+				continue
+			}
+
+			items, err := c.GetItemsBatch(ctx, updates.Items, WithNoCache())
+			if err != nil && ctx.Err() == nil {
+				c.logger().Warn("failed to hydrate updated items", "error", err)
+				c.handleError(err)
+			}
+
+			for _, item := range items {
+				select {
+				case itemsCh <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return itemsCh, nil
+}
+
+// StartProfileUpdates begins polling the updates endpoint and returns a channel of
+// fully fetched users whose profiles changed. Changed usernames are hydrated with
+// GetUsersBatch, so the client's Concurrency configuration applies.
+//
+// Users that fail to load are skipped; their errors are logged and passed to the
+// ErrorHandler. The returned channel is closed when the context is canceled.
+func (c *Client) StartProfileUpdates(ctx context.Context) (<-chan *User, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	usersCh := make(chan *User, cap(updatesCh))
+
+	c.goBackground(func() { c.runUpdates(ctx, updatesCh, nil) })
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(usersCh)
+
+		for updates := range updatesCh {
+			if len(updates.Profiles) == 0 {
+				continue
+			}
+
+			users, err := c.GetUsersBatch(ctx, updates.Profiles, WithNoCache())
+			if err != nil && ctx.Err() == nil {
+				c.logger().Warn("failed to hydrate updated profiles", "error", err)
+				c.handleError(err)
+			}
+
+			for _, user := range users {
+				select {
+				case usersCh <- user:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return usersCh, nil
 }
@@ -437,6 +437,7 @@ func TestStartUpdatesTickerBased(t *testing.T) {
 	client := NewClient(
 		WithBaseURL(server.URL+"/"),
 		WithPollInterval(50*time.Millisecond), // Very short for testing
+		WithMaxRetries(0),
 	)
 
 	// Create a context with a timeout
@@ -511,6 +512,7 @@ func TestStartUpdatesInitialPollError(t *testing.T) {
 	client := NewClient(
 		WithBaseURL(server.URL+"/"),
 		WithPollInterval(50*time.Millisecond), // Very short for testing
+		WithMaxRetries(0),
 	)
 
 	// Create a context with a timeout
@@ -641,7 +643,7 @@ func TestPollUpdatesDirectContextCancellation(t *testing.T) {
 	// Call the pollUpdates method directly
 	// Since we're not reading from the channel, the send will block
 	// and then be interrupted by the context cancellation
-	err := client.pollUpdates(ctx, updatesCh)
+	err := client.pollUpdates(ctx, updatesCh, nil)
 
 	// We should get a context canceled error
 	if err == nil || err.Error() != "context canceled" {
@@ -683,7 +685,7 @@ func TestDirectCtxDoneInPollUpdates(t *testing.T) {
 	// Call pollUpdates directly
 	// Since we're trying to send to a channel that no one is reading from,
 	// the send will block, and then the context cancellation should interrupt it
-	err := client.pollUpdates(ctx, unbufferedCh)
+	err := client.pollUpdates(ctx, unbufferedCh, nil)
 
 	// We should get a context.Canceled error when the context is canceled
 	// during the channel send
@@ -751,3 +753,301 @@ func simulatePollUpdatesWithPreloadedData(ctx context.Context, updatesCh chan<-
 	}
 	return nil
 }
+
+func TestSendUpdatesOverflowPolicies(t *testing.T) {
+	first := Updates{Items: []int{1}}
+	second := Updates{Items: []int{2}}
+
+	tests := []struct {
+		name        string
+		policy      OverflowPolicy
+		wantItem    int
+		wantEmitted int64
+	}{
+		{name: "drop newest keeps buffered update", policy: OverflowDropNewest, wantItem: 1, wantEmitted: 1},
+		{name: "drop oldest keeps new update", policy: OverflowDropOldest, wantItem: 2, wantEmitted: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(WithUpdatesOverflowPolicy(tt.policy))
+			updatesCh := make(chan Updates, 1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			if err := client.sendUpdates(ctx, updatesCh, first); err != nil {
+				t.Fatalf("sendUpdates() first error = %v", err)
+			}
+
+			// The buffer is now full; the second send must not block
+			if err := client.sendUpdates(ctx, updatesCh, second); err != nil {
+				t.Fatalf("sendUpdates() second error = %v", err)
+			}
+
+			if len(updatesCh) != 1 {
+				t.Fatalf("Expected 1 buffered update, got %d", len(updatesCh))
+			}
+
+			got := <-updatesCh
+			if got.Items[0] != tt.wantItem {
+				t.Errorf("Expected buffered item %d, got %d", tt.wantItem, got.Items[0])
+			}
+
+			stats := client.Stats()
+			if stats.UpdatesEmitted != tt.wantEmitted || stats.UpdatesDropped != 1 {
+				t.Errorf("Expected %d emitted and 1 dropped, got %d and %d", tt.wantEmitted, stats.UpdatesEmitted, stats.UpdatesDropped)
+			}
+		})
+	}
+}
+
+func TestStartUpdatesBufferSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"items": [123], "profiles": []}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(10*time.Millisecond),
+		WithUpdatesBufferSize(4),
+		WithUpdatesOverflowPolicy(OverflowDropNewest),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updatesCh, err := client.StartUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdates() error = %v", err)
+	}
+
+	if cap(updatesCh) != 4 {
+		t.Errorf("Expected channel capacity 4, got %d", cap(updatesCh))
+	}
+
+	// Without a consumer the buffer fills up and further updates are dropped
+	time.Sleep(100 * time.Millisecond)
+	if len(updatesCh) != 4 {
+		t.Errorf("Expected buffer to be full with 4 updates, got %d", len(updatesCh))
+	}
+
+	cancel()
+	for range updatesCh {
+		// Drain until the poller closes the channel
+	}
+}
+
+func TestStartUpdatesWithErrors(t *testing.T) {
+	var requestCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request, then succeed
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"items": [123], "profiles": []}`))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(20*time.Millisecond),
+		WithMaxRetries(0),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	updatesCh, errCh, err := client.StartUpdatesWithErrors(ctx)
+	if err != nil {
+		t.Fatalf("StartUpdatesWithErrors() error = %v", err)
+	}
+
+	select {
+	case err := <-errCh:
+		if err == nil || !strings.Contains(err.Error(), "failed to get updates") {
+			t.Errorf("Expected polling error, got %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for polling error")
+	}
+
+	select {
+	case updates := <-updatesCh:
+		if len(updates.Items) != 1 || updates.Items[0] != 123 {
+			t.Errorf("Expected updates with item 123, got %+v", updates)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for updates after error")
+	}
+
+	cancel()
+
+	// Both channels must be closed once polling stops
+	for range updatesCh {
+	}
+	for range errCh {
+	}
+}
+
+func TestStartItemUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "updates.json"):
+			resp = `{"items": [1, 2], "profiles": ["user1"]}`
+		case strings.HasSuffix(r.URL.Path, "item/1.json"):
+			resp = `{"id": 1, "type": "story", "title": "First"}`
+		case strings.HasSuffix(r.URL.Path, "item/2.json"):
+			resp = `{"id": 2, "type": "comment", "text": "Second"}`
+		default:
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(time.Hour),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	itemsCh, err := client.StartItemUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartItemUpdates() error = %v", err)
+	}
+
+	seen := make(map[int]bool)
+	for len(seen) < 2 {
+		select {
+		case item, ok := <-itemsCh:
+			if !ok {
+				t.Fatal("Items channel closed before all items were received")
+			}
+			seen[item.ID] = true
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for hydrated items, got %v", seen)
+		}
+	}
+
+	if !seen[1] || !seen[2] {
+		t.Errorf("Expected items 1 and 2, got %v", seen)
+	}
+
+	cancel()
+	for range itemsCh {
+	}
+}
+
+func TestStartProfileUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "updates.json"):
+			resp = `{"items": [1], "profiles": ["alice"]}`
+		case strings.HasSuffix(r.URL.Path, "user/alice.json"):
+			resp = `{"id": "alice", "karma": 42}`
+		default:
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(time.Hour),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	usersCh, err := client.StartProfileUpdates(ctx)
+	if err != nil {
+		t.Fatalf("StartProfileUpdates() error = %v", err)
+	}
+
+	select {
+	case user := <-usersCh:
+		if user == nil || user.ID != "alice" || user.Karma != 42 {
+			t.Errorf("Expected hydrated user alice, got %+v", user)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for hydrated user")
+	}
+
+	cancel()
+	for range usersCh {
+	}
+}
+
+func TestUpdatesHydrate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/item/1.json":
+			_, _ = w.Write([]byte(`{"id": 1, "type": "story"}`))
+		case "/item/2.json":
+			_, _ = w.Write([]byte(`{"id": 2, "type": "comment"}`))
+		case "/user/pg.json":
+			_, _ = w.Write([]byte(`{"id": "pg", "karma": 155111}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	ctx := context.Background()
+
+	items, users, err := Updates{Items: []int{1, 2}, Profiles: []string{"pg"}}.Hydrate(ctx, client)
+	if err != nil {
+		t.Fatalf("Hydrate() error = %v", err)
+	}
+	if len(items) != 2 || items[0].ID != 1 || items[1].ID != 2 {
+		t.Errorf("Expected items 1 and 2, got %v", items)
+	}
+	if len(users) != 1 || users[0].ID != "pg" {
+		t.Errorf("Expected user pg, got %v", users)
+	}
+
+	// Failures of both batches are reported together with what loaded
+	items, users, err = Updates{Items: []int{1, 3}, Profiles: []string{"nobody"}}.Hydrate(ctx, client)
+	if err == nil || !strings.Contains(err.Error(), "item 3") || !strings.Contains(err.Error(), "user nobody") {
+		t.Errorf("Expected the failures of item 3 and user nobody, got %v", err)
+	}
+	if len(items) != 1 || len(users) != 0 || users == nil {
+		t.Errorf("Expected 1 item and no users, got %v and %v", items, users)
+	}
+
+	// Empty updates need no requests
+	items, users, err = Updates{}.Hydrate(ctx, NewClient(WithBaseURL("http://127.0.0.1:0/")))
+	if err != nil || len(items) != 0 || len(users) != 0 {
+		t.Errorf("Expected nothing to hydrate, got %v, %v, %v", items, users, err)
+	}
+}
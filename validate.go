@@ -0,0 +1,148 @@
+package hnapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// configProblem is an invalid configuration value and how to repair it.
+type configProblem struct {
+	err error
+
+	// reset restores a usable value, or is nil if there is none
+	reset func(c *Config)
+}
+
+// Validate reports every invalid value in the configuration, joined into one error.
+// A BaseURL without a trailing slash is not an error; NewClient and NewClientE add
+// the slash.
+func (c *Config) Validate() error {
+	var errs []error
+	for _, p := range c.problems() {
+		errs = append(errs, p.err)
+	}
+	return errors.Join(errs...)
+}
+
+// problems checks the configuration against the defaults it could be reset to.
+func (c *Config) problems() []configProblem {
+	defaults := DefaultConfig()
+	var problems []configProblem
+
+	check := func(invalid bool, reset func(c *Config), format string, args ...interface{}) {
+		if invalid {
+			problems = append(problems, configProblem{err: fmt.Errorf(format, args...), reset: reset})
+		}
+	}
+
+	for _, base := range append([]string{c.BaseURL}, c.FallbackURLs...) {
+		if u, err := url.Parse(base); err != nil {
+			check(true, nil, "invalid base URL %q: %w", base, err)
+		} else {
+			check(!u.IsAbs() || u.Host == "", nil, "invalid base URL %q: must be an absolute URL", base)
+		}
+	}
+
+	check(c.RequestTimeout < 0, func(c *Config) { c.RequestTimeout = defaults.RequestTimeout },
+		"invalid RequestTimeout %v: must not be negative", c.RequestTimeout)
+	check(c.MaxResponseSize < 0, func(c *Config) { c.MaxResponseSize = defaults.MaxResponseSize },
+		"invalid MaxResponseSize %d: must not be negative", c.MaxResponseSize)
+	check(c.MaxRetries < 0, func(c *Config) { c.MaxRetries = 0 },
+		"invalid MaxRetries %d: must not be negative", c.MaxRetries)
+	check(c.RetryBudget.Window < 0 || c.RetryBudget.Ratio < 0 || c.RetryBudget.MinRetries < 0,
+		func(c *Config) { c.RetryBudget = RetryBudget{} },
+		"invalid RetryBudget %+v: must not be negative", c.RetryBudget)
+	for _, class := range []struct {
+		name   string
+		policy func(c *Config) *EndpointCache
+	}{
+		{"Items", func(c *Config) *EndpointCache { return &c.CachePolicy.Items }},
+		{"Users", func(c *Config) *EndpointCache { return &c.CachePolicy.Users }},
+		{"Lists", func(c *Config) *EndpointCache { return &c.CachePolicy.Lists }},
+		{"Updates", func(c *Config) *EndpointCache { return &c.CachePolicy.Updates }},
+	} {
+		policy := class.policy(c)
+		check(policy.Enabled && policy.TTL <= 0, func(c *Config) { *class.policy(c) = EndpointCache{} },
+			"invalid CachePolicy.%s TTL %v: must be positive when enabled", class.name, policy.TTL)
+	}
+	check(c.CachePolicy.MaxEntries < 0, func(c *Config) { c.CachePolicy.MaxEntries = 0 },
+		"invalid CachePolicy.MaxEntries %d: must not be negative", c.CachePolicy.MaxEntries)
+	check(c.BackoffInterval < 0, func(c *Config) { c.BackoffInterval = defaults.BackoffInterval },
+		"invalid BackoffInterval %v: must not be negative", c.BackoffInterval)
+	check(c.PollInterval <= 0, func(c *Config) { c.PollInterval = defaults.PollInterval },
+		"invalid PollInterval %v: must be positive", c.PollInterval)
+	check(c.Concurrency < 1, func(c *Config) { c.Concurrency = defaults.Concurrency },
+		"invalid Concurrency %d: must be at least 1", c.Concurrency)
+	check(c.MaxInFlight < 0, func(c *Config) { c.MaxInFlight = 0 },
+		"invalid MaxInFlight %d: must not be negative", c.MaxInFlight)
+	check(c.MaxIdleConnsPerHost < 0, func(c *Config) { c.MaxIdleConnsPerHost = 0 },
+		"invalid MaxIdleConnsPerHost %d: must not be negative", c.MaxIdleConnsPerHost)
+	check(c.IdleConnTimeout < 0, func(c *Config) { c.IdleConnTimeout = 0 },
+		"invalid IdleConnTimeout %v: must not be negative", c.IdleConnTimeout)
+	check(c.UpdatesMode != UpdatesModePoll && c.UpdatesMode != UpdatesModeStream,
+		func(c *Config) { c.UpdatesMode = defaults.UpdatesMode },
+		"invalid UpdatesMode %v", c.UpdatesMode)
+	check(c.UpdatesBufferSize < 0, func(c *Config) { c.UpdatesBufferSize = defaults.UpdatesBufferSize },
+		"invalid UpdatesBufferSize %d: must not be negative", c.UpdatesBufferSize)
+	check(c.UpdatesOverflowPolicy < OverflowBlock || c.UpdatesOverflowPolicy > OverflowDropNewest,
+		func(c *Config) { c.UpdatesOverflowPolicy = defaults.UpdatesOverflowPolicy },
+		"invalid UpdatesOverflowPolicy %v", c.UpdatesOverflowPolicy)
+	check(c.UpdatesDedupWindow < 0, func(c *Config) { c.UpdatesDedupWindow = 0 },
+		"invalid UpdatesDedupWindow %v: must not be negative", c.UpdatesDedupWindow)
+
+	return problems
+}
+
+// normalizeBaseURL adds the trailing slash that endpoint paths are appended to.
+func (c *Config) normalizeBaseURL() {
+	c.BaseURL = withTrailingSlash(c.BaseURL)
+	for i, base := range c.FallbackURLs {
+		c.FallbackURLs[i] = withTrailingSlash(base)
+	}
+}
+
+// withTrailingSlash adds a trailing slash to a non-empty URL that lacks one.
+func withTrailingSlash(base string) string {
+	if base != "" && !strings.HasSuffix(base, "/") {
+		return base + "/"
+	}
+	return base
+}
+
+// repair normalizes the BaseURL and replaces invalid values with their defaults,
+// logging a warning for each.
+func (c *Config) repair() {
+	c.normalizeBaseURL()
+
+	logger := c.Logger
+	if logger == nil {
+		logger = newDiscardLogger()
+	}
+
+	for _, p := range c.problems() {
+		if p.reset == nil {
+			logger.Warn("invalid client option", "error", p.err)
+			continue
+		}
+		p.reset(c)
+		logger.Warn("invalid client option replaced with default", "error", p.err)
+	}
+}
+
+// NewClientE creates a new Hacker News API client like NewClient, but returns an
+// error describing every invalid option instead of repairing the configuration.
+func NewClientE(opts ...Option) (*Client, error) {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+	config.normalizeBaseURL()
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid client configuration: %w", err)
+	}
+
+	return newClient(config), nil
+}
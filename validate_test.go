@@ -0,0 +1,89 @@
+package hnapi
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr []string
+	}{
+		{name: "defaults are valid"},
+		{name: "base URL without trailing slash is normalized", opts: []Option{WithBaseURL("https://example.com/v0")}},
+		{name: "negative concurrency", opts: []Option{WithConcurrency(-1)}, wantErr: []string{"Concurrency"}},
+		{name: "zero poll interval", opts: []Option{WithPollInterval(0)}, wantErr: []string{"PollInterval"}},
+		{name: "relative base URL", opts: []Option{WithBaseURL("v0/")}, wantErr: []string{"base URL"}},
+		{
+			name:    "every problem is reported",
+			opts:    []Option{WithConcurrency(0), WithMaxRetries(-1), WithUpdatesOverflowPolicy(OverflowPolicy(9))},
+			wantErr: []string{"Concurrency", "MaxRetries", "UpdatesOverflowPolicy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientE(tt.opts...)
+
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("NewClientE() error = %v", err)
+				}
+				if !strings.HasSuffix(client.Config.BaseURL, "/") {
+					t.Errorf("Expected BaseURL with trailing slash, got %q", client.Config.BaseURL)
+				}
+				return
+			}
+
+			if err == nil || client != nil {
+				t.Fatalf("Expected an error and no client, got %v, %v", client, err)
+			}
+			for _, field := range tt.wantErr {
+				if !strings.Contains(err.Error(), field) {
+					t.Errorf("Expected error to mention %s, got %v", field, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNewClientRepairsConfig(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	client := NewClient(
+		WithLogger(logger),
+		WithBaseURL("https://example.com/v0"),
+		WithConcurrency(-5),
+		WithPollInterval(0),
+		WithBackoffInterval(time.Second),
+	)
+
+	defaults := DefaultConfig()
+	if client.Config.Concurrency != defaults.Concurrency {
+		t.Errorf("Expected Concurrency reset to %d, got %d", defaults.Concurrency, client.Config.Concurrency)
+	}
+	if client.Config.PollInterval != defaults.PollInterval {
+		t.Errorf("Expected PollInterval reset to %v, got %v", defaults.PollInterval, client.Config.PollInterval)
+	}
+	if client.Config.BackoffInterval != time.Second {
+		t.Errorf("Expected valid BackoffInterval to be kept, got %v", client.Config.BackoffInterval)
+	}
+	if client.Config.BaseURL != "https://example.com/v0/" {
+		t.Errorf("Expected trailing slash to be added, got %q", client.Config.BaseURL)
+	}
+
+	if got := strings.Count(buf.String(), "invalid client option"); got != 2 {
+		t.Errorf("Expected 2 warnings, got %d: %s", got, buf.String())
+	}
+
+	// Derived clients are repaired the same way
+	if derived := client.With(WithConcurrency(0)); derived.Config.Concurrency != defaults.Concurrency {
+		t.Errorf("Expected derived Concurrency reset to %d, got %d", defaults.Concurrency, derived.Config.Concurrency)
+	}
+}
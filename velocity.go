@@ -0,0 +1,232 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultVelocityWindows are the sliding windows WatchCommentVelocity reports when
+// none are given.
+var DefaultVelocityWindows = []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour}
+
+// VelocityWindow describes the comment activity of a thread within one sliding window.
+type VelocityWindow struct {
+	// Window is the length of the window, ending at the snapshot time.
+	Window time.Duration
+
+	// Comments is the number of comments created within the window.
+	Comments int
+
+	// PerMinute is Comments divided by the window length in minutes.
+	PerMinute float64
+
+	// NewCommenters is the number of users whose first comment in the thread was
+	// created within the window.
+	NewCommenters int
+}
+
+// VelocitySnapshot is the comment activity of a thread at one point in time.
+type VelocitySnapshot struct {
+	// StoryID is the watched story.
+	StoryID int
+
+	// Time is when the snapshot was taken.
+	Time time.Time
+
+	// Comments is the number of live comments in the thread.
+	Comments int
+
+	// Commenters is the number of distinct users who commented.
+	Commenters int
+
+	// Windows holds the activity within each requested window, in the order given.
+	Windows []VelocityWindow
+}
+
+// WatchCommentVelocity loads the comment thread of a story and returns a channel of
+// activity snapshots: comments per minute and new commenters within each of the
+// given sliding windows, or DefaultVelocityWindows if none are given.
+//
+// The first snapshot is sent once the thread is loaded. After that, the updates feed
+// is polled and a snapshot is sent after every poll, since activity within the
+// windows changes as time passes even when no comments arrive. Only items of the
+// thread that the feed reports as changed are re-fetched, together with any new
+// replies they gained.
+//
+// Windows must be positive. The story is fetched synchronously and its error is
+// returned. Later fetch errors are logged and passed to the ErrorHandler. The
+// returned channel is closed when the context is canceled.
+func (c *Client) WatchCommentVelocity(ctx context.Context, storyID int, windows ...time.Duration) (<-chan VelocitySnapshot, error) {
+	for _, window := range windows {
+		if window <= 0 {
+			return nil, fmt.Errorf("invalid velocity window %v: must be positive", window)
+		}
+	}
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	story, err := c.GetItem(ctx, storyID)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to watch comment velocity of item %d: %w", storyID, err)
+	}
+
+	if len(windows) == 0 {
+		windows = DefaultVelocityWindows
+	}
+	thread := newVelocityThread(storyID, windows)
+
+	updatesCh := c.newUpdatesChannel()
+	snapshotsCh := make(chan VelocitySnapshot, 1)
+
+	c.goBackground(func() { c.runUpdates(ctx, updatesCh, nil) })
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(snapshotsCh)
+
+		send := func() bool {
+			select {
+			case snapshotsCh <- thread.snapshot(c.clock().Now()):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		c.expandThread(ctx, thread, thread.add(story))
+		if !send() {
+			return
+		}
+
+		for updates := range updatesCh {
+			if changed := thread.known(updates.Items); len(changed) > 0 {
+				c.expandThread(ctx, thread, changed)
+			}
+			if !send() {
+				return
+			}
+		}
+	})
+
+	return snapshotsCh, nil
+}
+
+// expandThread fetches ids, adds them to the thread, and keeps fetching replies the
+// thread does not know yet, one level per batch.
+func (c *Client) expandThread(ctx context.Context, thread *velocityThread, ids []int) {
+	for len(ids) > 0 && ctx.Err() == nil {
+		var next []int
+		for _, result := range c.fetchItems(ctx, ids, WithNoCache()) {
+			if result.Error != nil {
+				c.reportWatchError(ctx, fmt.Errorf("failed to get item %d: %w", result.ID, result.Error))
+				continue
+			}
+			next = append(next, thread.add(result.Item)...)
+		}
+		ids = next
+	}
+}
+
+// velocityThread holds the comments of a watched thread. It is used by a single
+// goroutine and is not safe for concurrent use.
+type velocityThread struct {
+	storyID  int
+	windows  []time.Duration
+	comments map[int]*Item
+
+	// fetched holds the IDs of every item that has been loaded, including the story
+	fetched map[int]struct{}
+}
+
+// newVelocityThread creates an empty thread for the story.
+func newVelocityThread(storyID int, windows []time.Duration) *velocityThread {
+	return &velocityThread{
+		storyID:  storyID,
+		windows:  windows,
+		comments: make(map[int]*Item),
+		fetched:  make(map[int]struct{}),
+	}
+}
+
+// add records a fetched item and returns the IDs of its replies that have not been
+// fetched yet.
+func (t *velocityThread) add(item *Item) []int {
+	t.fetched[item.ID] = struct{}{}
+	if item.ID != t.storyID {
+		t.comments[item.ID] = item
+	}
+
+	var unknown []int
+	for _, kid := range item.Kids {
+		if _, ok := t.fetched[kid]; !ok {
+			unknown = append(unknown, kid)
+		}
+	}
+	return unknown
+}
+
+// known returns the IDs that belong to the thread.
+func (t *velocityThread) known(ids []int) []int {
+	var known []int
+	for _, id := range ids {
+		if _, ok := t.fetched[id]; ok {
+			known = append(known, id)
+		}
+	}
+	return known
+}
+
+// snapshot computes the thread's activity as of now.
+func (t *velocityThread) snapshot(now time.Time) VelocitySnapshot {
+	// firstComment maps each commenter to the creation time of their first comment
+	firstComment := make(map[string]time.Time)
+	var times []time.Time
+
+	for _, comment := range t.comments {
+		if !comment.IsLive() {
+			continue
+		}
+		created := comment.CreatedAt()
+		times = append(times, created)
+
+		if first, ok := firstComment[comment.By]; !ok || created.Before(first) {
+			firstComment[comment.By] = created
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	snapshot := VelocitySnapshot{
+		StoryID:    t.storyID,
+		Time:       now,
+		Comments:   len(times),
+		Commenters: len(firstComment),
+		Windows:    make([]VelocityWindow, len(t.windows)),
+	}
+
+	for i, window := range t.windows {
+		since := now.Add(-window)
+		comments := len(times) - sort.Search(len(times), func(j int) bool { return !times[j].Before(since) })
+
+		newCommenters := 0
+		for _, first := range firstComment {
+			if !first.Before(since) {
+				newCommenters++
+			}
+		}
+
+		snapshot.Windows[i] = VelocityWindow{
+			Window:        window,
+			Comments:      comments,
+			PerMinute:     float64(comments) / window.Minutes(),
+			NewCommenters: newCommenters,
+		}
+	}
+
+	return snapshot
+}
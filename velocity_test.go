@@ -0,0 +1,120 @@
+package hnapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVelocityThreadSnapshot(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ago := func(d time.Duration) int64 { return now.Add(-d).Unix() }
+
+	thread := newVelocityThread(1, []time.Duration{5 * time.Minute, time.Hour})
+	thread.add(&Item{ID: 1, Type: TypeStory, Kids: []int{2, 3}})
+	thread.add(&Item{ID: 2, By: "alice", Time: ago(50 * time.Minute), Kids: []int{4}})
+	thread.add(&Item{ID: 3, By: "bob", Time: ago(2 * time.Minute)})
+	thread.add(&Item{ID: 4, By: "alice", Time: ago(time.Minute)})
+	thread.add(&Item{ID: 5, Deleted: true, Time: ago(time.Minute)})
+	thread.add(&Item{ID: 6, By: "carol", Time: ago(2 * time.Hour)})
+
+	got := thread.snapshot(now)
+	if got.StoryID != 1 || got.Comments != 4 || got.Commenters != 3 {
+		t.Errorf("Unexpected totals %+v", got)
+	}
+
+	want := []VelocityWindow{
+		{Window: 5 * time.Minute, Comments: 2, PerMinute: 0.4, NewCommenters: 1},
+		{Window: time.Hour, Comments: 3, PerMinute: 0.05, NewCommenters: 2},
+	}
+	for i, w := range want {
+		if got.Windows[i] != w {
+			t.Errorf("Window %d = %+v, want %+v", i, got.Windows[i], w)
+		}
+	}
+
+	if unknown := thread.add(&Item{ID: 3, By: "bob", Kids: []int{4, 7}}); len(unknown) != 1 || unknown[0] != 7 {
+		t.Errorf("Expected only the new reply to be unknown, got %v", unknown)
+	}
+	if known := thread.known([]int{1, 7, 99, 3}); len(known) != 2 || known[0] != 1 || known[1] != 3 {
+		t.Errorf("Unexpected known IDs %v", known)
+	}
+}
+
+func TestWatchCommentVelocity(t *testing.T) {
+	var replied int32
+	created := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.URL.Path {
+		case "/item/1.json":
+			if atomic.LoadInt32(&replied) == 1 {
+				resp = `{"id": 1, "type": "story", "kids": [2, 3]}`
+			} else {
+				resp = `{"id": 1, "type": "story", "kids": [2]}`
+			}
+		case "/item/2.json":
+			resp = `{"id": 2, "type": "comment", "by": "alice", "parent": 1, "time": ` + created + `}`
+		case "/item/3.json":
+			resp = `{"id": 3, "type": "comment", "by": "bob", "parent": 1, "time": ` + created + `}`
+		case "/updates.json":
+			if atomic.LoadInt32(&replied) == 1 {
+				resp = `{"items": [1, 99], "profiles": []}`
+			} else {
+				resp = `{"items": [], "profiles": []}`
+			}
+		default:
+			resp = "null"
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithPollInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	snapshots, err := client.WatchCommentVelocity(ctx, 1, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("WatchCommentVelocity() error = %v", err)
+	}
+
+	first := <-snapshots
+	if first.Comments != 1 || first.Windows[0].Comments != 1 || first.Windows[0].NewCommenters != 1 {
+		t.Errorf("Unexpected first snapshot %+v", first)
+	}
+
+	atomic.StoreInt32(&replied, 1)
+	for snapshot := range snapshots {
+		if snapshot.Comments == 2 {
+			if snapshot.Commenters != 2 || snapshot.Windows[0].NewCommenters != 2 {
+				t.Errorf("Unexpected snapshot %+v", snapshot)
+			}
+			cancel()
+		}
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		t.Fatalf("Timed out waiting for the new reply")
+	}
+
+	if _, err := client.WatchCommentVelocity(context.Background(), 99); err == nil {
+		t.Errorf("Expected an error for a missing story")
+	}
+}
+
+func TestWatchCommentVelocityInvalidWindow(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:0/"))
+	defer client.Close()
+
+	for _, window := range []time.Duration{0, -time.Minute} {
+		if _, err := client.WatchCommentVelocity(context.Background(), 1, time.Minute, window); err == nil {
+			t.Errorf("Expected an error for window %v", window)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrViewStarted is returned by ListView.Start when the view was already started.
+var ErrViewStarted = errors.New("list view already started")
+
+// ListView keeps a hydrated snapshot of the first stories of a list, such as the
+// top stories, fresh by refreshing it in the background. Get never waits on the
+// network, which makes a ListView a good fit for web handlers serving a front page.
+// It is safe for concurrent use.
+type ListView struct {
+	client   *Client
+	list     List
+	n        int
+	interval time.Duration
+
+	// startMu guards started, so a view runs at most one refresh loop
+	startMu sync.Mutex
+	started bool
+
+	// refreshMu serializes refreshes, so a slow one cannot overwrite a newer one
+	refreshMu sync.Mutex
+	snapshot  atomic.Pointer[listSnapshot]
+}
+
+// listSnapshot is the state of a ListView after a refresh.
+type listSnapshot struct {
+	items   []*Item
+	updated time.Time
+}
+
+// NewListView creates a view of the first n stories of list, or all of them if n is
+// 0 or less, refreshed every interval once started. An interval of 0 uses the
+// client's PollInterval.
+func (c *Client) NewListView(list List, n int, interval time.Duration) *ListView {
+	if interval <= 0 {
+		interval = c.Config.PollInterval
+	}
+	return &ListView{client: c, list: list, n: n, interval: interval}
+}
+
+// Start refreshes the view immediately and then every interval, in the background,
+// until the context is canceled or the client is closed. Errors are logged and
+// passed to the ErrorHandler; the view keeps its last snapshot until a refresh
+// succeeds. A view can be started once; later calls return ErrViewStarted.
+func (v *ListView) Start(ctx context.Context) error {
+	c := v.client
+
+	v.startMu.Lock()
+	defer v.startMu.Unlock()
+
+	if v.started {
+		return ErrViewStarted
+	}
+
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return err
+	}
+	v.started = true
+
+	c.goBackground(func() {
+		defer cancel()
+		c.pollEvery(ctx, v.interval, func() {
+			if err := v.Refresh(ctx); err != nil && ctx.Err() == nil {
+				c.logger().Warn("failed to refresh list view", "list", v.list, "error", err)
+				c.handleError(err)
+			}
+		})
+	})
+
+	return nil
+}
+
+// Get returns the stories of the latest snapshot in list order, or nil before the
+// first refresh. Deleted and dead stories are left out. The items are shared
+// between callers and must not be modified.
+func (v *ListView) Get() []*Item {
+	snapshot := v.snapshot.Load()
+	if snapshot == nil {
+		return nil
+	}
+	return append([]*Item(nil), snapshot.items...)
+}
+
+// Updated returns when the latest snapshot was taken, or the zero time before the
+// first refresh.
+func (v *ListView) Updated() time.Time {
+	snapshot := v.snapshot.Load()
+	if snapshot == nil {
+		return time.Time{}
+	}
+	return snapshot.updated
+}
+
+// Refresh fetches the list and its stories once and replaces the snapshot. If the
+// list fails to load, the snapshot is kept and the error returned. Stories that fail
+// to load keep their previous version, if they had one, and the first failure is
+// returned with the new snapshot in place.
+func (v *ListView) Refresh(ctx context.Context) error {
+	v.refreshMu.Lock()
+	defer v.refreshMu.Unlock()
+
+	c := v.client
+	ctx, end := c.startOperation(ctx, Operation{Name: "RefreshListView"})
+	err := v.refresh(ctx)
+	end(err)
+
+	return err
+}
+
+// refresh implements Refresh.
+func (v *ListView) refresh(ctx context.Context) error {
+	ids, err := v.client.GetList(ctx, v.list)
+	if err != nil {
+		return err
+	}
+	if v.n > 0 && len(ids) > v.n {
+		ids = ids[:v.n]
+	}
+
+	// Stories that fail to load fall back to their previous version
+	previous := make(map[int]*Item)
+	if snapshot := v.snapshot.Load(); snapshot != nil {
+		for _, item := range snapshot.items {
+			previous[item.ID] = item
+		}
+	}
+
+	items := make([]*Item, 0, len(ids))
+	var firstErr error
+	for _, result := range v.client.GetItemsBatchResults(ctx, ids, SkipDeadAndDeleted(), WithNoCache()) {
+		switch {
+		case result.Err == nil:
+			items = append(items, result.Item)
+		case previous[result.ID] != nil:
+			items = append(items, previous[result.ID])
+		}
+		if result.Err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to get item %d: %w", result.ID, result.Err)
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	v.snapshot.Store(&listSnapshot{items: items, updated: v.client.clock().Now()})
+	return firstErr
+}
@@ -0,0 +1,153 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListView(t *testing.T) {
+	var mu sync.Mutex
+	list := `[1, 2, 3, 4]`
+	failing := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/topstories.json" {
+			_, _ = w.Write([]byte(list))
+			return
+		}
+
+		var id int
+		_, _ = fmt.Sscanf(r.URL.Path, "/item/%d.json", &id)
+		switch id {
+		case failing:
+			w.WriteHeader(http.StatusForbidden)
+		case 3:
+			_, _ = w.Write([]byte(`{"id": 3, "type": "story", "dead": true}`))
+		default:
+			_, _ = fmt.Fprintf(w, `{"id": %d, "type": "story", "title": "Story %d"}`, id, id)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithMaxRetries(0))
+	view := client.NewListView(ListTop, 3, time.Hour)
+	ctx := context.Background()
+
+	ids := func() string {
+		var ids []int
+		for _, item := range view.Get() {
+			ids = append(ids, item.ID)
+		}
+		return fmt.Sprint(ids)
+	}
+
+	if view.Get() != nil || !view.Updated().IsZero() {
+		t.Fatal("Expected an empty view before the first refresh")
+	}
+
+	if err := view.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got := ids(); got != "[1 2]" {
+		t.Errorf("Expected the first 3 stories without the dead one, [1 2], got %s", got)
+	}
+	if view.Updated().IsZero() {
+		t.Error("Expected the snapshot time to be set")
+	}
+
+	// A story that fails to load keeps its previous version
+	mu.Lock()
+	list = `[2, 1, 5]`
+	failing = 1
+	mu.Unlock()
+
+	if err := view.Refresh(ctx); err == nil {
+		t.Error("Expected the failure of story 1")
+	}
+	if got := ids(); got != "[2 1 5]" {
+		t.Errorf("Expected [2 1 5], got %s", got)
+	}
+
+	// A list that fails to load keeps the snapshot
+	server.Close()
+	if err := view.Refresh(ctx); err == nil {
+		t.Error("Expected an error from a closed server")
+	}
+	if got := ids(); got != "[2 1 5]" {
+		t.Errorf("Expected the snapshot to be kept, got %s", got)
+	}
+}
+
+func TestListViewStart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/topstories.json" {
+			_, _ = w.Write([]byte(`[8863]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 8863, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	defer client.Close()
+
+	view := client.NewListView(ListTop, 0, time.Hour)
+	if err := view.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(view.Get()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the view to be refreshed after Start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestListViewStartTwice(t *testing.T) {
+	var lists atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/topstories.json" {
+			lists.Add(1)
+			_, _ = w.Write([]byte(`[8863]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 8863, "type": "story"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+	defer client.Close()
+
+	view := client.NewListView(ListTop, 0, time.Hour)
+	if err := view.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := view.Start(context.Background()); !errors.Is(err, ErrViewStarted) {
+		t.Errorf("Expected ErrViewStarted from a second Start, got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(view.Get()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the view to be refreshed after Start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Only one refresh loop is running, so the list was fetched once
+	time.Sleep(20 * time.Millisecond)
+	if got := lists.Load(); got != 1 {
+		t.Errorf("Expected 1 list fetch, got %d", got)
+	}
+}
@@ -0,0 +1,130 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrStopWalk can be returned by a WalkItems callback to stop the walk early.
+// WalkItems then returns nil.
+var ErrStopWalk = errors.New("stop walk")
+
+// WalkItems fetches the items with IDs from startID to endID inclusive and calls fn
+// for each of them in ascending ID order. Items are fetched ahead of fn with the
+// client's Concurrency, so fn sees a steady stream even though requests complete
+// out of order. IDs that do not exist are skipped.
+//
+// The walk stops at the first fetch error, or when fn returns an error, which is
+// returned unless it is ErrStopWalk. Call options apply to every item fetch; pass
+// SkipDeadAndDeleted to leave out tombstoned items.
+func (c *Client) WalkItems(ctx context.Context, startID, endID int, fn func(*Item) error, opts ...CallOption) error {
+	if endID < startID {
+		return nil
+	}
+
+	ctx, end := c.startOperation(ctx, Operation{Name: "WalkItems", BatchSize: endID - startID + 1})
+	err := c.walkItems(ctx, startID, endID, fn, opts)
+	end(err)
+
+	return err
+}
+
+// walkItems implements WalkItems for a non-empty range.
+func (c *Client) walkItems(ctx context.Context, startID, endID int, fn func(*Item) error, opts []CallOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	o := newCallOptions(opts)
+	skipTombstones := o.skipTombstones
+	progress := newProgressReporter(o.progress, endID-startID+1)
+
+	id := startID
+	pending := c.fetchAhead(ctx, &wg, 2*c.Config.Concurrency, func() (int, bool) {
+		if id > endID {
+			return 0, false
+		}
+		id++
+		return id - 1, true
+	}, opts)
+
+	for result := range pending {
+		r := <-result
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		progress.step(1)
+
+		tombstoned := isTombstone(r.Error)
+		switch {
+		case errors.Is(r.Error, ErrNotFound):
+			continue
+		case r.Error != nil && !tombstoned:
+			return fmt.Errorf("failed to walk items: %w", r.Error)
+		case skipTombstones && (tombstoned || tombstoneError(r.Item) != nil):
+			continue
+		}
+
+		if err := fn(r.Item); err != nil {
+			if errors.Is(err, ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// fetchAhead fetches the IDs returned by next with the client's Concurrency and
+// returns a channel of results in the order of the IDs. At most lookahead results
+// are queued ahead of the consumer. The fetching goroutines are tracked by wg and
+// stop when ctx is canceled; the returned channel is then closed.
+func (c *Client) fetchAhead(ctx context.Context, wg *sync.WaitGroup, lookahead int, next func() (int, bool), opts []CallOption) <-chan chan itemResult {
+	// Fetches are queued in ID order; the queue capacity bounds how far fetching
+	// runs ahead of the consumer
+	pending := make(chan chan itemResult, lookahead)
+	sem := make(chan struct{}, c.Config.Concurrency)
+	opts = bulkOptions(opts)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(pending)
+
+		for {
+			id, ok := next()
+			if !ok {
+				return
+			}
+
+			result := make(chan itemResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				result <- itemResult{ID: id, Error: ctx.Err()}
+				return
+			}
+
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				item, err := c.GetItem(ctx, id, opts...)
+				result <- itemResult{Item: item, ID: id, Error: err}
+			}(id)
+		}
+	}()
+
+	return pending
+}
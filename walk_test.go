@@ -0,0 +1,106 @@
+package hnapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newWalkServer serves items 1-20 except 5 (missing), with 7 deleted and 13 failing.
+func newWalkServer(requests *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/item/"), ".json")
+
+		switch id {
+		case "5":
+			_, _ = w.Write([]byte(`null`))
+		case "7":
+			_, _ = w.Write([]byte(`{"id": 7, "deleted": true}`))
+		case "13":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{"id": ` + id + `, "type": "comment"}`))
+		}
+	}))
+}
+
+func TestWalkItems(t *testing.T) {
+	var requests atomic.Int64
+	server := newWalkServer(&requests)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(3), WithMaxRetries(0))
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		start   int
+		end     int
+		opts    []CallOption
+		want    []int
+		wantErr bool
+	}{
+		{name: "skips missing items", start: 1, end: 8, want: []int{1, 2, 3, 4, 6, 7, 8}},
+		{name: "skips tombstones", start: 6, end: 8, opts: []CallOption{SkipDeadAndDeleted()}, want: []int{6, 8}},
+		{name: "stops at fetch errors", start: 11, end: 15, want: []int{11, 12}, wantErr: true},
+		{name: "empty range", start: 3, end: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			err := client.WalkItems(ctx, tt.start, tt.end, func(item *Item) error {
+				got = append(got, item.ID)
+				return nil
+			}, tt.opts...)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WalkItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WalkItems() visited %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkItemsStop(t *testing.T) {
+	var requests atomic.Int64
+	server := newWalkServer(&requests)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/"), WithConcurrency(2))
+	ctx := context.Background()
+
+	var got []int
+	err := client.WalkItems(ctx, 1, 1000, func(item *Item) error {
+		got = append(got, item.ID)
+		if item.ID == 3 {
+			return ErrStopWalk
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkItems() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("WalkItems() visited %v, want [1 2 3]", got)
+	}
+
+	// Fetching runs only a bounded distance ahead of the callback
+	if n := requests.Load(); n > 10 {
+		t.Errorf("Expected a bounded number of requests after stopping, got %d", n)
+	}
+
+	testErr := errors.New("callback failed")
+	err = client.WalkItems(ctx, 1, 10, func(item *Item) error { return testErr })
+	if !errors.Is(err, testErr) {
+		t.Errorf("Expected the callback error, got %v", err)
+	}
+}
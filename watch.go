@@ -0,0 +1,156 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// WatchItem returns a channel that receives the item with the given ID immediately
+// and then a fresh copy whenever the updates feed reports that it changed, such as
+// when its score changes, it gains new kids, or it is edited.
+//
+// The initial fetch is performed synchronously and its error is returned. Later
+// fetch errors are logged and passed to the ErrorHandler. The returned channel is
+// closed when the context is canceled.
+func (c *Client) WatchItem(ctx context.Context, id int) (<-chan *Item, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := c.GetItem(ctx, id)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to watch item %d: %w", id, err)
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	itemCh := make(chan *Item, 1)
+	itemCh <- item
+
+	c.goBackground(func() { c.runUpdates(ctx, updatesCh, nil) })
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(itemCh)
+
+		for updates := range updatesCh {
+			if !slices.Contains(updates.Items, id) {
+				continue
+			}
+
+			// The feed reported a change, so a cached copy would be stale
+			item, err := c.GetItem(ctx, id, WithNoCache())
+			if err != nil {
+				c.reportWatchError(ctx, err)
+				continue
+			}
+
+			select {
+			case itemCh <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return itemCh, nil
+}
+
+// UserEvent describes a change to a watched user.
+type UserEvent struct {
+	// User is the freshly fetched user profile.
+	User *User
+
+	// NewSubmissions are the IDs of items the user submitted since the previous event,
+	// most recent first.
+	NewSubmissions []int
+}
+
+// WatchUser returns a channel that receives the user immediately and then an event
+// whenever the updates feed reports that the profile changed, such as when karma or
+// the about text changes or the user submits new items. New submissions are found by
+// diffing the user's submitted list against the previous one.
+//
+// The initial fetch is performed synchronously and its error is returned. Later
+// fetch errors are logged and passed to the ErrorHandler. The returned channel is
+// closed when the context is canceled.
+func (c *Client) WatchUser(ctx context.Context, username string) (<-chan UserEvent, error) {
+	ctx, cancel, err := c.startBackground(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.GetUser(ctx, username)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to watch user %s: %w", username, err)
+	}
+
+	updatesCh := c.newUpdatesChannel()
+	eventCh := make(chan UserEvent, 1)
+	eventCh <- UserEvent{User: user}
+
+	c.goBackground(func() { c.runUpdates(ctx, updatesCh, nil) })
+
+	c.goBackground(func() {
+		defer cancel()
+		defer close(eventCh)
+
+		previous := user
+		for updates := range updatesCh {
+			if !slices.Contains(updates.Profiles, username) {
+				continue
+			}
+
+			user, err := c.GetUser(ctx, username, WithNoCache())
+			if err != nil {
+				c.reportWatchError(ctx, err)
+				continue
+			}
+
+			event := UserEvent{
+				User:           user,
+				NewSubmissions: missingIDs(previous.Submitted, user.Submitted),
+			}
+			if user.Karma == previous.Karma && user.About == previous.About && len(event.NewSubmissions) == 0 {
+				continue
+			}
+			previous = user
+
+			select {
+			case eventCh <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return eventCh, nil
+}
+
+// missingIDs returns the IDs in ids that are not in base, preserving order.
+func missingIDs(base, ids []int) []int {
+	seen := make(map[int]struct{}, len(base))
+	for _, id := range base {
+		seen[id] = struct{}{}
+	}
+
+	var added []int
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// reportWatchError logs and reports a watcher error unless the context is done.
+func (c *Client) reportWatchError(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	c.logger().Warn("watch error", "error", err)
+	c.handleError(err)
+}
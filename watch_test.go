@@ -0,0 +1,220 @@
+package hnapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchItem(t *testing.T) {
+	var itemCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/updates.json"):
+			resp = `{"items": [8863, 1], "profiles": []}`
+		case strings.HasSuffix(r.URL.Path, "/item/8863.json"):
+			// Every fetch returns a higher score
+			score := atomic.AddInt32(&itemCalls, 1) * 10
+			resp = fmt.Sprintf(`{"id": 8863, "type": "story", "score": %d}`, score)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(time.Hour),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	itemCh, err := client.WatchItem(ctx, 8863)
+	if err != nil {
+		t.Fatalf("WatchItem() error = %v", err)
+	}
+
+	for _, wantScore := range []int{10, 20} {
+		select {
+		case item := <-itemCh:
+			if item.ID != 8863 || item.Score != wantScore {
+				t.Errorf("Expected item 8863 with score %d, got %+v", wantScore, item)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for item with score %d", wantScore)
+		}
+	}
+
+	cancel()
+	for range itemCh {
+	}
+}
+
+func TestWatchBypassesResponseCache(t *testing.T) {
+	var itemCalls, userCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/updates.json"):
+			resp = `{"items": [8863], "profiles": ["pg"]}`
+		case strings.HasSuffix(r.URL.Path, "/item/8863.json"):
+			score := atomic.AddInt32(&itemCalls, 1)
+			resp = fmt.Sprintf(`{"id": 8863, "type": "story", "score": %d}`, score)
+		case strings.HasSuffix(r.URL.Path, "/user/pg.json"):
+			karma := atomic.AddInt32(&userCalls, 1)
+			resp = fmt.Sprintf(`{"id": "pg", "karma": %d}`, karma)
+		default:
+			resp = "null"
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer server.Close()
+
+	// With caching on, refetches after a change must still reach the API
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(10*time.Millisecond),
+		WithCachePolicy(CachePolicy{
+			Items: EndpointCache{Enabled: true, TTL: 10 * time.Minute},
+			Users: EndpointCache{Enabled: true, TTL: 10 * time.Minute},
+		}),
+	)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	itemCh, err := client.WatchItem(ctx, 8863)
+	if err != nil {
+		t.Fatalf("WatchItem() error = %v", err)
+	}
+	for _, wantScore := range []int{1, 2, 3} {
+		select {
+		case item := <-itemCh:
+			if item.Score != wantScore {
+				t.Fatalf("Expected score %d, got %d", wantScore, item.Score)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for score %d", wantScore)
+		}
+	}
+
+	eventCh, err := client.WatchUser(ctx, "pg")
+	if err != nil {
+		t.Fatalf("WatchUser() error = %v", err)
+	}
+	<-eventCh
+	select {
+	case event := <-eventCh:
+		if event.User.Karma <= 1 {
+			t.Errorf("Expected updated karma, got %d", event.User.Karma)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for a karma change")
+	}
+}
+
+func TestWatchItemNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("null"))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL + "/"))
+
+	if _, err := client.WatchItem(context.Background(), 1); err == nil {
+		t.Errorf("Expected error when watched item does not exist")
+	}
+}
+
+func TestWatchUser(t *testing.T) {
+	var userCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		var resp string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/updates.json"):
+			resp = `{"items": [], "profiles": ["pg", "jl"]}`
+		case strings.HasSuffix(r.URL.Path, "/user/jl.json"):
+			switch atomic.AddInt32(&userCalls, 1) {
+			case 1:
+				resp = `{"id": "jl", "karma": 100, "submitted": [2, 1]}`
+			case 2:
+				// Nothing changed, no event expected
+				resp = `{"id": "jl", "karma": 100, "submitted": [2, 1]}`
+			default:
+				resp = `{"id": "jl", "karma": 105, "submitted": [4, 3, 2, 1]}`
+			}
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			resp = "null"
+		}
+
+		_, err := w.Write([]byte(resp))
+		if err != nil {
+			t.Fatalf("Failed to write mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL+"/"),
+		WithPollInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	eventCh, err := client.WatchUser(ctx, "jl")
+	if err != nil {
+		t.Fatalf("WatchUser() error = %v", err)
+	}
+
+	select {
+	case event := <-eventCh:
+		if event.User.Karma != 100 || len(event.NewSubmissions) != 0 {
+			t.Errorf("Expected initial event with karma 100 and no new submissions, got %+v", event)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for initial event")
+	}
+
+	select {
+	case event := <-eventCh:
+		if event.User.Karma != 105 {
+			t.Errorf("Expected karma 105, got %d", event.User.Karma)
+		}
+		if fmt.Sprint(event.NewSubmissions) != "[4 3]" {
+			t.Errorf("Expected new submissions [4 3], got %v", event.NewSubmissions)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for change event")
+	}
+
+	cancel()
+	for range eventCh {
+	}
+}